@@ -30,5 +30,5 @@ var awsLambdaAdapter = serverless.NewAWSLambdaAdapter(
 )
 
 func main() {
-	lambda.Start(awsLambdaAdapter.ProxyWithContext)
+	lambda.Start(awsLambdaAdapter.HandleWithContext)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"time"
@@ -15,14 +16,26 @@ import (
 	quick_actions "xnku.be/github-quick-actions/internal/quick-actions"
 	"xnku.be/github-quick-actions/pkg/cmd"
 	appv2 "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	"xnku.be/github-quick-actions/pkg/scheduler"
 )
 
 func main() {
 	// define logger before anything
 	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
 
-	config := cmd.CLIConfig{}
-	kong.Parse(&config, kong.Vars{"version": version.Version})
+	var cli struct {
+		Serve   cmd.CLIConfig      `cmd:"" default:"withargs" help:"Start the Github Application webhook server"`
+		GenDocs cmd.GenDocsCommand `cmd:"" name:"gen-docs" help:"Generate the README from the registered quick actions"`
+	}
+	kctx := kong.Parse(&cli, kong.Vars{"version": version.Version})
+
+	if kctx.Command() == "gen-docs" {
+		githubQuickActions := appv2.NewGithubQuickActions(nil)
+		quick_actions.InjectAll(githubQuickActions)
+		kctx.FatalIfErrorf(kctx.Run(githubQuickActions))
+		return
+	}
+	config := cli.Serve
 
 	llvl, _ := zerolog.ParseLevel(config.LogLevel)
 	logger = logger.Level(llvl)
@@ -45,6 +58,34 @@ func main() {
 	logger.Info().Msgf("prepare issues/pull_requests quick actions handlers")
 	githubQuickActions := appv2.NewGithubQuickActions(cc)
 	quick_actions.InjectAll(githubQuickActions)
+	if err := quick_actions.SetDefaultLabelPolicy(config.LabelPolicy); err != nil {
+		logger.Fatal().Err(err).Send()
+	}
+	githubQuickActions.SetFeedback(appv2.Feedback{Reactions: config.FeedbackReactions, Report: config.FeedbackReport})
+	if config.RepositoryConfig {
+		githubQuickActions.EnableRepositoryConfig()
+	}
+	if len(config.PluginsEndpoints) > 0 {
+		githubQuickActions.EnablePlugins(appv2.PluginSettings{
+			Endpoints: config.PluginsEndpoints,
+			Timeout:   config.PluginsTimeout,
+		})
+	}
+
+	var store scheduler.Store
+	if config.StorePath != "" {
+		store, err = scheduler.NewBoltStore(config.StorePath)
+		if err != nil {
+			logger.Fatal().Err(err).Send()
+		}
+		githubQuickActions.SetStore(store)
+	} else {
+		logger.Warn().Msgf("no store configured; scheduled jobs (like /remind) are disabled")
+	}
+
+	// NOTE: the scheduler runs both the scheduled jobs and the sweepers
+	githubQuickActions.SetSweepInterval(config.SweepInterval)
+	go githubQuickActions.RunScheduler(logger.WithContext(context.Background()), config.TickInterval)
 
 	app := githubapp.NewEventDispatcher(
 		[]githubapp.EventHandler{githubQuickActions},
@@ -71,5 +112,13 @@ func main() {
 	}
 
 	logger.Info().Msgf("start listening on %s%s", config.ListenAddr, config.ListenPath)
-	logger.Fatal().Err(srv.ListenAndServe())
+	err = srv.ListenAndServe()
+
+	// NOTE: logger.Fatal exits immediately, so deferred calls would never run
+	if store != nil {
+		if err := store.Close(); err != nil {
+			logger.Error().Err(err).Msgf("failed to close store")
+		}
+	}
+	logger.Fatal().Err(err).Send()
 }
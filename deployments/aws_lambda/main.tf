@@ -5,6 +5,9 @@ locals {
   gateway_logformat_default = "{\"http_method\":\"$context.httpMethod\",\"path\":\"$context.path\",\"request_id\":\"$context.requestId\",\"lambda\":{\"status\":$context.integration.status,\"error\":\"$context.integration.error\"},\"response\":{\"status\":$context.status}}"
   gateway_logformat_verbose = "{\"time\":\"$context.requestTime\",\"protocol\":\"$context.protocol\",\"http_method\":\"$context.httpMethod\",\"gateway_api\":{\"id\":\"$context.apiId\",\"domain\":\"$context.domainName\",\"stage\":\"$context.stage\"},\"path\":\"$context.path\",\"request_id\":\"$context.requestId\",\"source_ip\":\"$context.identity.sourceIp\",\"user-agent\":\"$context.identity.userAgent\",\"lambda\":{\"lambda_status\":$context.integration.integrationStatus,\"status\":$context.integration.status,\"error\":\"$context.integration.error\",\"latency\":$context.integration.latency},\"response\":{\"status\":$context.status,\"latency\":$context.responseLatency,\"length\":$context.responseLength}}"
   gateway_logformat         = var.enable_tracing ? local.gateway_logformat_verbose : local.gateway_logformat_default
+
+  store_enabled    = length(var.store_subnet_ids) > 0
+  store_mount_path = "/mnt/store"
 }
 
 // Publish API Gateway as application ingress
@@ -59,6 +62,14 @@ module "app_lambda" {
     "GQA_GITHUB_APP_ID"         = var.github_app_id
     "GQA_GITHUB_PKEY"           = var.github_b64pkey
     "GQA_GITHUB_WEBHOOK_SECRET" = var.github_webhook_secret
+    "GQA_STORE_PATH"            = local.store_enabled ? "${local.store_mount_path}/jobs.db" : ""
+    "GQA_SWEEP_INTERVAL"        = var.sweep_interval
+    "GQA_LABEL_POLICY"          = var.label_policy
+    "GQA_FEEDBACK_REACTIONS"    = var.feedback_reactions
+    "GQA_FEEDBACK_REPORT"       = var.feedback_report
+    "GQA_REPOSITORY_CONFIG"     = var.repository_config
+    "GQA_PLUGINS_ENDPOINTS"     = join(";", [for url, secret in var.plugins_endpoints : "${url}=${secret}"])
+    "GQA_PLUGINS_TIMEOUT"       = var.plugins_timeout
     "GQA_LOG_LEVEL" : var.app_log_level
   }
 
@@ -67,8 +78,20 @@ module "app_lambda" {
       service    = "apigateway"
       source_arn = "${module.api_gateway.apigatewayv2_api_execution_arn}/*/*"
     },
+    AllowExecutionFromEventBridge = {
+      principal  = "events.amazonaws.com"
+      source_arn = aws_cloudwatch_event_rule.tick.arn
+    },
   }
 
+  // NOTE: the scheduled jobs store is on an EFS volume, shared by all
+  //       function instances; it requires the function to run in a VPC
+  vpc_subnet_ids               = local.store_enabled ? var.store_subnet_ids : null
+  vpc_security_group_ids       = local.store_enabled ? var.store_security_group_ids : null
+  attach_network_policy        = local.store_enabled
+  file_system_arn              = local.store_enabled ? aws_efs_access_point.store[0].arn : null
+  file_system_local_mount_path = local.store_enabled ? local.store_mount_path : null
+
   attach_cloudwatch_logs_policy     = true
   cloudwatch_logs_retention_in_days = 1
   cloudwatch_logs_tags = {
@@ -86,6 +109,73 @@ module "app_lambda" {
 
   // NOTE: disable all unused features
   create_layer = false
+
+  // NOTE: the volume can only be mounted once reachable from the subnets
+  depends_on = [aws_efs_mount_target.store]
+}
+
+// Store scheduled jobs (like /remind) on a volume shared by all function
+// instances
+resource "aws_efs_file_system" "store" {
+  count = local.store_enabled ? 1 : 0
+
+  creation_token = "github-quick-actions-store"
+  encrypted      = true
+
+  tags = {
+    "application.x-amz.com" : local.app_name
+    "version.x-amz.com" : var.app_version
+  }
+}
+
+resource "aws_efs_mount_target" "store" {
+  for_each = local.store_enabled ? toset(var.store_subnet_ids) : toset([])
+
+  file_system_id  = aws_efs_file_system.store[0].id
+  subnet_id       = each.value
+  security_groups = var.store_security_group_ids
+}
+
+resource "aws_efs_access_point" "store" {
+  count = local.store_enabled ? 1 : 0
+
+  file_system_id = aws_efs_file_system.store[0].id
+
+  posix_user {
+    uid = 1000
+    gid = 1000
+  }
+  root_directory {
+    path = "/github-quick-actions"
+    creation_info {
+      owner_uid   = 1000
+      owner_gid   = 1000
+      permissions = "0750"
+    }
+  }
+
+  tags = {
+    "application.x-amz.com" : local.app_name
+    "version.x-amz.com" : var.app_version
+  }
+}
+
+// Run scheduled jobs (like /remind) and periodic tasks (like due date
+// reminders) periodically
+resource "aws_cloudwatch_event_rule" "tick" {
+  name                = "github-quick-actions-tick"
+  description         = "Run Github quick action scheduled tasks"
+  schedule_expression = var.tick_schedule
+
+  tags = {
+    "application.x-amz.com" : local.app_name
+    "version.x-amz.com" : var.app_version
+  }
+}
+
+resource "aws_cloudwatch_event_target" "tick" {
+  rule = aws_cloudwatch_event_rule.tick.name
+  arn  = module.app_lambda.lambda_function_arn
 }
 
 data "archive_file" "app_archive" {
@@ -42,6 +42,66 @@ variable "github_webhook_secret" {
   type        = string
 }
 
+variable "store_subnet_ids" {
+  description = "Subnets where the EFS volume of the scheduled jobs store is mounted; the function runs in these subnets, which must reach Github through a NAT gateway (scheduled jobs like /remind are disabled if empty)."
+  type        = list(string)
+  default     = []
+}
+variable "store_security_group_ids" {
+  description = "Security groups of the function and of the EFS volume; they must allow NFS between them (only used if store_subnet_ids is set)."
+  type        = list(string)
+  default     = []
+}
+variable "tick_schedule" {
+  description = "Schedule expression of the scheduled tasks runner."
+  type        = string
+  default     = "rate(1 minute)"
+}
+variable "sweep_interval" {
+  description = "Minimum interval between two runs of the periodic tasks (like due date reminders)."
+  type        = string
+  default     = "1h"
+}
+
+variable "label_policy" {
+  description = "How /label handles labels missing from the repository (allow, create or reject)."
+  type        = string
+  default     = "allow"
+
+  validation {
+    condition     = contains(["allow", "create", "reject"], var.label_policy)
+    error_message = "The label_policy value must be one of allow, create or reject."
+  }
+}
+
+variable "feedback_reactions" {
+  description = "Add reactions on comments containing quick actions (👀 on receipt, then 👍 or 😕)."
+  type        = bool
+  default     = true
+}
+variable "feedback_report" {
+  description = "Reply to comments containing quick actions with the result of each command."
+  type        = bool
+  default     = false
+}
+variable "repository_config" {
+  description = "Read the configuration of each repository from .github/quick-actions.yml, inheriting from the organization .github repository."
+  type        = bool
+  default     = true
+}
+
+variable "plugins_endpoints" {
+  description = "URL prefixes plugins can be declared with, and the secret used to sign the requests sent to each of them (plugins are disabled if empty)."
+  sensitive   = true
+  type        = map(string)
+  default     = {}
+}
+variable "plugins_timeout" {
+  description = "Timeout of each plugin request."
+  type        = string
+  default     = "10s"
+}
+
 variable "app_log_level" {
   description = "Application log level."
   type        = string
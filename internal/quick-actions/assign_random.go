@@ -0,0 +1,309 @@
+package quick_actions
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+	"github.com/thoas/go-funk"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+type (
+	// AssignRandomQuickAction implements QuickAction interface for /assign_random
+	// command.
+	// This quick action requests reviews from randomly chosen code owners
+	// of the changed files (or members of the given team), like
+	// `/assign_random 2 @org/team`. Members with a limited availability are
+	// excluded and the choice is weighted by the number of open review
+	// requests of each member.
+	AssignRandomQuickAction struct{ githubEventHelper }
+
+	// reviewerCandidate represents a user who can be requested for review.
+	reviewerCandidate struct {
+		login string
+		// openReviewRequests is the number of open pull requests where a
+		// review is requested from the user.
+		openReviewRequests int
+	}
+)
+
+// randomFloat64 returns a random number in [0.0,1.0); it can be replaced in
+// tests.
+// NOTE: commands can be handled concurrently, so the (locked) top-level
+//		 source must be used instead of a dedicated *rand.Rand
+var randomFloat64 = rand.Float64
+
+func (qa AssignRandomQuickAction) TriggerOnEvents() []EventType {
+	// NOTE: assign_random should be triggered on pull requests description too
+	return []EventType{EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment}
+}
+
+func (qa AssignRandomQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{
+		{Name: "n", Kind: ArgumentKindNumber},
+		{Name: "team", Kind: ArgumentKindTeam},
+	}
+}
+
+func (qa AssignRandomQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa AssignRandomQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/assign_random [<n>] [@org/team]"},
+		Description: "Request reviews from `<n>` (1 by default) random code owners of the changed files, or members of the given team.",
+		Details:     "The author and users with a limited availability (Github busy status) are excluded; users with fewer open review requests are more likely to be chosen.",
+		Examples:    []string{"/assign_random", "/assign_random 2 @xunleii/maintainers"},
+	}
+}
+
+func (qa AssignRandomQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "assign_random").
+		Logger()
+
+	logger.Info().Msgf("handle `/assign_random` (args: %v)", command.Arguments)
+
+	if !qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/assign_random can only be used on pull requests; ignored")
+		return nil
+	}
+
+	count := 1
+	if n, exists := command.Values.Get("n"); exists {
+		count = n.Number
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	pr, err := qa.getPullRequest(ctx, client, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	var owners []string
+	if team, exists := command.Values.Get("team"); exists {
+		owners = []string{"@" + team.Value}
+	} else if owners, err = qa.getCodeowners(ctx, client, command.Payload, pr); err != nil {
+		return err
+	}
+
+	logins, err := qa.expandOwners(ctx, client, owners)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the author and the users already requested cannot be chosen
+	excluded := []string{pr.GetUser().GetLogin()}
+	for _, reviewer := range pr.RequestedReviewers {
+		excluded = append(excluded, reviewer.GetLogin())
+	}
+
+	v4client, err := ctx.NewGraphQLClient(command.Payload)
+	if err != nil {
+		return err
+	}
+
+	var candidates []reviewerCandidate
+	for _, login := range logins {
+		if funk.ContainsString(excluded, login) {
+			continue
+		}
+
+		candidate, available, err := qa.getReviewerCandidate(ctx, v4client, command.Payload.RepositoryOwner(), login)
+		if err != nil {
+			return err
+		}
+		if !available {
+			logger.Debug().Msgf("@%s has a limited availability; ignored", login)
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	if len(candidates) == 0 {
+		return fmt.Errorf("no available reviewer found")
+	}
+
+	reviewers := pickReviewers(candidates, count, randomFloat64)
+	_, _, err = client.PullRequests.RequestReviewers(
+		ctx,
+		command.Payload.RepositoryOwner(),
+		command.Payload.RepositoryName(),
+		command.Payload.IssueNumber(),
+		github.ReviewersRequest{Reviewers: reviewers},
+	)
+	return err
+}
+
+// getCodeowners returns the owners of all files changed by the given pull
+// request, based on the CODEOWNERS file of its base branch.
+func (AssignRandomQuickAction) getCodeowners(ctx *EventContext, client *github.Client, payload EventPayload, pr *github.PullRequest) ([]string, error) {
+	var rules codeowners
+	for _, location := range codeownersLocations {
+		file, _, _, err := client.Repositories.GetContents(
+			ctx,
+			payload.RepositoryOwner(),
+			payload.RepositoryName(),
+			location,
+			&github.RepositoryContentGetOptions{Ref: pr.GetBase().GetRef()},
+		)
+		if isNotFound(err) || (err == nil && file == nil) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", location, err)
+		}
+		rules = parseCodeowners(content)
+		break
+	}
+	if rules == nil {
+		return nil, fmt.Errorf("no CODEOWNERS file found; use `/assign_random @org/team` instead")
+	}
+
+	var owners []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := client.PullRequests.ListFiles(
+			ctx,
+			payload.RepositoryOwner(),
+			payload.RepositoryName(),
+			payload.IssueNumber(),
+			opts,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			owners = append(owners, rules.owners(file.GetFilename())...)
+		}
+
+		if resp.NextPage == 0 {
+			return funk.UniqString(owners), nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// expandOwners returns the logins of the given owners, where teams (like
+// `@org/team`) are replaced by their members; emails are ignored.
+func (AssignRandomQuickAction) expandOwners(ctx *EventContext, client *github.Client, owners []string) ([]string, error) {
+	var logins []string
+	for _, owner := range owners {
+		if !strings.HasPrefix(owner, "@") {
+			// NOTE: emails cannot be resolved to users
+			continue
+		}
+
+		idx := strings.Index(owner, "/")
+		if idx < 0 {
+			logins = append(logins, owner[1:])
+			continue
+		}
+
+		org, slug := owner[1:idx], owner[idx+1:]
+		opts := &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for {
+			members, resp, err := client.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list members of %s: %w", owner, err)
+			}
+
+			for _, member := range members {
+				logins = append(logins, member.GetLogin())
+			}
+
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+	}
+
+	logins = funk.UniqString(logins)
+	sort.Strings(logins)
+	return logins, nil
+}
+
+// getReviewerCandidate returns the number of open review requests of the
+// given user, in the repositories of the given owner, and whether the user
+// is available (Github status without limited availability).
+func (AssignRandomQuickAction) getReviewerCandidate(ctx *EventContext, client *githubv4.Client, owner, login string) (reviewerCandidate, bool, error) {
+	var query struct {
+		User struct {
+			Status *struct {
+				IndicatesLimitedAvailability bool
+			}
+		} `graphql:"user(login: $login)"`
+		Search struct {
+			IssueCount int
+		} `graphql:"search(query: $query, type: ISSUE, first: 1)"`
+	}
+
+	err := client.Query(ctx, &query, map[string]interface{}{
+		"login": githubv4.String(login),
+		"query": githubv4.String(fmt.Sprintf("is:pr is:open user:%s review-requested:%s", owner, login)),
+	})
+	if err != nil {
+		return reviewerCandidate{}, false, fmt.Errorf("failed to get @%s availability: %w", login, err)
+	}
+
+	available := query.User.Status == nil || !query.User.Status.IndicatesLimitedAvailability
+	return reviewerCandidate{login: login, openReviewRequests: query.Search.IssueCount}, available, nil
+}
+
+// pickReviewers randomly picks n reviewers from the given candidates (or all
+// candidates if there are not enough); each candidate has a weight inversely
+// proportional to its number of open review requests.
+func pickReviewers(candidates []reviewerCandidate, n int, random func() float64) []string {
+	candidates = append([]reviewerCandidate{}, candidates...)
+
+	var reviewers []string
+	for len(reviewers) < n && len(candidates) > 0 {
+		total := 0.
+		for _, candidate := range candidates {
+			total += candidate.weight()
+		}
+
+		idx, r := len(candidates)-1, random()*total
+		for i, candidate := range candidates {
+			if r < candidate.weight() {
+				idx = i
+				break
+			}
+			r -= candidate.weight()
+		}
+
+		reviewers = append(reviewers, candidates[idx].login)
+		candidates = append(candidates[:idx], candidates[idx+1:]...)
+	}
+	return reviewers
+}
+
+func (candidate reviewerCandidate) weight() float64 {
+	return 1 / float64(1+candidate.openReviewRequests)
+}
+
+func init() {
+	// NOTE: the top-level source is deterministic until seeded
+	rand.Seed(time.Now().UnixNano())
+
+	// NOTE: register quick actions
+	registerQuickAction("assign_random", &AssignRandomQuickAction{})
+}
@@ -0,0 +1,76 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestPickReviewers(t *testing.T) {
+	candidates := []reviewerCandidate{
+		{login: "xunleii", openReviewRequests: 3}, // weight: 0.25
+		{login: "mojombo", openReviewRequests: 0}, // weight: 1
+		{login: "octocat", openReviewRequests: 1}, // weight: 0.5
+	}
+	random := func(values ...float64) func() float64 {
+		return func() float64 {
+			value := values[0]
+			values = values[1:]
+			return value
+		}
+	}
+
+	ts := map[string]struct {
+		n         int
+		random    func() float64
+		reviewers []string
+	}{
+		"first candidate":       {n: 1, random: random(0.1), reviewers: []string{"xunleii"}},
+		"most available":        {n: 1, random: random(0.5), reviewers: []string{"mojombo"}},
+		"last candidate":        {n: 1, random: random(0.99), reviewers: []string{"octocat"}},
+		"without replacement":   {n: 2, random: random(0.5, 0.5), reviewers: []string{"mojombo", "octocat"}},
+		"not enough candidates": {n: 5, random: random(0, 0, 0), reviewers: []string{"xunleii", "mojombo", "octocat"}},
+		"random close to 1":     {n: 1, random: random(0.9999999), reviewers: []string{"octocat"}},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.reviewers, pickReviewers(candidates, tc.n, tc.random))
+		})
+	}
+}
+
+func TestAssignRandom_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment},
+		AssignRandomQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestAssignRandomFeature(t *testing.T) {
+	events := AssignRandomQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"assign_random": &AssignRandomQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("assign_random && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
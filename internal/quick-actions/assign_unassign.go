@@ -25,6 +25,21 @@ func (qa AssignQuickAction) TriggerOnEvents() []EventType {
 	// NOTE: assign should be triggered on issues & pull requests description
 	return []EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment}
 }
+func (qa AssignQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "user", Kind: ArgumentKindUser, Required: true, Variadic: true}}
+}
+func (qa AssignQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa AssignQuickAction) Metadata() Metadata {
+	return Metadata{
+		Description: "Assign one or more users.",
+		Details:     "Use `me` to assign yourself.",
+		Examples:    []string{"/assign me", "/assign @octocat @hubot"},
+	}
+}
+
 func (qa AssignQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
 	logger := zerolog.Ctx(ctx).With().
 		Str("quick_action", "assign").
@@ -54,6 +69,23 @@ func (qa AssignQuickAction) HandleCommand(ctx *EventContext, command *EventComma
 	return err
 }
 
+func (qa UnassignQuickAction) Arguments() []ArgumentSpec {
+	// NOTE: all assignees are removed if no user is given
+	return []ArgumentSpec{{Name: "user", Kind: ArgumentKindUser, Variadic: true}}
+}
+func (qa UnassignQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa UnassignQuickAction) Metadata() Metadata {
+	return Metadata{
+		Description: "Remove one or more assignees, or all of them.",
+		Details:     "Use `me` to remove yourself.",
+		Aliases:     []string{"remove_assignees"},
+		Examples:    []string{"/unassign", "/unassign me"},
+	}
+}
+
 func (qa UnassignQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
 	logger := zerolog.Ctx(ctx).With().
 		Str("quick_action", "unassign").
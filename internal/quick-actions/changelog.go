@@ -0,0 +1,330 @@
+package quick_actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/rs/zerolog"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+const (
+	// changelogName is the name used to identify the changelog metadata.
+	changelogName = "changelog"
+	// changelogStatusContext is the commit status used to block the merge
+	// of pull requests without changelog entry through the branch
+	// protection.
+	changelogStatusContext = "quick-actions/changelog"
+)
+
+type (
+	// ChangelogQuickAction implements QuickAction interface for /changelog
+	// command.
+	// This quick action records the release note entry of a PR, like
+	// `/changelog fixed "Crash on empty comments"` (or `/changelog none`),
+	// and sets a commit status failing until an entry is recorded.
+	// It also implements EventHandler in order to set this status on new
+	// pull requests and new commits, on repositories opting in.
+	ChangelogQuickAction struct{ githubEventHelper }
+	// ReleaseNotesQuickAction implements QuickAction interface for
+	// /release_notes command.
+	// This quick action renders the changelog entries of all PRs merged
+	// between two tags, like `/release_notes v1.0.0 v1.1.0`.
+	ReleaseNotesQuickAction struct{ githubEventHelper }
+
+	// changelogData contains the changelog entry stored in the pull request
+	// description.
+	changelogData struct {
+		Kind string `json:"kind,omitempty"`
+		Text string `json:"text,omitempty"`
+		// None is true when the pull request doesn't require any entry.
+		None bool `json:"none,omitempty"`
+	}
+)
+
+// changelogKinds contains all valid changelog entry kinds, in the order
+// they are rendered in release notes.
+var changelogKinds = []struct{ kind, title string }{
+	{"added", "Added"},
+	{"changed", "Changed"},
+	{"fixed", "Fixed"},
+}
+
+func (qa ChangelogQuickAction) TriggerOnEvents() []EventType {
+	// NOTE: changelog should be triggered on pull requests description too
+	return []EventType{EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment}
+}
+
+func (qa ChangelogQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage, AllowAuthor: true}
+}
+
+func (qa ChangelogQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/changelog added|fixed|changed <text>", "/changelog none"},
+		Description: "Record the release note entry of the pull request, or mark it as not required.",
+		Details:     "A `quick-actions/changelog` commit status fails until an entry (or `none`) is recorded; on new pull requests and commits, it is only set if `changelog` is listed in the `enabled` setting of the repository.",
+		Examples:    []string{`/changelog fixed "Crash on empty comments"`, "/changelog none"},
+	}
+}
+
+func (qa ChangelogQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "changelog").
+		Logger()
+
+	logger.Info().Msgf("handle `/changelog` (args: %v)", command.Arguments)
+
+	if !qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/changelog can only be used on pull requests; ignored")
+		return nil
+	}
+
+	data, err := parseChangelogEntry(command.Arguments)
+	if err != nil {
+		return err
+	}
+
+	block, err := encodeMetadata(changelogName, data)
+	if err != nil {
+		return err
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	pr, err := qa.getPullRequest(ctx, client, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	body := replaceMetadata(pr.GetBody(), changelogName, block)
+	_, _, err = client.Issues.Edit(
+		ctx,
+		command.Payload.RepositoryOwner(),
+		command.Payload.RepositoryName(),
+		command.Payload.IssueNumber(),
+		&github.IssueRequest{Body: github.String(body)},
+	)
+	if err != nil {
+		return err
+	}
+
+	return qa.setChangelogStatus(ctx, client, command.Payload, pr.GetHead().GetSHA(), data)
+}
+
+func (qa ChangelogQuickAction) TriggerOnActions() map[EventType][]EventAction {
+	return map[EventType][]EventAction{EventTypePullRequest: {EventActionOpened, EventActionReopened, EventActionSynchronize}}
+}
+func (qa ChangelogQuickAction) HandledCommand() (string, bool) {
+	// NOTE: the changelog status blocks pull requests, so it is only set on
+	//		 repositories explicitly enabling /changelog
+	return "changelog", true
+}
+func (qa ChangelogQuickAction) HandleEvent(ctx *EventContext, payload EventPayload) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("event_handler", "changelog_status").
+		Logger()
+
+	event, valid := payload.Raw().(*github.PullRequestEvent)
+	if !valid {
+		return fmt.Errorf("invalid event type %T", payload.Raw())
+	}
+
+	data := &changelogData{}
+	found, err := decodeMetadata(event.GetPullRequest().GetBody(), changelogName, data)
+	if err != nil {
+		return err
+	}
+	if !found {
+		data = nil
+	}
+
+	client, err := qa.newInstallationClient(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("set changelog status on head %s", event.GetPullRequest().GetHead().GetSHA())
+	return qa.setChangelogStatus(ctx, client, payload, event.GetPullRequest().GetHead().GetSHA(), data)
+}
+
+// setChangelogStatus sets the changelog commit status on the given commit,
+// failing if no entry has been recorded (nil data).
+func (qa ChangelogQuickAction) setChangelogStatus(ctx *EventContext, client *github.Client, payload EventPayload, sha string, data *changelogData) error {
+	status := &github.RepoStatus{
+		State:       github.String("failure"),
+		Context:     github.String(changelogStatusContext),
+		Description: github.String("Missing changelog entry; use /changelog added|fixed|changed \"text\" or /changelog none"),
+	}
+
+	switch {
+	case data == nil:
+	case data.None:
+		status.State = github.String("success")
+		status.Description = github.String("No changelog entry required")
+	default:
+		status.State = github.String("success")
+		status.Description = github.String(fmt.Sprintf("Changelog entry recorded (%s)", data.Kind))
+	}
+
+	return qa.setCommitStatus(ctx, client, payload, sha, status)
+}
+
+func (qa ReleaseNotesQuickAction) TriggerOnEvents() []EventType {
+	return []EventType{EventTypeIssueComment}
+}
+
+func (qa ReleaseNotesQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa ReleaseNotesQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/release_notes <from-tag> <to-tag>"},
+		Description: "Post the release notes built from the `/changelog` entries of all pull requests merged between the two tags.",
+		Examples:    []string{"/release_notes v1.1.0 v1.2.0"},
+	}
+}
+
+func (qa ReleaseNotesQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "release_notes").
+		Logger()
+
+	logger.Info().Msgf("handle `/release_notes` (args: %v)", command.Arguments)
+
+	if len(command.Arguments) != 2 {
+		return fmt.Errorf("/release_notes requires two tags (like `/release_notes v1.0.0 v1.1.0`)")
+	}
+	from, to := command.Arguments[0], command.Arguments[1]
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	prs, err := qa.listMergedPullRequests(ctx, client, command.Payload, from, to)
+	if err != nil {
+		return err
+	}
+
+	return qa.createComment(ctx, client, command.Payload, renderReleaseNotes(from, to, prs))
+}
+
+// listMergedPullRequests returns all pull requests merged between the two
+// given tags (or any other git references), ordered by merge.
+func (ReleaseNotesQuickAction) listMergedPullRequests(ctx *EventContext, client *github.Client, payload EventPayload, from, to string) ([]*github.PullRequest, error) {
+	owner, repo := payload.RepositoryOwner(), payload.RepositoryName()
+
+	var commits []*github.RepositoryCommit
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, from, to, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s...%s: %w", from, to, err)
+		}
+		commits = append(commits, comparison.Commits...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var prs []*github.PullRequest
+	found := map[int]bool{}
+	for _, commit := range commits {
+		commitPRs, _, err := client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, commit.GetSHA(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range commitPRs {
+			if pr.MergedAt == nil || found[pr.GetNumber()] {
+				continue
+			}
+			found[pr.GetNumber()] = true
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
+
+// parseChangelogEntry parses the /changelog arguments.
+func parseChangelogEntry(args []string) (*changelogData, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("/changelog requires a kind (`added`, `fixed` or `changed`) and a text, or `none`")
+	}
+
+	kind := strings.ToLower(args[0])
+	if kind == "none" && len(args) == 1 {
+		return &changelogData{None: true}, nil
+	}
+
+	for _, changelogKind := range changelogKinds {
+		if changelogKind.kind != kind {
+			continue
+		}
+
+		text := strings.TrimSpace(strings.Join(args[1:], " "))
+		if text == "" {
+			return nil, fmt.Errorf("/changelog %s requires a text (like `/changelog %s \"Some change\"`)", kind, kind)
+		}
+		return &changelogData{Kind: kind, Text: text}, nil
+	}
+	return nil, fmt.Errorf("invalid changelog kind '%s' (expected `added`, `fixed`, `changed` or `none`)", args[0])
+}
+
+// renderReleaseNotes generates the release notes of the given pull
+// requests, grouped by changelog entry kind.
+func renderReleaseNotes(from, to string, prs []*github.PullRequest) string {
+	entries := map[string][]string{}
+	var missing []string
+	for _, pr := range prs {
+		data := &changelogData{}
+		found, err := decodeMetadata(pr.GetBody(), changelogName, data)
+		switch {
+		case err != nil || !found:
+			missing = append(missing, fmt.Sprintf("#%d", pr.GetNumber()))
+		case data.None:
+		default:
+			entries[data.Kind] = append(entries[data.Kind], fmt.Sprintf("- %s (#%d)", data.Text, pr.GetNumber()))
+		}
+	}
+
+	notes := &strings.Builder{}
+	fmt.Fprintf(notes, "## Changelog (%s...%s)\n", from, to)
+
+	empty := true
+	for _, changelogKind := range changelogKinds {
+		if len(entries[changelogKind.kind]) == 0 {
+			continue
+		}
+
+		empty = false
+		fmt.Fprintf(notes, "\n### %s\n\n", changelogKind.title)
+		notes.WriteString(strings.Join(entries[changelogKind.kind], "\n") + "\n")
+	}
+	if empty {
+		notes.WriteString("\n_No changelog entry._\n")
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintf(notes, "\n_Pull requests without changelog entry: %s._\n", strings.Join(missing, ", "))
+	}
+	return notes.String()
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("changelog", &ChangelogQuickAction{})
+	registerQuickAction("release_notes", &ReleaseNotesQuickAction{})
+
+	// NOTE: register event handlers
+	registerEventHandler("changelog_status", &ChangelogQuickAction{})
+}
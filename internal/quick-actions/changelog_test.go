@@ -0,0 +1,97 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestChangelog_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment},
+		ChangelogQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestChangelog_HandledCommand(t *testing.T) {
+	// NOTE: the changelog status is opt-in
+	command, optIn := ChangelogQuickAction{}.HandledCommand()
+	assert.Equal(t, "changelog", command)
+	assert.True(t, optIn)
+}
+
+func TestChangelogFeature(t *testing.T) {
+	events := ChangelogQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"changelog": &ChangelogQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("changelog && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestChangelogStatusEventHandlerFeature(t *testing.T) {
+	for event := range (ChangelogQuickAction{}).TriggerOnActions() {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializerWithHandlers(nil, map[string]EventHandler{"changelog_status": &ChangelogQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("changelog_status && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestReleaseNotes_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment},
+		ReleaseNotesQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestReleaseNotesFeature(t *testing.T) {
+	events := ReleaseNotesQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"release_notes": &ReleaseNotesQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("release_notes && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package quick_actions
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+)
+
+type (
+	// codeowners contains the rules of a CODEOWNERS file.
+	codeowners []codeownersRule
+
+	// codeownersRule associates a path pattern to its owners (users like
+	// `@user`, teams like `@org/team` or emails).
+	codeownersRule struct {
+		pattern *regexp.Regexp
+		owners  []string
+	}
+)
+
+// codeownersLocations contains all locations where Github looks for the
+// CODEOWNERS file, by priority.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// parseCodeowners parses the content of a CODEOWNERS file; invalid lines
+// are ignored, like Github does.
+func parseCodeowners(content string) codeowners {
+	var rules codeowners
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		pattern, err := codeownersPatternRegexp(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, codeownersRule{pattern: pattern, owners: fields[1:]})
+	}
+	return rules
+}
+
+// owners returns the owners of the given file; the last matching rule takes
+// precedence.
+func (rules codeowners) owners(path string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].pattern.MatchString(path) {
+			return rules[i].owners
+		}
+	}
+	return nil
+}
+
+// codeownersPatternRegexp converts a CODEOWNERS pattern (which follows most
+// of the gitignore rules) into a regular expression matching file paths.
+func codeownersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	// NOTE: patterns without slash (except a trailing one) match at any
+	//		 depth; the others are relative to the repository root
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	expr := &strings.Builder{}
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	// NOTE: patterns also match everything inside the matching directories
+	if strings.HasSuffix(pattern, "/") {
+		expr.WriteString(".*$")
+	} else {
+		expr.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expr.String())
+}
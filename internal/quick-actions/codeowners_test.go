@@ -0,0 +1,48 @@
+package quick_actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeowners_owners(t *testing.T) {
+	rules := parseCodeowners(`
+# Default owners
+*                   @xunleii
+
+*.go                @octocat # Go files
+/docs/              @org/docs
+apps/               @mojombo
+/build/logs/        @defunkt
+**/fixtures/**      @org/qa
+/scripts/*.sh       @pjhyett
+invalid-owner
+`)
+
+	ts := map[string][]string{
+		"README.md":                         {"@xunleii"},
+		"main.go":                           {"@octocat"},
+		"pkg/cmd/config.go":                 {"@octocat"},
+		"docs/README.md":                    {"@org/docs"},
+		"docs/api/main.go":                  {"@org/docs"},
+		"pkg/docs/README.md":                {"@xunleii"},
+		"apps/web/index.js":                 {"@mojombo"},
+		"pkg/apps/web/index.js":             {"@mojombo"},
+		"build/logs/output.log":             {"@defunkt"},
+		"pkg/build/logs/output.log":         {"@xunleii"},
+		"test/fixtures/events/issue.json":   {"@org/qa"},
+		"fixtures/events/issue.json":        {"@org/qa"},
+		"scripts/release.sh":                {"@pjhyett"},
+		"scripts/ci/lint.sh":                {"@xunleii"},
+		"invalid-owner":                     {},
+		"invalid-owner/but/valid/directory": {},
+	}
+
+	for path, owners := range ts {
+		path, owners := path, owners
+		t.Run(path, func(t *testing.T) {
+			assert.ElementsMatch(t, owners, rules.owners(path))
+		})
+	}
+}
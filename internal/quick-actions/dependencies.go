@@ -0,0 +1,446 @@
+package quick_actions
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"github.com/thoas/go-funk"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+const (
+	// dependenciesName is the name used to identify the dependencies
+	// metadata, used when the issue dependencies API is unavailable.
+	dependenciesName = "dependencies"
+	// blockedStatusContext is the commit status used to block the merge of
+	// pull requests with open blockers.
+	blockedStatusContext = "quick-actions/blocked"
+	// commitStatusDescriptionMaxLength is the maximum length of a commit
+	// status description (defined by Github).
+	commitStatusDescriptionMaxLength = 140
+)
+
+type (
+	// BlockedByQuickAction implements QuickAction interface for /blocked_by
+	// command. This quick action records the issues (or pull requests)
+	// blocking the current one and, on pull requests, sets a failing commit
+	// status until every blocker is closed.
+	// It also implements EventHandler in order to update the status of
+	// blocked pull requests when a blocker is closed or reopened.
+	BlockedByQuickAction struct{ dependenciesHelper }
+	// BlocksQuickAction implements QuickAction interface for /blocks command.
+	// This quick action records the issues (or pull requests) blocked by
+	// the current one.
+	BlocksQuickAction struct{ dependenciesHelper }
+
+	// dependenciesHelper implements methods to manage issue dependencies,
+	// through the issue dependencies API or through the description
+	// metadata when this API is unavailable.
+	dependenciesHelper struct{ githubEventHelper }
+
+	// dependenciesData contains the blockers stored in the issue (or pull
+	// request) description.
+	dependenciesData struct {
+		BlockedBy []string `json:"blocked_by"`
+	}
+
+	// issueDependency represents a blocker of an issue (or pull request).
+	issueDependency struct {
+		ref  issueReference
+		open bool
+	}
+)
+
+func (qa BlockedByQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "issue", Kind: ArgumentKindIssueRef, Required: true, Variadic: true}}
+}
+
+func (qa BlockedByQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa BlockedByQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/blocked_by <issue> [<issue>...]"},
+		Description: "Record the issues or pull requests blocking the current one.",
+		Details:     "On pull requests, a failing `quick-actions/blocked` commit status is set until every blocker is closed. Dependencies are stored in the description when the issue dependencies API is unavailable.",
+		Examples:    []string{"/blocked_by #12 owner/repo#5"},
+	}
+}
+
+func (qa BlockedByQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "blocked_by").
+		Logger()
+
+	logger.Info().Msgf("handle `/blocked_by` (args: %v)", command.Arguments)
+
+	current := qa.getIssueReference(command.Payload)
+	blockers, err := qa.dependencies(command)
+	if err != nil {
+		return err
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	for _, blocker := range blockers {
+		if err := qa.addDependency(ctx, client, current, blocker); err != nil {
+			return err
+		}
+	}
+
+	if !qa.isPullRequest(command.Payload) {
+		return nil
+	}
+	return qa.updateBlockedStatus(ctx, client, current)
+}
+
+func (qa BlockedByQuickAction) TriggerOnActions() map[EventType][]EventAction {
+	return map[EventType][]EventAction{
+		EventTypeIssue:       {EventActionClosed, EventActionReopened},
+		EventTypePullRequest: {EventActionClosed, EventActionReopened, EventActionSynchronize},
+	}
+}
+func (qa BlockedByQuickAction) HandledCommand() (string, bool) {
+	return "blocked_by", false
+}
+func (qa BlockedByQuickAction) HandleEvent(ctx *EventContext, payload EventPayload) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("event_handler", "blocked_by").
+		Logger()
+
+	client, err := qa.newInstallationClient(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	current := qa.getIssueReference(payload)
+	if payload.Action() == EventActionSynchronize {
+		event, valid := payload.Raw().(*github.PullRequestEvent)
+		if !valid {
+			return fmt.Errorf("invalid event type %T", payload.Raw())
+		}
+
+		blockers, err := qa.listBlockers(ctx, client, current, event.GetPullRequest().GetBody())
+		if err != nil {
+			return err
+		}
+		if len(blockers) == 0 {
+			logger.Debug().Msgf("pull request not blocked; ignored")
+			return nil
+		}
+
+		logger.Info().Msgf("keep blocked status on new head %s", event.GetPullRequest().GetHead().GetSHA())
+		return qa.setBlockedStatus(ctx, client, current, event.GetPullRequest().GetHead().GetSHA(), blockers)
+	}
+
+	dependents, err := qa.listBlockedPullRequests(ctx, client, current)
+	if err != nil {
+		return err
+	}
+	if len(dependents) == 0 {
+		logger.Debug().Msgf("no pull request blocked by %s; ignored", current)
+		return nil
+	}
+
+	logger.Info().Msgf("%s has been %s, update %d blocked pull request(s)", current, payload.Action(), len(dependents))
+	errs := &multierror.Error{}
+	for _, dependent := range dependents {
+		errs = multierror.Append(errs, qa.updateBlockedStatus(ctx, client, dependent))
+	}
+	return errs.ErrorOrNil()
+}
+
+func (qa BlocksQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "issue", Kind: ArgumentKindIssueRef, Required: true, Variadic: true}}
+}
+
+func (qa BlocksQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa BlocksQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/blocks <issue> [<issue>...]"},
+		Description: "Record the issues or pull requests blocked by the current one.",
+		Examples:    []string{"/blocks #34"},
+	}
+}
+
+func (qa BlocksQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "blocks").
+		Logger()
+
+	logger.Info().Msgf("handle `/blocks` (args: %v)", command.Arguments)
+
+	current := qa.getIssueReference(command.Payload)
+	dependents, err := qa.dependencies(command)
+	if err != nil {
+		return err
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	for _, dependent := range dependents {
+		if err := qa.addDependency(ctx, client, dependent, current); err != nil {
+			return err
+		}
+		if err := qa.updateBlockedStatus(ctx, client, dependent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dependenciesHelper) TriggerOnEvents() []EventType {
+	return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
+}
+
+// dependencies returns the issues referenced by the given command, rejecting
+// the issue (or pull request) where the event comes from.
+func (qa dependenciesHelper) dependencies(command *EventCommand) ([]issueReference, error) {
+	current := qa.getIssueReference(command.Payload)
+
+	var refs []issueReference
+	for _, issue := range command.Values["issue"] {
+		ref := issueReferenceFrom(issue)
+		if ref == current {
+			return nil, fmt.Errorf("%s cannot depend on itself", ref)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// dependenciesURL returns the issue dependencies API URL of the given issue.
+func dependenciesURL(ref issueReference, kind string) string {
+	return fmt.Sprintf("repos/%s/%s/issues/%d/dependencies/%s", ref.Owner, ref.Repo, ref.Number, kind)
+}
+
+// addDependency records that the given issue is blocked by the given
+// blocker. The dependency is stored in the blocked issue description when
+// the issue dependencies API is unavailable.
+func (qa dependenciesHelper) addDependency(ctx *EventContext, client *github.Client, blocked, blocker issueReference) error {
+	issue, _, err := client.Issues.Get(ctx, blocker.Owner, blocker.Repo, blocker.Number)
+	if err != nil {
+		return fmt.Errorf("failed to find issue %s: %w", blocker, err)
+	}
+
+	// NOTE: go-github doesn't manage issue dependencies yet
+	req, err := client.NewRequest(http.MethodPost, dependenciesURL(blocked, "blocked_by"), map[string]int64{"issue_id": issue.GetID()})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(ctx, req, nil)
+	if !isNotFound(err) {
+		return err
+	}
+
+	blockedIssue, _, err := client.Issues.Get(ctx, blocked.Owner, blocked.Repo, blocked.Number)
+	if err != nil {
+		return fmt.Errorf("failed to find issue %s: %w", blocked, err)
+	}
+
+	data := &dependenciesData{}
+	if _, err := decodeMetadata(blockedIssue.GetBody(), dependenciesName, data); err != nil {
+		return err
+	}
+	if funk.ContainsString(data.BlockedBy, blocker.String()) {
+		return nil
+	}
+	data.BlockedBy = append(data.BlockedBy, blocker.String())
+
+	block, err := encodeMetadata(dependenciesName, data)
+	if err != nil {
+		return err
+	}
+
+	body := replaceMetadata(blockedIssue.GetBody(), dependenciesName, block)
+	_, _, err = client.Issues.Edit(ctx, blocked.Owner, blocked.Repo, blocked.Number, &github.IssueRequest{Body: github.String(body)})
+	return err
+}
+
+// listBlockers returns all blockers of the given issue, through the issue
+// dependencies API or through the given description.
+func (dependenciesHelper) listBlockers(ctx *EventContext, client *github.Client, ref issueReference, body string) ([]issueDependency, error) {
+	// NOTE: Github limits the number of blockers to 50 per issue
+	req, err := client.NewRequest(http.MethodGet, dependenciesURL(ref, "blocked_by")+"?per_page=100", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*github.Issue
+	_, err = client.Do(ctx, req, &issues)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	var blockers []issueDependency
+	if err == nil {
+		for _, issue := range issues {
+			blockers = append(blockers, issueDependency{ref: issueReferenceOf(issue), open: issue.GetState() != "closed"})
+		}
+		return blockers, nil
+	}
+
+	refs, err := decodeDependencies(body, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blocker := range refs {
+		issue, _, err := client.Issues.Get(ctx, blocker.Owner, blocker.Repo, blocker.Number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find issue %s: %w", blocker, err)
+		}
+		blockers = append(blockers, issueDependency{ref: blocker, open: issue.GetState() != "closed"})
+	}
+	return blockers, nil
+}
+
+// decodeDependencies returns the blockers stored in the given description
+// of the given issue. As the description can be edited by users, references
+// without repository are resolved against the issue one and malformed
+// references are rejected.
+func decodeDependencies(body string, ref issueReference) ([]issueReference, error) {
+	data := &dependenciesData{}
+	if _, err := decodeMetadata(body, dependenciesName, data); err != nil {
+		return nil, err
+	}
+
+	var blockers []issueReference
+	for _, raw := range data.BlockedBy {
+		blocker, err := resolveIssueReference(raw, ref.Owner, ref.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s metadata of %s: %w", dependenciesName, ref, err)
+		}
+		blockers = append(blockers, blocker)
+	}
+	return blockers, nil
+}
+
+// listBlockedPullRequests returns all open pull requests blocked by the
+// given issue.
+func (dependenciesHelper) listBlockedPullRequests(ctx *EventContext, client *github.Client, ref issueReference) ([]issueReference, error) {
+	req, err := client.NewRequest(http.MethodGet, dependenciesURL(ref, "blocking")+"?per_page=100", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*github.Issue
+	_, err = client.Do(ctx, req, &issues)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	var dependents []issueReference
+	if err == nil {
+		for _, issue := range issues {
+			if issue.IsPullRequest() && issue.GetState() == "open" {
+				dependents = append(dependents, issueReferenceOf(issue))
+			}
+		}
+		return dependents, nil
+	}
+
+	// NOTE: without the issue dependencies API, only the open pull requests
+	//		 of the same repository can be found
+	opts := &github.PullRequestListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		prs, resp, err := client.PullRequests.List(ctx, ref.Owner, ref.Repo, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			dependent := issueReference{Owner: ref.Owner, Repo: ref.Repo, Number: pr.GetNumber()}
+			blockers, err := decodeDependencies(pr.GetBody(), dependent)
+			if err != nil {
+				// NOTE: invalid metadata must not prevent other pull
+				//		 requests to be updated
+				continue
+			}
+
+			for _, blocker := range blockers {
+				if blocker == ref {
+					dependents = append(dependents, dependent)
+					break
+				}
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return dependents, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// updateBlockedStatus updates the blocked status of the given pull request
+// (issues are ignored).
+func (qa dependenciesHelper) updateBlockedStatus(ctx *EventContext, client *github.Client, ref issueReference) error {
+	pr, _, err := client.PullRequests.Get(ctx, ref.Owner, ref.Repo, ref.Number)
+	if isNotFound(err) {
+		// NOTE: only pull requests have commit statuses
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	blockers, err := qa.listBlockers(ctx, client, ref, pr.GetBody())
+	if err != nil {
+		return err
+	}
+	return qa.setBlockedStatus(ctx, client, ref, pr.GetHead().GetSHA(), blockers)
+}
+
+// setBlockedStatus creates a failing commit status on the given commit SHA if
+// some blockers are still open.
+func (dependenciesHelper) setBlockedStatus(ctx *EventContext, client *github.Client, ref issueReference, sha string, blockers []issueDependency) error {
+	var open []string
+	for _, blocker := range blockers {
+		if blocker.open {
+			open = append(open, blocker.ref.String())
+		}
+	}
+
+	status := &github.RepoStatus{
+		State:       github.String("success"),
+		Context:     github.String(blockedStatusContext),
+		Description: github.String("All blockers are closed"),
+	}
+	if len(open) > 0 {
+		description := "Blocked by " + strings.Join(open, ", ")
+		if len(description) > commitStatusDescriptionMaxLength {
+			description = description[:commitStatusDescriptionMaxLength-3] + "..."
+		}
+
+		status.State = github.String("failure")
+		status.Description = github.String(description)
+	}
+
+	_, _, err := client.Repositories.CreateStatus(ctx, ref.Owner, ref.Repo, sha, status)
+	return err
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("blocked_by", &BlockedByQuickAction{})
+	registerQuickAction("blocks", &BlocksQuickAction{})
+
+	// NOTE: register event handlers
+	registerEventHandler("blocked_by", &BlockedByQuickAction{})
+}
@@ -0,0 +1,100 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestBlockedBy_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		BlockedByQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestBlockedBy_TriggerOnActions(t *testing.T) {
+	assert.Equal(t,
+		map[EventType][]EventAction{
+			EventTypeIssue:       {EventActionClosed, EventActionReopened},
+			EventTypePullRequest: {EventActionClosed, EventActionReopened, EventActionSynchronize},
+		},
+		BlockedByQuickAction{}.TriggerOnActions(),
+	)
+}
+
+func TestBlockedByFeature(t *testing.T) {
+	events := BlockedByQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"blocked_by": &BlockedByQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("blocked_by && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestBlockedByEventHandlerFeature(t *testing.T) {
+	for event := range (BlockedByQuickAction{}).TriggerOnActions() {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializerWithHandlers(nil, map[string]EventHandler{"blocked_by": &BlockedByQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("blocked_by && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestBlocks_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		BlocksQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestBlocksFeature(t *testing.T) {
+	events := BlocksQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"blocks": &BlocksQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("blocks && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
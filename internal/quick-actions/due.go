@@ -0,0 +1,283 @@
+package quick_actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	"xnku.be/github-quick-actions/pkg/scheduler"
+)
+
+const (
+	// dueDateName is the name used to identify the due date metadata.
+	dueDateName = "due"
+	// dueDateProjectField is the Projects v2 date field updated by /due.
+	dueDateProjectField = "Due date"
+	// dueDateReminderDelay is the delay before the due date from which a
+	// reminder is posted.
+	dueDateReminderDelay = 24 * time.Hour
+)
+
+type (
+	// DueQuickAction implements QuickAction interface for /due command.
+	// This quick action sets the due date of an issue or a pull request and
+	// schedules a reminder posted when the deadline is near.
+	// It also implements JobRunner in order to post the reminder once due.
+	DueQuickAction struct{ dueDateHelper }
+	// RemoveDueDateQuickAction implements QuickAction interface for /remove_due_date
+	// command.
+	RemoveDueDateQuickAction struct{ dueDateHelper }
+
+	// dueDateHelper implements methods shared by due date quick actions.
+	dueDateHelper struct{ projectsHelper }
+
+	// dueDateData contains the due date stored in the issue (or pull
+	// request) description.
+	dueDateData struct {
+		Date     string `json:"date"`
+		Reminded bool   `json:"reminded,omitempty"`
+	}
+	// dueDateJobData contains the issue stored in the scheduled reminder job.
+	dueDateJobData struct {
+		Owner  string `json:"owner"`
+		Repo   string `json:"repo"`
+		Number int    `json:"number"`
+		Date   string `json:"date"`
+	}
+)
+
+func (qa DueQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "date", Kind: ArgumentKindDate, Required: true}}
+}
+
+func (qa DueQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa DueQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/due <date>"},
+		Description: "Set due date. Examples of valid `<date>` include `in 2 days`, `in 1 week`, `tomorrow`, and `2026-11-01`.",
+		Details:     "The `Due date` field of the projects containing the issue is updated too, and a reminder is posted one day before the deadline (reminders require a store, see `GQA_STORE_PATH`).",
+		Examples:    []string{"/due in 2 days", "/due 2026-11-01"},
+	}
+}
+
+func (qa DueQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "due").
+		Logger()
+
+	logger.Info().Msgf("handle `/due` (args: %v)", command.Arguments)
+
+	arg, _ := command.Values.Get("date")
+	date := arg.Time(qa.getEventDate(command.Payload))
+	data := &dueDateData{Date: date.Format("2006-01-02")}
+	if err := qa.updateDueDate(ctx, command.Payload, data); err != nil {
+		return err
+	}
+
+	if ctx.Store == nil {
+		logger.Warn().Msgf("no store configured; no reminder will be posted")
+		return nil
+	}
+
+	ref := qa.getIssueReference(command.Payload)
+	raw, err := json.Marshal(dueDateJobData{Owner: ref.Owner, Repo: ref.Repo, Number: ref.Number, Date: data.Date})
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the reminder job is not removed when the due date changes; the
+	//		 due date is checked again when the job runs
+	reminderAt, _ := time.Parse("2006-01-02", data.Date)
+	job := &scheduler.Job{Kind: dueDateName, InstallationID: qa.getInstallationID(command.Payload), DueAt: reminderAt.Add(-dueDateReminderDelay), Data: raw}
+	if err := ctx.Store.Schedule(ctx, job); err != nil {
+		return err
+	}
+
+	logger.Debug().Msgf("due date reminder %s scheduled on %s", job.ID, job.DueAt.Format(time.RFC3339))
+	return nil
+}
+
+func (qa RemoveDueDateQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa RemoveDueDateQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/remove_due_date"},
+		Description: "Remove due date.",
+	}
+}
+
+func (qa RemoveDueDateQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "remove_due_date").
+		Logger()
+
+	logger.Info().Msgf("handle `/remove_due_date` (args: %v)", command.Arguments)
+
+	return qa.updateDueDate(ctx, command.Payload, nil)
+}
+
+func (dueDateHelper) TriggerOnEvents() []EventType {
+	// NOTE: due date should be triggered on issues & pull requests description too
+	return []EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment}
+}
+
+// updateDueDate stores the given due date (removes it if nil) in the
+// description metadata and in the projects containing the issue.
+// NOTE: the metadata is always stored, even if the issue belongs to a
+//		 project, because it is used to send reminders. Once stored, the
+//		 projects failures are only logged.
+func (qa dueDateHelper) updateDueDate(ctx *EventContext, payload EventPayload, data *dueDateData) error {
+	client, err := qa.newInstallationClient(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	err = qa.updateIssueMetadata(ctx, client, qa.getIssueReference(payload), dueDateName, func(string) (string, error) {
+		if data == nil {
+			return "", nil
+		}
+		return encodeMetadata(dueDateName, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := qa.updateProjectsDueDate(ctx, payload, data); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msgf("failed to update the due date of the projects containing %s", qa.getIssueReference(payload))
+	}
+	return nil
+}
+
+// updateProjectsDueDate sets the given due date (clears it if nil) in all
+// projects containing the issue.
+func (qa dueDateHelper) updateProjectsDueDate(ctx *EventContext, payload EventPayload, data *dueDateData) error {
+	v4client, err := ctx.NewGraphQLClient(payload)
+	if err != nil {
+		return err
+	}
+
+	fields, err := qa.getProjectItemFields(ctx, v4client, payload, dueDateProjectField)
+	if err != nil {
+		return err
+	}
+
+	errors := &multierror.Error{}
+	for _, field := range fields {
+		if data == nil {
+			err = qa.clearProjectItemField(ctx, v4client, field)
+		} else {
+			err = qa.setProjectItemValue(ctx, v4client, field, projectV2FieldValue{Date: githubv4.NewString(githubv4.String(data.Date))})
+		}
+		errors = multierror.Append(errors, err)
+	}
+	return errors.ErrorOrNil()
+}
+
+// RunJob implements the JobRunner interface by posting the reminder.
+func (qa DueQuickAction) RunJob(ctx *EventContext, job *scheduler.Job) error {
+	client, err := ctx.NewInstallationClient(job.InstallationID)
+	if err != nil {
+		return err
+	}
+	return qa.remindJob(ctx, client, job, time.Now().UTC())
+}
+
+// remindJob posts a reminder on the issue contained in the given job, if its
+// due date is still near.
+func (qa DueQuickAction) remindJob(ctx *EventContext, client *github.Client, job *scheduler.Job, now time.Time) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("job_runner", dueDateName).
+		Logger()
+
+	jobData := dueDateJobData{}
+	if err := json.Unmarshal(job.Data, &jobData); err != nil {
+		return fmt.Errorf("invalid due date reminder %s: %w", job.ID, err)
+	}
+
+	issue, _, err := client.Issues.Get(ctx, jobData.Owner, jobData.Repo, jobData.Number)
+	if isNotFound(err) {
+		// NOTE: the issue (or the repository) has been removed; the job is
+		//		 dropped instead of being retried forever
+		logger.Warn().Msgf("%s/%s#%d not found, due date reminder %s dropped", jobData.Owner, jobData.Repo, jobData.Number, job.ID)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if issue.GetState() == "closed" {
+		logger.Debug().Msgf("%s/%s#%d is closed; ignored", jobData.Owner, jobData.Repo, jobData.Number)
+		return nil
+	}
+
+	// NOTE: the due date is read again from the description because it can
+	//		 be changed (or removed) since the job has been scheduled
+	return qa.remindDueDate(ctx, client, jobData.Owner, jobData.Repo, issue, now)
+}
+
+// remindDueDate posts a reminder on the given issue if its due date is near
+// and no reminder has been posted yet.
+func (qa DueQuickAction) remindDueDate(ctx *EventContext, client *github.Client, owner, repo string, issue *github.Issue, now time.Time) error {
+	data := &dueDateData{}
+	found, err := decodeMetadata(issue.GetBody(), dueDateName, data)
+	if err != nil || !found || data.Reminded {
+		return err
+	}
+
+	date, err := time.Parse("2006-01-02", data.Date)
+	if err != nil {
+		return fmt.Errorf("invalid due date '%s': %w", data.Date, err)
+	}
+	if now.Add(dueDateReminderDelay).Before(date) {
+		return nil
+	}
+
+	var mentions []string
+	for _, assignee := range issue.Assignees {
+		mentions = append(mentions, "@"+assignee.GetLogin())
+	}
+	if len(mentions) == 0 {
+		mentions = append(mentions, "@"+issue.GetUser().GetLogin())
+	}
+
+	reminder := fmt.Sprintf("%s this is due on **%s**.", strings.Join(mentions, " "), data.Date)
+	if now.After(date.AddDate(0, 0, 1)) {
+		reminder = fmt.Sprintf("%s this was due on **%s**.", strings.Join(mentions, " "), data.Date)
+	}
+
+	_, _, err = client.Issues.CreateComment(ctx, owner, repo, issue.GetNumber(), &github.IssueComment{Body: github.String(reminder)})
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the reminder is only sent once
+	return qa.updateIssueMetadata(ctx, client, issueReference{Owner: owner, Repo: repo, Number: issue.GetNumber()}, dueDateName, func(body string) (string, error) {
+		// NOTE: the due date can be removed while the reminder is posted
+		data := &dueDateData{}
+		if found, err := decodeMetadata(body, dueDateName, data); err != nil || !found {
+			return "", err
+		}
+		data.Reminded = true
+		return encodeMetadata(dueDateName, data)
+	})
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("due", &DueQuickAction{})
+	registerQuickAction("remove_due_date", &RemoveDueDateQuickAction{})
+
+	// NOTE: register job runners
+	registerJobRunner(dueDateName, &DueQuickAction{})
+}
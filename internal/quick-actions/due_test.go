@@ -0,0 +1,204 @@
+package quick_actions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+	gqa_httptest "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx/httptest"
+	"xnku.be/github-quick-actions/pkg/scheduler"
+)
+
+func TestDueQuickAction_Arguments(t *testing.T) {
+	from := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	ts := map[string]struct {
+		value string
+		date  string
+		err   error
+	}{
+		"absolute":        {value: "2026-11-01", date: "2026-11-01"},
+		"today":           {value: "today", date: "2026-10-19"},
+		"tomorrow":        {value: "Tomorrow", date: "2026-10-20"},
+		"in days":         {value: "in 3 days", date: "2026-10-22"},
+		"in one day":      {value: "in 1 day", date: "2026-10-20"},
+		"in weeks":        {value: "in 2 weeks", date: "2026-11-02"},
+		"in months":       {value: "in 1 month", date: "2026-11-19"},
+		"in years":        {value: "in 1 year", date: "2027-10-19"},
+		"short delay":     {value: "2w", date: "2026-11-02"},
+		"invalid date":    {value: "2026-13-01", err: fmt.Errorf("invalid date '2026-13-01'; usage: `/due <date>`")},
+		"invalid unit":    {value: "in 2 seconds", err: fmt.Errorf("invalid date 'in'; usage: `/due <date>`")},
+		"invalid pattern": {value: "next friday", err: fmt.Errorf("invalid date 'next'; usage: `/due <date>`")},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			values, err := ParseArguments("due", DueQuickAction{}.Arguments(), strings.Fields(tc.value), nil)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			date, _ := values.Get("date")
+			assert.Equal(t, tc.date, date.Time(from).Format("2006-01-02"))
+		})
+	}
+}
+
+func TestDueQuickAction_scheduleJob(t *testing.T) {
+	payload, err := PayloadFactory(EventTypeIssueComment, []byte(`{
+		"action": "created",
+		"comment": { "body": "/due 2026-11-01", "created_at": "2026-10-19T10:00:00Z" },
+		"repository": { "owner": { "login": "xunleii" }, "name": "github-quick-actions" },
+		"issue": { "number": 1, "body": "Some description" },
+		"sender": { "login": "xunleii" },
+		"installation": { "id": 123456789 }
+	}`))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/1", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 1, "body": "Some description"}`))
+	})
+
+	srv := gqa_httptest.NewServer(mux)
+	store := scheduler.NewMemoryStore()
+	ctx := &EventContext{Context: context.TODO(), ClientCreator: &gqa_scenario_context.ClientCreator{Client: srv.Client()}, Store: store}
+	command := &EventCommand{Command: "due", Arguments: []string{"2026-11-01"}, Payload: payload}
+	require.NoError(t, ValidateArguments(DueQuickAction{}, command))
+	require.NoError(t, DueQuickAction{}.HandleCommand(ctx, command))
+
+	jobs, err := store.Due(context.TODO(), time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, dueDateName, jobs[0].Kind)
+	assert.Equal(t, int64(123456789), jobs[0].InstallationID)
+	assert.Equal(t, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC), jobs[0].DueAt)
+	assert.JSONEq(t, `{"owner":"xunleii","repo":"github-quick-actions","number":1,"date":"2026-11-01"}`, string(jobs[0].Data))
+
+	// NOTE: without store, the due date is still set but no reminder is scheduled
+	require.NoError(t, DueQuickAction{}.HandleCommand(&EventContext{Context: context.TODO(), ClientCreator: &gqa_scenario_context.ClientCreator{Client: srv.Client()}}, command))
+}
+
+func TestDueQuickAction_remindJob(t *testing.T) {
+	issues := map[string]string{
+		"/repos/xunleii/github-quick-actions/issues/1": `{"number": 1, "body": "no due date"}`,
+		"/repos/xunleii/github-quick-actions/issues/2": `{"number": 2, "body": "<!-- quick-actions:due:data {\"date\":\"2026-10-30\"} -->"}`,
+		"/repos/xunleii/github-quick-actions/issues/3": `{"number": 3, "body": "<!-- quick-actions:due:data {\"date\":\"2026-10-20\"} -->", "assignees": [{"login": "mojombo"}, {"login": "xunleii"}]}`,
+		"/repos/xunleii/github-quick-actions/issues/4": `{"number": 4, "body": "<!-- quick-actions:due:data {\"date\":\"2026-10-01\"} -->", "user": {"login": "xunleii"}}`,
+		"/repos/xunleii/github-quick-actions/issues/5": `{"number": 5, "body": "<!-- quick-actions:due:data {\"date\":\"2026-10-01\",\"reminded\":true} -->"}`,
+		"/repos/xunleii/github-quick-actions/issues/6": `{"number": 6, "state": "closed", "body": "<!-- quick-actions:due:data {\"date\":\"2026-10-01\"} -->"}`,
+	}
+
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(issues[r.URL.Path]))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/repos/xunleii/removed/issues/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	srv := gqa_httptest.NewServer(mux)
+	client := github.NewClient(srv.Client())
+	ctx := &EventContext{Context: context.TODO()}
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	jobs := []string{
+		`{"owner":"xunleii","repo":"github-quick-actions","number":1,"date":"2026-10-20"}`,
+		`{"owner":"xunleii","repo":"github-quick-actions","number":2,"date":"2026-10-20"}`,
+		`{"owner":"xunleii","repo":"github-quick-actions","number":3,"date":"2026-10-20"}`,
+		`{"owner":"xunleii","repo":"github-quick-actions","number":4,"date":"2026-10-01"}`,
+		`{"owner":"xunleii","repo":"github-quick-actions","number":5,"date":"2026-10-01"}`,
+		`{"owner":"xunleii","repo":"github-quick-actions","number":6,"date":"2026-10-01"}`,
+		`{"owner":"xunleii","repo":"removed","number":7,"date":"2026-10-01"}`,
+	}
+	for _, data := range jobs {
+		require.NoError(t, DueQuickAction{}.remindJob(ctx, client, &scheduler.Job{Kind: dueDateName, Data: []byte(data)}, now))
+	}
+
+	assert.Equal(t, []string{
+		"POST /repos/xunleii/github-quick-actions/issues/3/comments " + `{"body":"@mojombo @xunleii this is due on **2026-10-20**."}` + "\n",
+		"PATCH /repos/xunleii/github-quick-actions/issues/3 " + `{"body":"<!-- quick-actions:due:data {\"date\":\"2026-10-20\",\"reminded\":true} -->"}` + "\n",
+		"POST /repos/xunleii/github-quick-actions/issues/4/comments " + `{"body":"@xunleii this was due on **2026-10-01**."}` + "\n",
+		"PATCH /repos/xunleii/github-quick-actions/issues/4 " + `{"body":"<!-- quick-actions:due:data {\"date\":\"2026-10-01\",\"reminded\":true} -->"}` + "\n",
+	}, requests)
+}
+
+func TestDue_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment},
+		DueQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestDueFeature(t *testing.T) {
+	events := DueQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"due": &DueQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("due && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestRemoveDueDate_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment},
+		RemoveDueDateQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestRemoveDueDateFeature(t *testing.T) {
+	events := RemoveDueDateQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"remove_due_date": &RemoveDueDateQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("remove_due_date && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
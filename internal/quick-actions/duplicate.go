@@ -2,8 +2,6 @@ package quick_actions
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/google/go-github/v39/github"
 	"github.com/hashicorp/go-multierror"
@@ -15,7 +13,14 @@ type (
 	// DuplicateQuickAction implements QuickAction interface for /duplicate command.
 	// This quick action closes an issue or a PR and marks as duplicate of
 	// another issue.
-	DuplicateQuickAction struct{ githubEventHelper }
+	DuplicateQuickAction struct{ labelsHelper }
+
+	// duplicateOptions contains the repository options of /duplicate.
+	duplicateOptions struct {
+		// Label is added on the issue marked as duplicate (no label is
+		// added if empty).
+		Label string `yaml:"label"`
+	}
 )
 
 func (qa DuplicateQuickAction) TriggerOnEvents() []EventType {
@@ -23,6 +28,26 @@ func (qa DuplicateQuickAction) TriggerOnEvents() []EventType {
 	return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
 }
 
+func (qa DuplicateQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "issue", Kind: ArgumentKindIssueRef, Required: true, Variadic: true}}
+}
+
+func (qa DuplicateQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa DuplicateQuickAction) Options() interface{} {
+	return &duplicateOptions{}
+}
+
+func (qa DuplicateQuickAction) Metadata() Metadata {
+	return Metadata{
+		Description: "Close this issue and mark as a duplicate of another issue (from this repository or another one).",
+		Details:     "A label can also be added on the issue through the `label` option.",
+		Examples:    []string{"/duplicate #12", "/duplicate owner/repo#12"},
+	}
+}
+
 func (qa DuplicateQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
 	logger := zerolog.Ctx(ctx).With().
 		Str("quick_action", "duplicate").
@@ -30,57 +55,44 @@ func (qa DuplicateQuickAction) HandleCommand(ctx *EventContext, command *EventCo
 
 	logger.Info().Msgf("handle `/duplicate` (args: %v)", command.Arguments)
 
-	var issues []int
-	for _, issue := range command.Arguments {
-		if !strings.HasPrefix(issue, "#") {
-			logger.Debug().Msgf("invalid issue '%s' provided; ignored", issue)
-			continue
-		}
-
-		n, err := strconv.Atoi(strings.TrimPrefix(issue, "#"))
-		if err != nil {
-			logger.Error().Err(err).Msgf("invalid issue '%s' provided; ignored", issue)
-			continue
-		}
-		issues = append(issues, n)
-	}
-
-	if len(issues) == 0 {
-		logger.Error().Msgf("no valid issue provided; ignored")
-		return nil
-	}
-
 	client, err := qa.newInstallationClient(ctx, command.Payload)
 	if err != nil {
 		return err
 	}
 
 	var errs *multierror.Error
-	for _, issue := range issues {
-		if issue == command.Payload.IssueNumber() {
+	var marked bool
+	for _, issue := range command.Values["issue"] {
+		local := issue.Owner == command.Payload.RepositoryOwner() && issue.Repo == command.Payload.RepositoryName()
+		if local && issue.Number == command.Payload.IssueNumber() {
 			logger.Debug().Msgf("cannot mark current issue as duplicate of itself; ignored")
 			continue
 		}
 
-		_, _, err := client.Issues.Get(
-			ctx,
-			command.Payload.RepositoryOwner(),
-			command.Payload.RepositoryName(),
-			issue,
-		)
+		_, _, err := client.Issues.Get(ctx, issue.Owner, issue.Repo, issue.Number)
 		if err != nil {
 			// NOTE: invalid issue are ignored
 			continue
 		}
 
+		ref := issue.Value
+		if local {
+			ref = fmt.Sprintf("#%d", issue.Number)
+		}
+
 		_, _, err = client.Issues.CreateComment(
 			ctx,
 			command.Payload.RepositoryOwner(),
 			command.Payload.RepositoryName(),
 			command.Payload.IssueNumber(),
-			&github.IssueComment{Body: github.String(fmt.Sprintf("Duplicate of #%d", issue))},
+			&github.IssueComment{Body: github.String(fmt.Sprintf("Duplicate of %s", ref))},
 		)
 		errs = multierror.Append(errs, err)
+		marked = marked || err == nil
+	}
+
+	if options, valid := command.Options.(*duplicateOptions); valid && options.Label != "" && marked {
+		errs = multierror.Append(errs, qa.addLabels(ctx, client, command.Payload, options.Label))
 	}
 
 	return errs.ErrorOrNil()
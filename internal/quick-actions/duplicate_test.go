@@ -1,13 +1,19 @@
 package quick_actions
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"testing"
 
 	"github.com/cucumber/godog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
 	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+	gqa_httptest "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx/httptest"
 )
 
 func TestDuplicateQuickAction_TriggerOnEvents(t *testing.T) {
@@ -38,3 +44,44 @@ func TestDuplicateFeature(t *testing.T) {
 		})
 	}
 }
+
+func TestDuplicateQuickAction_labelOption(t *testing.T) {
+	payload, err := PayloadFactory(EventTypeIssueComment, []byte(`{
+		"action": "created",
+		"repository": { "owner": { "login": "xunleii" }, "name": "github-quick-actions" },
+		"issue": { "number": 1 },
+		"installation": { "id": 123456789 }
+	}`))
+	require.NoError(t, err)
+
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/12", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 12}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, bytes.TrimSpace(body)))
+		if bytes.HasPrefix(body, []byte("[")) {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	srv := gqa_httptest.NewServer(mux)
+	ctx := &EventContext{Context: context.TODO(), ClientCreator: &gqa_scenario_context.ClientCreator{Client: srv.Client()}}
+	command := &EventCommand{
+		Command:   "duplicate",
+		Arguments: []string{"#12"},
+		Values:    Arguments{"issue": {{Raw: "#12", Value: "xunleii/github-quick-actions#12", Owner: "xunleii", Repo: "github-quick-actions", Number: 12}}},
+		Options:   &duplicateOptions{Label: "duplicate"},
+		Payload:   payload,
+	}
+
+	require.NoError(t, DuplicateQuickAction{}.HandleCommand(ctx, command))
+	assert.Equal(t, []string{
+		`POST /repos/xunleii/github-quick-actions/issues/1/comments {"body":"Duplicate of #12"}`,
+		`POST /repos/xunleii/github-quick-actions/issues/1/labels ["duplicate"]`,
+	}, requests)
+}
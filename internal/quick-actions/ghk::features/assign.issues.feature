@@ -1,12 +1,12 @@
-@issue
+@issues
 Feature: assign someone with /assign @user [@user...] on issue description
 
   Background:
-    Given quick action "/assign" is registered for "issue" events
+    Given quick action "/assign" is registered for "issues" events
 
   @assign
   Scenario: /assign @mojombo
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -22,13 +22,13 @@ Feature: assign someone with /assign @user [@user...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "issue" event with arguments ["@mojombo"] by sending these following requests
+    Then Github Quick Actions should handle command "/assign" for "issues" event with arguments ["@mojombo"] by sending these following requests
       | API request method | API request URL                                                              | API request payload       |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/assignees | {"assignees":["mojombo"]} |
 
   @assign
   Scenario: /assign @mojombo @defunkt
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -44,13 +44,13 @@ Feature: assign someone with /assign @user [@user...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "issue" event with arguments ["@mojombo","@defunkt"] by sending these following requests
+    Then Github Quick Actions should handle command "/assign" for "issues" event with arguments ["@mojombo","@defunkt"] by sending these following requests
       | API request method | API request URL                                                              | API request payload                 |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/assignees | {"assignees":["mojombo","defunkt"]} |
 
   @assign
   Scenario: /assign me
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -66,13 +66,13 @@ Feature: assign someone with /assign @user [@user...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "issue" event with arguments ["me"] by sending these following requests
+    Then Github Quick Actions should handle command "/assign" for "issues" event with arguments ["me"] by sending these following requests
       | API request method | API request URL                                                              | API request payload       |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/assignees | {"assignees":["xunleii"]} |
 
   @assign
   Scenario: /assign @mojombo me
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -88,13 +88,13 @@ Feature: assign someone with /assign @user [@user...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "issue" event with arguments ["@mojombo","me"] by sending these following requests
+    Then Github Quick Actions should handle command "/assign" for "issues" event with arguments ["@mojombo","me"] by sending these following requests
       | API request method | API request URL                                                              | API request payload                 |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/assignees | {"assignees":["mojombo","xunleii"]} |
 
   @assign
   Scenario: /assign @mojombo @mojombo
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -110,13 +110,13 @@ Feature: assign someone with /assign @user [@user...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "issue" event with arguments ["@mojombo","@mojombo"] by sending these following requests
+    Then Github Quick Actions should handle command "/assign" for "issues" event with arguments ["@mojombo","@mojombo"] by sending these following requests
       | API request method | API request URL                                                              | API request payload       |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/assignees | {"assignees":["mojombo"]} |
 
   @assign @error
   Scenario: /assign mojombo
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -132,11 +132,11 @@ Feature: assign someone with /assign @user [@user...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "issue" event with arguments ["mojombo"] without sending anything
+    Then Github Quick Actions should handle command "/assign" for "issues" event with arguments ["mojombo"] but returns this error: 'invalid user 'mojombo'; usage: `/assign @user [@user...]`'
 
   @assign @error
   Scenario: /assign without argument
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -153,12 +153,12 @@ Feature: assign someone with /assign @user [@user...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "issue" event without argument without sending anything
+    Then Github Quick Actions should handle command "/assign" for "issues" event without argument but returns this error: 'missing argument `@user`; usage: `/assign @user [@user...]`'
 
   @assign @error
   Scenario: error handling on /assign
     Given Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/assignees' with '404 {"message": "Not Found", "documentation_url": "https://docs.github.com/en/rest/reference/issues#add-labels-to-an-issue"}'
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -174,4 +174,4 @@ Feature: assign someone with /assign @user [@user...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "issue" event with arguments ["me"] but returns this error: 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/assignees: 404 Not Found []'
+    Then Github Quick Actions should handle command "/assign" for "issues" event with arguments ["me"] but returns this error: 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/assignees: 404 Not Found []'
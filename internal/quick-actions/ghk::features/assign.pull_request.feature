@@ -132,7 +132,7 @@ Feature: assign someone with /assign @user [@user...] on pull request descriptio
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "pull_request" event with arguments ["mojombo"] without sending anything
+    Then Github Quick Actions should handle command "/assign" for "pull_request" event with arguments ["mojombo"] but returns this error: 'invalid user 'mojombo'; usage: `/assign @user [@user...]`'
 
   @assign @error
   Scenario: /assign without argument
@@ -152,7 +152,7 @@ Feature: assign someone with /assign @user [@user...] on pull request descriptio
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "pull_request" event without argument without sending anything
+    Then Github Quick Actions should handle command "/assign" for "pull_request" event without argument but returns this error: 'missing argument `@user`; usage: `/assign @user [@user...]`'
 
   @assign @error
   Scenario: error handling on /assign
@@ -114,7 +114,7 @@ Feature: assign someone with /assign @user [@user...] on pull request review com
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "pull_request_review_comment" event with arguments ["mojombo"] without sending anything
+    Then Github Quick Actions should handle command "/assign" for "pull_request_review_comment" event with arguments ["mojombo"] but returns this error: 'invalid user 'mojombo'; usage: `/assign @user [@user...]`'
 
   @assign @error
   Scenario: /assign without argument
@@ -131,7 +131,7 @@ Feature: assign someone with /assign @user [@user...] on pull request review com
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/assign" for "pull_request_review_comment" event without argument without sending anything
+    Then Github Quick Actions should handle command "/assign" for "pull_request_review_comment" event without argument but returns this error: 'missing argument `@user`; usage: `/assign @user [@user...]`'
 
   @assign @error
   Scenario: error handling on /assign
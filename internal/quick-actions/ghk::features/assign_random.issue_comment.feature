@@ -0,0 +1,159 @@
+@issue_comment
+Feature: request reviews from random code owners with /assign_random [<n>] [@org/team] on issue comment
+
+  Background:
+    Given quick action "/assign_random" is registered for "issue_comment" events
+
+  @assign_random
+  Scenario: /assign_random 1 @xunleii/maintainers
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "user": {"login": "xunleii"}, "base": {"ref": "main"}, "requested_reviewers": [{"login": "defunkt"}]}'
+    And Github replies to 'GET https://api.github.com/orgs/xunleii/teams/maintainers/members' with '200 [{"login": "xunleii"}, {"login": "mojombo"}, {"login": "defunkt"}, {"login": "octocat"}]'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"user": {"status": {"indicatesLimitedAvailability": true}}, "search": {"issueCount": 0}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"user": {"status": null}, "search": {"issueCount": 3}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/assign_random 1 @xunleii/maintainers", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/assign_random" for "issue_comment" event with arguments ["1","@xunleii/maintainers"] by sending these following requests
+      | API request method | API request URL                                                                       | API request payload                                                                                                                                                                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                     |                                                                                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/orgs/xunleii/teams/maintainers/members?per_page=100            |                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/graphql                                                        | {"query":"query($login:String!$query:String!){user(login: $login){status{indicatesLimitedAvailability}},search(query: $query, type: ISSUE, first: 1){issueCount}}","variables":{"login":"mojombo","query":"is:pr is:open user:xunleii review-requested:mojombo"}} |
+      | POST               | https://api.github.com/graphql                                                        | {"query":"query($login:String!$query:String!){user(login: $login){status{indicatesLimitedAvailability}},search(query: $query, type: ISSUE, first: 1){issueCount}}","variables":{"login":"octocat","query":"is:pr is:open user:xunleii review-requested:octocat"}} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/requested_reviewers | {"reviewers":["octocat"]}                                                                                                                                                                                                                                         |
+
+  @assign_random
+  Scenario: /assign_random with CODEOWNERS
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "user": {"login": "xunleii"}, "base": {"ref": "main"}, "requested_reviewers": [{"login": "defunkt"}]}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/contents/.github/CODEOWNERS' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/contents/CODEOWNERS' with '200 {"type": "file", "encoding": "base64", "content": "KiBAeHVubGVpaQoqLmdvIEBtb2pvbWJvCi9kb2NzLyBAeHVubGVpaS9kb2NzCg=="}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/files' with '200 [{"filename": "main.go"}, {"filename": "pkg/cmd/config.go"}]'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"user": {"status": null}, "search": {"issueCount": 0}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/assign_random", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/assign_random" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                                | API request payload                                                                                                                                                                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                              |                                                                                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/contents/.github/CODEOWNERS?ref=main |                                                                                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/contents/CODEOWNERS?ref=main         |                                                                                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/files?per_page=100           |                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/graphql                                                                 | {"query":"query($login:String!$query:String!){user(login: $login){status{indicatesLimitedAvailability}},search(query: $query, type: ISSUE, first: 1){issueCount}}","variables":{"login":"mojombo","query":"is:pr is:open user:xunleii review-requested:mojombo"}} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/requested_reviewers          | {"reviewers":["mojombo"]}                                                                                                                                                                                                                                         |
+
+  @assign_random
+  Scenario: /assign_random on an issue
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/assign_random", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/assign_random" for "issue_comment" event without argument without sending anything
+
+  @assign_random @error
+  Scenario: /assign_random without CODEOWNERS
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "user": {"login": "xunleii"}, "base": {"ref": "main"}, "requested_reviewers": [{"login": "defunkt"}]}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/contents/.github/CODEOWNERS' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/contents/CODEOWNERS' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/contents/docs/CODEOWNERS' with '404 {"message": "Not Found"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/assign_random 2", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/assign_random" for "issue_comment" event with arguments ["2"] but returns this error: 'no CODEOWNERS file found; use `/assign_random @org/team` instead'
+
+  @assign_random @error
+  Scenario: /assign_random without available reviewer
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "user": {"login": "xunleii"}, "base": {"ref": "main"}, "requested_reviewers": [{"login": "defunkt"}]}'
+    And Github replies to 'GET https://api.github.com/orgs/xunleii/teams/maintainers/members' with '200 [{"login": "xunleii"}, {"login": "defunkt"}]'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/assign_random @xunleii/maintainers", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/assign_random" for "issue_comment" event with arguments ["@xunleii/maintainers"] but returns this error: 'no available reviewer found'
+
+  @assign_random @error
+  Scenario: /assign_random with an invalid argument
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/assign_random me", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/assign_random" for "issue_comment" event with arguments ["me"] but returns this error: 'invalid number 'me'; usage: `/assign_random [<n>] [@org/team]`'
+
+  @assign_random @error
+  Scenario: /assign_random with an invalid number of reviewers
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/assign_random 0", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/assign_random" for "issue_comment" event with arguments ["0"] but returns this error: 'invalid number '0'; usage: `/assign_random [<n>] [@org/team]`'
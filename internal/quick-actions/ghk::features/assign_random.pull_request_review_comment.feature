@@ -0,0 +1,33 @@
+@pull_request_review_comment
+Feature: request reviews from random code owners with /assign_random [<n>] [@org/team] on pull request review comment
+
+  Background:
+    Given quick action "/assign_random" is registered for "pull_request_review_comment" events
+
+  @assign_random
+  Scenario: /assign_random 1 @xunleii/maintainers
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "user": {"login": "xunleii"}, "base": {"ref": "main"}, "requested_reviewers": [{"login": "defunkt"}]}'
+    And Github replies to 'GET https://api.github.com/orgs/xunleii/teams/maintainers/members' with '200 [{"login": "xunleii"}, {"login": "mojombo"}, {"login": "defunkt"}, {"login": "octocat"}]'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"user": {"status": {"indicatesLimitedAvailability": true}}, "search": {"issueCount": 0}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"user": {"status": null}, "search": {"issueCount": 3}}}'
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/assign_random 1 @xunleii/maintainers", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/assign_random" for "pull_request_review_comment" event with arguments ["1","@xunleii/maintainers"] by sending these following requests
+      | API request method | API request URL                                                                       | API request payload                                                                                                                                                                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                     |                                                                                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/orgs/xunleii/teams/maintainers/members?per_page=100            |                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/graphql                                                        | {"query":"query($login:String!$query:String!){user(login: $login){status{indicatesLimitedAvailability}},search(query: $query, type: ISSUE, first: 1){issueCount}}","variables":{"login":"mojombo","query":"is:pr is:open user:xunleii review-requested:mojombo"}} |
+      | POST               | https://api.github.com/graphql                                                        | {"query":"query($login:String!$query:String!){user(login: $login){status{indicatesLimitedAvailability}},search(query: $query, type: ISSUE, first: 1){issueCount}}","variables":{"login":"octocat","query":"is:pr is:open user:xunleii review-requested:octocat"}} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/requested_reviewers | {"reviewers":["octocat"]}                                                                                                                                                                                                                                         |
@@ -0,0 +1,167 @@
+@issue_comment
+Feature: record blockers with /blocked_by <issue> [<issue>...] on issue comment
+
+  Background:
+    Given quick action "/blocked_by" is registered for "issue_comment" events
+
+  @blocked_by
+  Scenario: /blocked_by #12 on an issue
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12' with '200 {"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '201 {"id": 1001, "number": 1, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocked_by #12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocked_by" for "issue_comment" event with arguments ["#12"] by sending these following requests
+      | API request method | API request URL                                                                            | API request payload |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12                        |                     |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by | {"issue_id":1012}   |
+
+  @blocked_by
+  Scenario: /blocked_by #12 xunleii/roadmap#5 on a pull request
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12' with '200 {"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/roadmap/issues/5' with '200 {"id": 2005, "number": 5, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/roadmap"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '201 {"id": 1001, "number": 1, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '200 [{"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}, {"id": 2005, "number": 5, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/roadmap"}]'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocked_by #12 xunleii/roadmap#5", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocked_by" for "issue_comment" event with arguments ["#12","xunleii/roadmap#5"] by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12                                         |                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by                  | {"issue_id":1012}                                                                                                |
+      | GET                | https://api.github.com/repos/xunleii/roadmap/issues/5                                                       |                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by                  | {"issue_id":2005}                                                                                                |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                                  |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by?per_page=100     |                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"failure","description":"Blocked by xunleii/github-quick-actions#12","context":"quick-actions/blocked"} |
+
+  @blocked_by
+  Scenario: /blocked_by #12 on a pull request without issue dependencies API
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12' with '200 {"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description"}'
+    And Github replies to 'PATCH https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:dependencies:data {\"blocked_by\":[\"xunleii/github-quick-actions#12\"]} -->", "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '404 {"message": "Not Found"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocked_by #12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocked_by" for "issue_comment" event with arguments ["#12"] by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                                                  |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12                                         |                                                                                                                                      |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by                  | {"issue_id":1012}                                                                                                                    |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                                          |                                                                                                                                      |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                                          | {"body":"Some description\\n\\n<!-- quick-actions:dependencies:data {\\"blocked_by\\":[\\"xunleii/github-quick-actions#12\\"]} -->"} |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                                                      |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by?per_page=100     |                                                                                                                                      |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12                                         |                                                                                                                                      |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"failure","description":"Blocked by xunleii/github-quick-actions#12","context":"quick-actions/blocked"}                     |
+
+  @blocked_by
+  Scenario: /blocked_by #12 already recorded without issue dependencies API
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12' with '200 {"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:dependencies:data {\"blocked_by\":[\"xunleii/github-quick-actions#12\"]} -->"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocked_by #12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocked_by" for "issue_comment" event with arguments ["#12"] by sending these following requests
+      | API request method | API request URL                                                                            | API request payload |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12                        |                     |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by | {"issue_id":1012}   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                         |                     |
+
+  @blocked_by @error
+  Scenario: /blocked_by #12 on a missing issue
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12' with '404 {"message": "Not Found"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocked_by #12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocked_by" for "issue_comment" event with arguments ["#12"] but returns this error: 'failed to find issue xunleii/github-quick-actions#12: GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12: 404 Not Found []'
+
+  @blocked_by @error
+  Scenario: /blocked_by #1 on itself
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocked_by #1", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocked_by" for "issue_comment" event with arguments ["#1"] but returns this error: 'xunleii/github-quick-actions#1 cannot depend on itself'
+
+  @blocked_by @error
+  Scenario: /blocked_by without reference
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocked_by", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocked_by" for "issue_comment" event without argument but returns this error: 'missing argument `#issue`; usage: `/blocked_by #issue [#issue...]`'
@@ -0,0 +1,148 @@
+@issues
+Feature: update blocked pull requests when a blocker is closed or reopened
+
+  Background:
+    Given event handler "blocked_by" is registered for "issues" events
+
+  @blocked_by
+  Scenario: blocker closed
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking' with '200 [{"id": 1034, "number": 34, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions", "pull_request": {"url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/34"}}, {"id": 1035, "number": 35, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/34' with '200 {"number": 34, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by' with '200 [{"id": 1012, "number": 12, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}]'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "closed",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {"id": 1012, "number": 12, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"},
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "issues" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                           |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking?per_page=100      |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/34                                          |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by?per_page=100    |                                                                                               |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"success","description":"All blockers are closed","context":"quick-actions/blocked"} |
+
+  @blocked_by
+  Scenario: blocker closed without issue dependencies API
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls' with '200 [{"number": 34, "body": "<!-- quick-actions:dependencies:data {\"blocked_by\":[\"xunleii/github-quick-actions#12\"]} -->"}, {"number": 35, "body": "Some description"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/34' with '200 {"number": 34, "body": "<!-- quick-actions:dependencies:data {\"blocked_by\":[\"xunleii/github-quick-actions#12\"]} -->", "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12' with '200 {"id": 1012, "number": 12, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "closed",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {"id": 1012, "number": 12, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"},
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "issues" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                           |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking?per_page=100      |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls?per_page=100&state=open                     |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/34                                          |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by?per_page=100    |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12                                         |                                                                                               |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"success","description":"All blockers are closed","context":"quick-actions/blocked"} |
+
+  @blocked_by
+  Scenario: blocker reopened
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking' with '200 [{"id": 1034, "number": 34, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions", "pull_request": {"url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/34"}}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/34' with '200 {"number": 34, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by' with '200 [{"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}]'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "reopened",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"},
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "issues" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking?per_page=100      |                                                                                                                  |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/34                                          |                                                                                                                  |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by?per_page=100    |                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"failure","description":"Blocked by xunleii/github-quick-actions#12","context":"quick-actions/blocked"} |
+
+  @blocked_by
+  Scenario: issue closed without blocked pull request
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking' with '200 []'
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "closed",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {"id": 1012, "number": 12, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"},
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "issues" event by sending these following requests
+      | API request method | API request URL                                                                                        | API request payload |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking?per_page=100 |                     |
+
+  @blocked_by
+  Scenario: other issue events are ignored
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "edited",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"},
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions shouldn't do anything
+
+  @blocked_by
+  Scenario: blocker closed with references edited in the description
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls' with '200 [{"number": 34, "body": "<!-- quick-actions:dependencies:data {\"blocked_by\":[\"#12\"]} -->"}, {"number": 35, "body": "<!-- quick-actions:dependencies:data {\"blocked_by\":[\"#12\", \"12\"]} -->"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/34' with '200 {"number": 34, "body": "<!-- quick-actions:dependencies:data {\"blocked_by\":[\"#12\"]} -->", "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by' with '404 {"message": "Not Found"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12' with '200 {"id": 1012, "number": 12, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "closed",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {"id": 1012, "number": 12, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"},
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "issues" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                           |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12/dependencies/blocking?per_page=100      |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls?per_page=100&state=open                     |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/34                                          |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by?per_page=100    |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12                                         |                                                                                               |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"success","description":"All blockers are closed","context":"quick-actions/blocked"} |
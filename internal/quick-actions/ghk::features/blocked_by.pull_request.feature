@@ -0,0 +1,86 @@
+@pull_request
+Feature: keep the blocked status of pull requests up to date
+
+  Background:
+    Given event handler "blocked_by" is registered for "pull_request" events
+
+  @blocked_by
+  Scenario: new commits pushed on a blocked pull request
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '200 [{"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}]'
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "synchronize",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1, "body": "", "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "pull_request" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by?per_page=100     |                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"failure","description":"Blocked by xunleii/github-quick-actions#12","context":"quick-actions/blocked"} |
+
+  @blocked_by
+  Scenario: new commits pushed on a pull request not blocked
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '200 []'
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "synchronize",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1, "body": "", "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "pull_request" event by sending these following requests
+      | API request method | API request URL                                                                                         | API request payload |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by?per_page=100 |                     |
+
+  @blocked_by
+  Scenario: new commits pushed on a pull request with malformed dependencies
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '404 {"message": "Not Found"}'
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "synchronize",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1, "body": "<!-- quick-actions:dependencies:data {\"blocked_by\":[\"#12\", \"12\"]} -->", "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "pull_request" event but returns this error: 'invalid dependencies metadata of xunleii/github-quick-actions#1: invalid issue reference '12' (expected `#12`, `owner/repo#12` or an issue URL)'
+
+  @blocked_by
+  Scenario: blocking pull request merged
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocking' with '200 [{"id": 1034, "number": 34, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions", "pull_request": {"url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/34"}}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/34' with '200 {"number": 34, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by' with '200 [{"id": 1001, "number": 1, "state": "closed", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}]'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "closed",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1, "body": "", "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "blocked_by" for "pull_request" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                           |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocking?per_page=100       |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/34                                          |                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by?per_page=100    |                                                                                               |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"success","description":"All blockers are closed","context":"quick-actions/blocked"} |
@@ -0,0 +1,33 @@
+@pull_request_review_comment
+Feature: record blockers with /blocked_by <issue> [<issue>...] on pull request review comment
+
+  Background:
+    Given quick action "/blocked_by" is registered for "pull_request_review_comment" events
+
+  @blocked_by
+  Scenario: /blocked_by #12
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/12' with '200 {"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '201 {"id": 1001, "number": 1, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by' with '200 [{"id": 1012, "number": 12, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}]'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocked_by #12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocked_by" for "pull_request_review_comment" event with arguments ["#12"] by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/12                                         |                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by                  | {"issue_id":1012}                                                                                                |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                                  |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/dependencies/blocked_by?per_page=100     |                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"failure","description":"Blocked by xunleii/github-quick-actions#12","context":"quick-actions/blocked"} |
@@ -0,0 +1,74 @@
+@issue_comment
+Feature: record blocked issues with /blocks <issue> [<issue>...] on issue comment
+
+  Background:
+    Given quick action "/blocks" is registered for "issue_comment" events
+
+  @blocks
+  Scenario: /blocks #34 on an issue
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"id": 1001, "number": 1, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by' with '201 {"id": 1034, "number": 34, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/34' with '404 {"message": "Not Found"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocks #34", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocks" for "issue_comment" event with arguments ["#34"] by sending these following requests
+      | API request method | API request URL                                                                             | API request payload |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                          |                     |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by | {"issue_id":1001}   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/34                          |                     |
+
+  @blocks
+  Scenario: /blocks #34 on a pull request
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"id": 1001, "number": 1, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by' with '201 {"id": 1034, "number": 34, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/34' with '200 {"number": 34, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by' with '200 [{"id": 1001, "number": 1, "state": "open", "repository_url": "https://api.github.com/repos/xunleii/github-quick-actions"}]'
+    And Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e' with '201 {}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocks #34", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocks" for "issue_comment" event with arguments ["#34"] by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                             |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                                          |                                                                                                                 |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by                 | {"issue_id":1001}                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/34                                          |                                                                                                                 |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/34/dependencies/blocked_by?per_page=100    |                                                                                                                 |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"failure","description":"Blocked by xunleii/github-quick-actions#1","context":"quick-actions/blocked"} |
+
+  @blocks @error
+  Scenario: /blocks without reference
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/blocks", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/blocks" for "issue_comment" event without argument but returns this error: 'missing argument `#issue`; usage: `/blocks #issue [#issue...]`'
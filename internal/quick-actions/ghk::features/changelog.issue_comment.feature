@@ -0,0 +1,146 @@
+@issue_comment
+Feature: record a release note entry with /changelog added|fixed|changed <text> or /changelog none on issue comment
+
+  Background:
+    Given quick action "/changelog" is registered for "issue_comment" events
+
+  @changelog
+  Scenario: /changelog fixed "Crash on empty comments"
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "body": "Some description", "head": {"sha": "7638417db6d59f3c431d3e1f261cc637155684cd"}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/changelog fixed \"Crash on empty comments\"", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/changelog" for "issue_comment" event with arguments ["fixed","Crash on empty comments"] by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                                                      |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                                                          |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                                          | {"body":"Some description\\n\\n<!-- quick-actions:changelog:data {\\"kind\\":\\"fixed\\",\\"text\\":\\"Crash on empty comments\\"} -->"} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"success","description":"Changelog entry recorded (fixed)","context":"quick-actions/changelog"}                                 |
+
+  @changelog
+  Scenario: /changelog none
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "body": "Some description", "head": {"sha": "7638417db6d59f3c431d3e1f261cc637155684cd"}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/changelog none", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/changelog" for "issue_comment" event with arguments ["none"] by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                 |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                     |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                                          | {"body":"Some description\\n\\n<!-- quick-actions:changelog:data {\\"none\\":true} -->"}            |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"success","description":"No changelog entry required","context":"quick-actions/changelog"} |
+
+  @changelog
+  Scenario: /changelog Added with an unquoted text
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "body": "Some description", "head": {"sha": "7638417db6d59f3c431d3e1f261cc637155684cd"}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/changelog Added support for /poll", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/changelog" for "issue_comment" event with arguments ["Added","support","for","/poll"] by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                                                |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                                                    |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                                          | {"body":"Some description\\n\\n<!-- quick-actions:changelog:data {\\"kind\\":\\"added\\",\\"text\\":\\"support for /poll\\"} -->"} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"success","description":"Changelog entry recorded (added)","context":"quick-actions/changelog"}                           |
+
+  @changelog
+  Scenario: /changelog on an issue
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/changelog none", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/changelog" for "issue_comment" event with arguments ["none"] without sending anything
+
+  @changelog @error
+  Scenario: /changelog without argument
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/changelog", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/changelog" for "issue_comment" event without argument but returns this error: '/changelog requires a kind (`added`, `fixed` or `changed`) and a text, or `none`'
+
+  @changelog @error
+  Scenario: /changelog with an invalid kind
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/changelog removed \"Old API\"", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/changelog" for "issue_comment" event with arguments ["removed","Old API"] but returns this error: 'invalid changelog kind 'removed' (expected `added`, `fixed`, `changed` or `none`)'
+
+  @changelog @error
+  Scenario: /changelog without text
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/changelog added", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/changelog" for "issue_comment" event with arguments ["added"] but returns this error: '/changelog added requires a text (like `/changelog added "Some change"`)'
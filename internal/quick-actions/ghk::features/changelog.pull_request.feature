@@ -0,0 +1,27 @@
+@pull_request
+Feature: record a release note entry with /changelog added|fixed|changed <text> or /changelog none on pull request
+
+  Background:
+    Given quick action "/changelog" is registered for "pull_request" events
+
+  @changelog
+  Scenario: /changelog fixed "Crash on empty comments"
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "body": "Some description", "head": {"sha": "7638417db6d59f3c431d3e1f261cc637155684cd"}}'
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "created",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1, "body": "/changelog fixed \"Crash on empty comments\"" },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/changelog" for "pull_request" event with arguments ["fixed","Crash on empty comments"] by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                                                      |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                                                          |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                                          | {"body":"Some description\\n\\n<!-- quick-actions:changelog:data {\\"kind\\":\\"fixed\\",\\"text\\":\\"Crash on empty comments\\"} -->"} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"success","description":"Changelog entry recorded (fixed)","context":"quick-actions/changelog"}                                 |
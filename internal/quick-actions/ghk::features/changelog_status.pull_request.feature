@@ -0,0 +1,91 @@
+@pull_request
+Feature: set the changelog commit status on new pull requests and new commits
+
+  Background:
+    Given event handler "changelog_status" is registered for "pull_request" events
+
+  @changelog_status
+  Scenario: new pull request without changelog entry
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "opened",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "body": "Some description",
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "changelog_status" for "pull_request" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                                                                                 |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"failure","description":"Missing changelog entry; use /changelog added\|fixed\|changed \\"text\\" or /changelog none","context":"quick-actions/changelog"} |
+
+  @changelog_status
+  Scenario: new commits pushed on a pull request with a changelog entry
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "synchronize",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "body": "Some description\n\n<!-- quick-actions:changelog:data {\"kind\":\"added\",\"text\":\"Support for /poll\"} -->",
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "changelog_status" for "pull_request" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                      |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"success","description":"Changelog entry recorded (added)","context":"quick-actions/changelog"} |
+
+  @changelog_status
+  Scenario: reopened pull request without changelog required
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "reopened",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "body": "<!-- quick-actions:changelog:data {\"none\":true} -->",
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "changelog_status" for "pull_request" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                 |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"success","description":"No changelog entry required","context":"quick-actions/changelog"} |
+
+  @changelog_status
+  Scenario: other pull request events are ignored
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "edited",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "body": "Some description",
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions shouldn't do anything
@@ -0,0 +1,71 @@
+@issue_comment
+Feature: add sub-issues with /child <issue> [<issue>...] on issue comment
+
+  Background:
+    Given quick action "/child" is registered for "issue_comment" events
+
+  @child
+  Scenario: /child #34 #35
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": null}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issue": {"id": "I_34"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issue": {"id": "I_35"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"addSubIssue": {"issue": {"id": "I_1"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"addSubIssue": {"issue": {"id": "I_1"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/child #34 #35", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/child" for "issue_comment" event with arguments ["#34","#35"] by sending these following requests
+      | API request method | API request URL                | API request payload                                                                                                                                                                                      |
+      | POST               | https://api.github.com/graphql | {"query":"query($id:ID!){node(id: $id){... on Issue{parent{id}}}}","variables":{"id":"I_1"}}                                                                                                             |
+      | POST               | https://api.github.com/graphql | {"query":"query($name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issue(number: $number){id}}}","variables":{"name":"github-quick-actions","number":34,"owner":"xunleii"}} |
+      | POST               | https://api.github.com/graphql | {"query":"query($name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issue(number: $number){id}}}","variables":{"name":"github-quick-actions","number":35,"owner":"xunleii"}} |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:AddSubIssueInput!){addSubIssue(input: $input){issue{id}}}","variables":{"input":{"issueId":"I_1","subIssueId":"I_34","replaceParent":true}}}                                   |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:AddSubIssueInput!){addSubIssue(input: $input){issue{id}}}","variables":{"input":{"issueId":"I_1","subIssueId":"I_35","replaceParent":true}}}                                   |
+
+  @child @error
+  Scenario: /child #34 creating a cycle
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": {"id": "I_12"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": {"id": "I_34"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": null}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issue": {"id": "I_34"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/child #34", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/child" for "issue_comment" event with arguments ["#34"] but returns this error: 'cannot add xunleii/github-quick-actions#34 as child: it would create a cycle'
+
+  @child @error
+  Scenario: /child without reference
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/child", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/child" for "issue_comment" event without argument but returns this error: 'missing argument `#issue`; usage: `/child #issue [#issue...]`'
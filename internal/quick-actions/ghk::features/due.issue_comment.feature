@@ -0,0 +1,179 @@
+@issue_comment
+Feature: set due date with /due <date> on issue comment
+
+  Background:
+    Given quick action "/due" is registered for "issue_comment" events
+
+  @due
+  Scenario: /due 2026-11-01
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issueOrPullRequest": {"projectItems": {"nodes": []}}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/due 2026-11-01", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issue_comment" event with arguments ["2026-11-01"] by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description\\n\\n<!-- quick-actions:due:data {\\"date\\":\\"2026-11-01\\"} -->"}                                                                                                                                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
+
+  @due
+  Scenario: /due in 2 weeks
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issueOrPullRequest": {"projectItems": {"nodes": []}}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/due in 2 weeks", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issue_comment" event with arguments ["in","2","weeks"] by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description\\n\\n<!-- quick-actions:due:data {\\"date\\":\\"2026-11-02\\"} -->"}                                                                                                                                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
+
+  @due
+  Scenario: /due tomorrow with an existing due date
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:due:data {\"date\":\"2026-10-01\",\"reminded\":true} -->"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issueOrPullRequest": {"projectItems": {"nodes": []}}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/due tomorrow", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:due:data {\"date\":\"2026-10-01\",\"reminded\":true} -->" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issue_comment" event with arguments ["tomorrow"] by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description\\n\\n<!-- quick-actions:due:data {\\"date\\":\\"2026-10-20\\"} -->"}                                                                                                                                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
+
+  @due
+  Scenario: /due 2026-11-01 on an issue in a project
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issueOrPullRequest": {"projectItems": {"nodes": [{"id": "PVTI_1", "project": {"id": "PVT_1", "field": {"id": "PVTF_1"}}}, {"id": "PVTI_2", "project": {"id": "PVT_2", "field": null}}]}}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "PVTI_1"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/due 2026-11-01", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issue_comment" event with arguments ["2026-11-01"] by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description\\n\\n<!-- quick-actions:due:data {\\"date\\":\\"2026-11-01\\"} -->"}                                                                                                                                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
+      | POST               | https://api.github.com/graphql                                     | {"query":"mutation($input:UpdateProjectV2ItemFieldValueInput!){updateProjectV2ItemFieldValue(input: $input){projectV2Item{id}}}","variables":{"input":{"projectId":"PVT_1","itemId":"PVTI_1","fieldId":"PVTF_1","value":{"date":"2026-11-01"}}}}                                                                                                                                                                                                                                 |
+
+  @due
+  Scenario: /due 2026-11-01 keeps the metadata stored since the event
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\"} -->"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issueOrPullRequest": {"projectItems": {"nodes": []}}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/due 2026-11-01", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issue_comment" event with arguments ["2026-11-01"] by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description\\n\\n<!-- quick-actions:snooze:data {\\"until\\":\\"2026-10-26T10:00:00Z\\"} -->\\n\\n<!-- quick-actions:due:data {\\"date\\":\\"2026-11-01\\"} -->"}                                                                                                                                                                                                                                                                                                  |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
+
+  @due
+  Scenario: /due 2026-11-01 when the projects can't be updated
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '500 {"message": "Internal Server Error"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/due 2026-11-01", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issue_comment" event with arguments ["2026-11-01"] by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description\\n\\n<!-- quick-actions:due:data {\\"date\\":\\"2026-11-01\\"} -->"}                                                                                                                                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
+
+  @due @error
+  Scenario: /due without date
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/due", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issue_comment" event without argument but returns this error: 'missing argument `<date>`; usage: `/due <date>`'
+
+  @due @error
+  Scenario: /due with an invalid date
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/due next friday", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issue_comment" event with arguments ["next","friday"] but returns this error: 'invalid date 'next'; usage: `/due <date>`'
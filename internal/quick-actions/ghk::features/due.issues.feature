@@ -0,0 +1,32 @@
+@issues
+Feature: set due date with /due <date> on issue description
+
+  Background:
+    Given quick action "/due" is registered for "issues" events
+
+  @due
+  Scenario: /due in 1 month
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n/due in 1 month"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issueOrPullRequest": {"projectItems": {"nodes": []}}}}}'
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "created",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {
+          "body": "Some description\n/due in 1 month",
+          "number": 1,
+          "user": { "login":"xunleii" },
+          "created_at": "2026-10-19T10:00:00Z"
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/due" for "issues" event with arguments ["in","1","month"] by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description\\n/due in 1 month\\n\\n<!-- quick-actions:due:data {\\"date\\":\\"2026-11-19\\"} -->"}                                                                                                                                                                                                                                                                                                                                                                 |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
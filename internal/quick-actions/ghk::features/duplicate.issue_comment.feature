@@ -24,6 +24,26 @@ Feature: assign someone with /duplicate #issue [#issue...] on issue comment
       | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1          |                            |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/2/comments | {"body":"Duplicate of #1"} |
 
+  @duplicate
+  Scenario: /duplicate octocat/hello-world#1
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/duplicate octocat/hello-world#1", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 2 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/duplicate" for "issue_comment" event with arguments ["octocat/hello-world#1"] by sending these following requests
+      | API request method | API request URL                                                             | API request payload                           |
+      | GET                | https://api.github.com/repos/octocat/hello-world/issues/1                   |                                               |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/2/comments | {"body":"Duplicate of octocat/hello-world#1"} |
+
   @duplicate
   Scenario: /duplicate #1 #2
     When Github sends an event "issue_comment" with
@@ -81,7 +101,7 @@ Feature: assign someone with /duplicate #issue [#issue...] on issue comment
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/duplicate" for "issue_comment" event with arguments ["1"] without sending anything
+    Then Github Quick Actions should handle command "/duplicate" for "issue_comment" event with arguments ["1"] but returns this error: 'invalid issue-ref '1'; usage: `/duplicate #issue [#issue...]`'
 
   @duplicate
   Scenario: invalid /duplicate wrong #issue
@@ -98,7 +118,7 @@ Feature: assign someone with /duplicate #issue [#issue...] on issue comment
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/duplicate" for "issue_comment" event with arguments ["wrong","#issue"] without sending anything
+    Then Github Quick Actions should handle command "/duplicate" for "issue_comment" event with arguments ["wrong","#issue"] but returns this error: 'invalid issue-ref 'wrong'; usage: `/duplicate #issue [#issue...]`'
 
   @duplicate @error
   Scenario: /duplicate without arguments
@@ -115,7 +135,7 @@ Feature: assign someone with /duplicate #issue [#issue...] on issue comment
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/duplicate" for "issue_comment" event without argument without sending anything
+    Then Github Quick Actions should handle command "/duplicate" for "issue_comment" event without argument but returns this error: 'missing argument `#issue`; usage: `/duplicate #issue [#issue...]`'
 
   @duplicate @error
   Scenario: /duplicate itself
@@ -81,7 +81,7 @@ Feature: assign someone with /duplicate #issue [#issue...] on review comment
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/duplicate" for "pull_request_review_comment" event with arguments ["1"] without sending anything
+    Then Github Quick Actions should handle command "/duplicate" for "pull_request_review_comment" event with arguments ["1"] but returns this error: 'invalid issue-ref '1'; usage: `/duplicate #issue [#issue...]`'
 
   @duplicate
   Scenario: invalid /duplicate wrong #issue
@@ -98,7 +98,7 @@ Feature: assign someone with /duplicate #issue [#issue...] on review comment
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/duplicate" for "pull_request_review_comment" event with arguments ["wrong","#issue"] without sending anything
+    Then Github Quick Actions should handle command "/duplicate" for "pull_request_review_comment" event with arguments ["wrong","#issue"] but returns this error: 'invalid issue-ref 'wrong'; usage: `/duplicate #issue [#issue...]`'
 
   @duplicate @error
   Scenario: /duplicate without arguments
@@ -115,7 +115,7 @@ Feature: assign someone with /duplicate #issue [#issue...] on review comment
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/duplicate" for "pull_request_review_comment" event without argument without sending anything
+    Then Github Quick Actions should handle command "/duplicate" for "pull_request_review_comment" event without argument but returns this error: 'missing argument `#issue`; usage: `/duplicate #issue [#issue...]`'
 
   @duplicate @error
   Scenario: /duplicate itself
@@ -0,0 +1,88 @@
+@issue_comment
+Feature: set time estimate with /estimate <duration> on issue comment
+
+  Background:
+    Given quick action "/estimate" is registered for "issue_comment" events
+
+  @estimate
+  Scenario: /estimate 1d2h
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/estimate 1d2h", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/estimate" for "issue_comment" event with arguments ["1d2h"] by sending these following requests
+      | API request method | API request URL                                                                          | API request payload                                                                                                                                                                        |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100 |                                                                                                                                                                                            |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments              | {"body":"<!-- quick-actions:time_tracking -->\\n#### Time tracking\\n\\n**Estimate:** 1d 2h<br>\\n**Time spent:** 0m\\n\\n<!-- quick-actions:time_tracking:data {\\"estimate\\":600} -->"} |
+
+  @estimate
+  Scenario: /estimate 1w 30m with an existing summary
+    Given Github replies to 'GET https://api.github.com/app' with '200 {"slug": "github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100' with '200 [{"id": 42, "user": {"login": "mojombo", "type": "User"}, "body": "<!-- quick-actions:time_tracking --> forged"}, {"id": 44, "user": {"login": "other-app[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking --> forged by another application"}, {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43' with '200 {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/estimate 1w 30m", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/estimate" for "issue_comment" event with arguments ["1w","30m"] by sending these following requests
+      | API request method | API request URL                                                                          | API request payload                                                                                                                                                                                                                                                                                                                                                        |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100 |                                                                                                                                                                                                                                                                                                                                                                            |
+      | GET                | https://api.github.com/app                                                               |                                                                                                                                                                                                                                                                                                                                                                            |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43             |                                                                                                                                                                                                                                                                                                                                                                            |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43             | {"body":"<!-- quick-actions:time_tracking -->\\n#### Time tracking\\n\\n**Estimate:** 1w 30m<br>\\n**Time spent:** 1h 30m\\n\\n\| User \| Time spent \|\\n\| :--- \| ---------: \|\\n\| @mojombo \| 1h 30m \|\\n\\n<!-- quick-actions:time_tracking:data {\\"estimate\\":2430,\\"spent\\":[{\\"user\\":\\"mojombo\\",\\"minutes\\":90,\\"date\\":\\"2026-10-18\\"}]} -->"} |
+
+  @estimate @error
+  Scenario: /estimate with invalid duration
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/estimate 2y", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/estimate" for "issue_comment" event with arguments ["2y"] but returns this error: 'invalid duration '2y'; usage: `/estimate <duration> [<duration>...]`'
+
+  @estimate @error
+  Scenario: /estimate without argument
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/estimate", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/estimate" for "issue_comment" event without argument but returns this error: 'missing argument `<duration>`; usage: `/estimate <duration> [<duration>...]`'
@@ -0,0 +1,26 @@
+@pull_request_review_comment
+Feature: set time estimate with /estimate <duration> on pull request review comment
+
+  Background:
+    Given quick action "/estimate" is registered for "pull_request_review_comment" events
+
+  @estimate
+  Scenario: /estimate 1d2h
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/estimate 1d2h", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/estimate" for "pull_request_review_comment" event with arguments ["1d2h"] by sending these following requests
+      | API request method | API request URL                                                                          | API request payload                                                                                                                                                                        |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100 |                                                                                                                                                                                            |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments              | {"body":"<!-- quick-actions:time_tracking -->\\n#### Time tracking\\n\\n**Estimate:** 1d 2h<br>\\n**Time spent:** 0m\\n\\n<!-- quick-actions:time_tracking:data {\\"estimate\\":600} -->"} |
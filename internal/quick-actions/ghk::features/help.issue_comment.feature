@@ -0,0 +1,79 @@
+@issue_comment
+Feature: list available quick actions with /help [<command>] on issue comment
+
+  Background:
+    Given quick action "/help" is registered for "issue_comment" events
+
+  @help
+  Scenario: /help
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/help", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/help" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                             | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments | {"body":"#### Quick actions available on `issue_comment` events\\n\\n\| Command \| Description \|\\n\| :------ \| :---------- \|\\n\| `/duplicate #issue [#issue...]` \| Close this issue and mark as a duplicate of another issue (from this repository or another one). \|\\n\| `/help [<command>]` \| List the quick actions available here, or describe the given one. \|\\n\| `/label ~label [~label...]`<br>`/label ~label:color[=\\"description\\"] [~label...]` \| Add one or more labels. \|\\n\| `/unlabel [~label [~label...]]` \| Remove specified labels, or all of them. \|\\n\\n_Use `/help <command>` to get more details about a quick action._"} |
+
+  @help
+  Scenario: /help label
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/help label", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/help" for "issue_comment" event with arguments ["label"] by sending these following requests
+      | API request method | API request URL                                                             | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments | {"body":"#### `/label`\\n\\nAdd one or more labels.\\n_Label names can also start without a tilde (`~`). Labels missing from the repository are added anyway (`allow`), refused with the closest existing labels (`reject`) or only created with a color (`create`), depending on the label policy (see `GQA_LABEL_POLICY` or the `policy` option); unless refused, they are created with the given color and description, like `~bug:d73a4a=\\"Something isn't working\\"`._\\n\\n**Usage:**\\n- `/label ~label [~label...]`\\n- `/label ~label:color[=\\"description\\"] [~label...]`\\n\\n**Examples:**\\n- `/label ~bug ~help-wanted`\\n\\n**Required permission:** `triage`\\n\\n**Available on:** `issue_comment`, `issues`, `pull_request`, `pull_request_review_comment`"} |
+
+  @help
+  Scenario: /help /remove_label
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/help /remove_label", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/help" for "issue_comment" event with arguments ["/remove_label"] by sending these following requests
+      | API request method | API request URL                                                             | API request payload                                                                                                                                                                                                                                                                                                                                                                     |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments | {"body":"#### `/unlabel`\\n\\nRemove specified labels, or all of them.\\n_Label names can also start without a tilde (`~`)._\\n\\n**Usage:**\\n- `/unlabel [~label [~label...]]`\\n\\n**Aliases:** `/remove_label`\\n\\n**Examples:**\\n- `/unlabel ~bug`\\n- `/unlabel`\\n\\n**Required permission:** `triage`\\n\\n**Available on:** `issue_comment`, `pull_request_review_comment`"} |
+
+  @help @error
+  Scenario: /help unknown
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/help unknown", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/help" for "issue_comment" event with arguments ["unknown"] but returns this error: 'quick action '/unknown' doesn't exist on issue_comment events (see `/help`)'
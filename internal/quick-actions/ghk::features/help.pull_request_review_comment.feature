@@ -0,0 +1,43 @@
+@pull_request_review_comment
+Feature: list available quick actions with /help [<command>] on review comment
+
+  Background:
+    Given quick action "/help" is registered for "pull_request_review_comment" events
+
+  @help
+  Scenario: /help
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/help", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/help" for "pull_request_review_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                             | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments | {"body":"#### Quick actions available on `pull_request_review_comment` events\\n\\n\| Command \| Description \|\\n\| :------ \| :---------- \|\\n\| `/duplicate #issue [#issue...]` \| Close this issue and mark as a duplicate of another issue (from this repository or another one). \|\\n\| `/help [<command>]` \| List the quick actions available here, or describe the given one. \|\\n\| `/label ~label [~label...]`<br>`/label ~label:color[=\\"description\\"] [~label...]` \| Add one or more labels. \|\\n\| `/unlabel [~label [~label...]]` \| Remove specified labels, or all of them. \|\\n\\n_Use `/help <command>` to get more details about a quick action._"} |
+
+  @help
+  Scenario: /help label
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/help label", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/help" for "pull_request_review_comment" event with arguments ["label"] by sending these following requests
+      | API request method | API request URL                                                             | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                                |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments | {"body":"#### `/label`\\n\\nAdd one or more labels.\\n_Label names can also start without a tilde (`~`). Labels missing from the repository are added anyway (`allow`), refused with the closest existing labels (`reject`) or only created with a color (`create`), depending on the label policy (see `GQA_LABEL_POLICY` or the `policy` option); unless refused, they are created with the given color and description, like `~bug:d73a4a=\\"Something isn't working\\"`._\\n\\n**Usage:**\\n- `/label ~label [~label...]`\\n- `/label ~label:color[=\\"description\\"] [~label...]`\\n\\n**Examples:**\\n- `/label ~bug ~help-wanted`\\n\\n**Required permission:** `triage`\\n\\n**Available on:** `issue_comment`, `issues`, `pull_request`, `pull_request_review_comment`"} |
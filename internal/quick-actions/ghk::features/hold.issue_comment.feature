@@ -0,0 +1,62 @@
+@issue_comment
+Feature: hold a pull request with /hold on issue comment
+
+  Background:
+    Given quick action "/hold" is registered for "issue_comment" events
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}, "base": {"ref": "main"}}'
+
+  @hold
+  Scenario: /hold
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/hold", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/hold" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                             |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                                 |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels                                   | ["do-not-merge/hold"]                                                                                           |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"failure","description":"Pull request held; use /unhold to release it","context":"quick-actions/hold"} |
+
+  @hold
+  Scenario: /hold on an issue
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/hold", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/hold" for "issue_comment" event without argument without sending anything
+
+  @hold @error
+  Scenario: error handling on /hold
+    Given Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels' with '404 {"message": "Not Found", "documentation_url": "https://docs.github.com/en/rest/reference/issues#add-labels-to-an-issue"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/hold", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/hold" for "issue_comment" event without argument but returns this error: 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels: 404 Not Found []'
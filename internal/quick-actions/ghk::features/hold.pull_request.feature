@@ -0,0 +1,67 @@
+@pull_request
+Feature: keep the hold on new commits pushed on a held pull request
+
+  Background:
+    Given event handler "hold" is registered for "pull_request" events
+
+  @hold
+  Scenario: new commits pushed on a held pull request
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "synchronize",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "labels": [{ "name": "kind/feature" }, { "name": "do-not-merge/hold" }],
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "hold" for "pull_request" event by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                             |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/7638417db6d59f3c431d3e1f261cc637155684cd | {"state":"failure","description":"Pull request held; use /unhold to release it","context":"quick-actions/hold"} |
+
+  @hold
+  Scenario: new commits pushed on a pull request not held
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "synchronize",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "labels": [{ "name": "kind/feature" }],
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "hold" for "pull_request" event without sending anything
+
+  @hold
+  Scenario: other pull request events are ignored
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "edited",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "labels": [{ "name": "do-not-merge/hold" }],
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions shouldn't do anything
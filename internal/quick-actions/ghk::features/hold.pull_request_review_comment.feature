@@ -0,0 +1,27 @@
+@pull_request_review_comment
+Feature: hold a pull request with /hold on pull request review comment
+
+  Background:
+    Given quick action "/hold" is registered for "pull_request_review_comment" events
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}, "base": {"ref": "main"}}'
+
+  @hold
+  Scenario: /hold
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/hold", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/hold" for "pull_request_review_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                                             |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                                                 |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels                                   | ["do-not-merge/hold"]                                                                                           |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"failure","description":"Pull request held; use /unhold to release it","context":"quick-actions/hold"} |
@@ -76,7 +76,45 @@ Feature: add label with /label ~label [~label...] on issue comment
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/label" for "issue_comment" event without argument without sending anything
+    Then Github Quick Actions should handle command "/label" for "issue_comment" event without argument but returns this error: 'missing argument `~label`; usage: `/label ~label [~label...]`'
+
+  @label
+  Scenario: /label ~feature added on edited comment
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "edited",
+        "comment": { "body": "/label ~bug\n/label ~feature" },
+        "changes": { "body": { "from": "/label ~bug\n/lable ~feature" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/label" for "issue_comment" event with arguments ["~feature"] by sending these following requests
+      | API request method | API request URL                                                           | API request payload |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["feature"]         |
+
+  @label
+  Scenario: edited comment without new command
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "edited",
+        "comment": { "body": "/label ~bug\n\nSome typo fixed" },
+        "changes": { "body": { "from": "/label ~bug\n\nSome tpyo fixed" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions shouldn't do anything
 
   @label @error
   Scenario: error handling on /label
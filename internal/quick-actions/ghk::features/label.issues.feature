@@ -1,12 +1,12 @@
-@issue
+@issues
 Feature: add label with /label ~label [~label...] on issue description
 
   Background:
-    Given quick action "/label" is registered for "issue" events
+    Given quick action "/label" is registered for "issues" events
 
   @label
   Scenario: /label ~feature
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -22,13 +22,13 @@ Feature: add label with /label ~label [~label...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/label" for "issue" event with arguments ["~feature"] by sending these following requests
+    Then Github Quick Actions should handle command "/label" for "issues" event with arguments ["~feature"] by sending these following requests
       | API request method | API request URL                                                           | API request payload |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["feature"]         |
 
   @label
   Scenario: /label ~feature ~bug:critical
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -44,13 +44,13 @@ Feature: add label with /label ~label [~label...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/label" for "issue" event with arguments ["~feature","~bug:critical"] by sending these following requests
+    Then Github Quick Actions should handle command "/label" for "issues" event with arguments ["~feature","~bug:critical"] by sending these following requests
       | API request method | API request URL                                                           | API request payload        |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["feature","bug:critical"] |
 
   @label
   Scenario: /label ~feature feature
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -66,13 +66,13 @@ Feature: add label with /label ~label [~label...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/label" for "issue" event with arguments ["~feature","feature"] by sending these following requests
+    Then Github Quick Actions should handle command "/label" for "issues" event with arguments ["~feature","feature"] by sending these following requests
       | API request method | API request URL                                                           | API request payload |
       | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["feature"]         |
 
   @label @error
   Scenario: /label without argument
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -88,12 +88,12 @@ Feature: add label with /label ~label [~label...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/label" for "issue" event without argument without sending anything
+    Then Github Quick Actions should handle command "/label" for "issues" event without argument but returns this error: 'missing argument `~label`; usage: `/label ~label [~label...]`'
 
   @label @error
   Scenario: error handling on /label
     Given Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels' with '404 {"message": "Not Found", "documentation_url": "https://docs.github.com/en/rest/reference/issues#add-labels-to-an-issue"}'
-    When Github sends an event "issue" with
+    When Github sends an event "issues" with
       """
       {
         "action": "created",
@@ -109,4 +109,4 @@ Feature: add label with /label ~label [~label...] on issue description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/label" for "issue" event with arguments ["~feature"] but returns this error: 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels: 404 Not Found []'
+    Then Github Quick Actions should handle command "/label" for "issues" event with arguments ["~feature"] but returns this error: 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels: 404 Not Found []'
@@ -88,7 +88,7 @@ Feature: add label with /label ~label [~label...] on pull_request description
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/label" for "pull_request" event without argument without sending anything
+    Then Github Quick Actions should handle command "/label" for "pull_request" event without argument but returns this error: 'missing argument `~label`; usage: `/label ~label [~label...]`'
 
   @label @error
   Scenario: error handling on /label
@@ -76,7 +76,7 @@ Feature: add label with /label ~label [~label...] on pull request review comment
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/label" for "pull_request_review_comment" event without argument without sending anything
+    Then Github Quick Actions should handle command "/label" for "pull_request_review_comment" event without argument but returns this error: 'missing argument `~label`; usage: `/label ~label [~label...]`'
 
   @label @error
   Scenario: error handling on /label
@@ -0,0 +1,101 @@
+@issue_comment
+Feature: mark a pull request as "looks good to me" with /lgtm [cancel] on issue comment
+
+  Background:
+    Given quick action "/lgtm" is registered for "issue_comment" events
+
+  @lgtm
+  Scenario: /lgtm
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/lgtm", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "user": { "login": "mojombo" }, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/lgtm" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                           | API request payload |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["lgtm"]            |
+
+  @lgtm
+  Scenario: /lgtm cancel
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/lgtm cancel", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "user": { "login": "mojombo" }, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/lgtm" for "issue_comment" event with arguments ["cancel"] by sending these following requests
+      | API request method | API request URL                                                                | API request payload |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/lgtm |                     |
+
+  @lgtm
+  Scenario: /lgtm by the pull request author
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/lgtm", "user": { "login":"mojombo" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "user": { "login": "mojombo" }, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "mojombo" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/lgtm" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                             | API request payload                                           |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments | {"body":"@mojombo you cannot `/lgtm` your own pull request."} |
+
+  @lgtm
+  Scenario: /lgtm on an issue
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/lgtm", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "user": { "login": "mojombo" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/lgtm" for "issue_comment" event without argument without sending anything
+
+  @lgtm @error
+  Scenario: /lgtm with invalid arguments
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/lgtm please", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "user": { "login": "mojombo" }, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/lgtm" for "issue_comment" event with arguments ["please"] but returns this error: 'invalid arguments [please] for /lgtm'
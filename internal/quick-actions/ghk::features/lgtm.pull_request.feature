@@ -0,0 +1,47 @@
+@pull_request
+Feature: remove the lgtm label when new commits are pushed
+
+  Background:
+    Given event handler "lgtm" is registered for "pull_request" events
+
+  @lgtm
+  Scenario: new commits pushed on a pull request marked as lgtm
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "synchronize",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "labels": [{ "name": "lgtm" }],
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "lgtm" for "pull_request" event by sending these following requests
+      | API request method | API request URL                                                                | API request payload |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/lgtm |                     |
+
+  @lgtm
+  Scenario: new commits pushed on a pull request not marked as lgtm
+    When Github sends an event "pull_request" with
+      """
+      {
+        "action": "synchronize",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": {
+          "number": 1,
+          "labels": [{ "name": "kind/feature" }],
+          "head": { "ref": "feature", "sha": "7638417db6d59f3c431d3e1f261cc637155684cd" }
+        },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "lgtm" for "pull_request" event without sending anything
@@ -0,0 +1,45 @@
+@pull_request_review_comment
+Feature: mark a pull request as "looks good to me" with /lgtm [cancel] on pull request review comment
+
+  Background:
+    Given quick action "/lgtm" is registered for "pull_request_review_comment" events
+
+  @lgtm
+  Scenario: /lgtm
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/lgtm", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1, "user": { "login": "mojombo" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/lgtm" for "pull_request_review_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                           | API request payload |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["lgtm"]            |
+
+  @lgtm
+  Scenario: /lgtm by the pull request author
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/lgtm", "user": { "login":"mojombo" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1, "user": { "login": "mojombo" } },
+        "sender": { "login": "mojombo" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/lgtm" for "pull_request_review_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                             | API request payload                                           |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments | {"body":"@mojombo you cannot `/lgtm` your own pull request."} |
@@ -0,0 +1,169 @@
+@issue_comment
+Feature: set parent issue with /parent <issue> on issue comment
+
+  Background:
+    Given quick action "/parent" is registered for "issue_comment" events
+
+  @parent
+  Scenario: /parent #12
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issue": {"id": "I_12"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": null}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"addSubIssue": {"issue": {"id": "I_12"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/parent #12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/parent" for "issue_comment" event with arguments ["#12"] by sending these following requests
+      | API request method | API request URL                | API request payload                                                                                                                                                                                      |
+      | POST               | https://api.github.com/graphql | {"query":"query($name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issue(number: $number){id}}}","variables":{"name":"github-quick-actions","number":12,"owner":"xunleii"}} |
+      | POST               | https://api.github.com/graphql | {"query":"query($id:ID!){node(id: $id){... on Issue{parent{id}}}}","variables":{"id":"I_12"}}                                                                                                            |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:AddSubIssueInput!){addSubIssue(input: $input){issue{id}}}","variables":{"input":{"issueId":"I_12","subIssueId":"I_1","replaceParent":true}}}                                   |
+
+  @parent
+  Scenario: /parent xunleii/roadmap#12
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issue": {"id": "I_12"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": {"id": "I_5"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": null}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"addSubIssue": {"issue": {"id": "I_12"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/parent xunleii/roadmap#12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/parent" for "issue_comment" event with arguments ["xunleii/roadmap#12"] by sending these following requests
+      | API request method | API request URL                | API request payload                                                                                                                                                                         |
+      | POST               | https://api.github.com/graphql | {"query":"query($name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issue(number: $number){id}}}","variables":{"name":"roadmap","number":12,"owner":"xunleii"}} |
+      | POST               | https://api.github.com/graphql | {"query":"query($id:ID!){node(id: $id){... on Issue{parent{id}}}}","variables":{"id":"I_12"}}                                                                                               |
+      | POST               | https://api.github.com/graphql | {"query":"query($id:ID!){node(id: $id){... on Issue{parent{id}}}}","variables":{"id":"I_5"}}                                                                                                |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:AddSubIssueInput!){addSubIssue(input: $input){issue{id}}}","variables":{"input":{"issueId":"I_12","subIssueId":"I_1","replaceParent":true}}}                      |
+
+  @parent
+  Scenario: /parent https://github.com/xunleii/roadmap/issues/12
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issue": {"id": "I_12"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": null}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"addSubIssue": {"issue": {"id": "I_12"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/parent https://github.com/xunleii/roadmap/issues/12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/parent" for "issue_comment" event with arguments ["https://github.com/xunleii/roadmap/issues/12"] by sending these following requests
+      | API request method | API request URL                | API request payload                                                                                                                                                                         |
+      | POST               | https://api.github.com/graphql | {"query":"query($name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issue(number: $number){id}}}","variables":{"name":"roadmap","number":12,"owner":"xunleii"}} |
+      | POST               | https://api.github.com/graphql | {"query":"query($id:ID!){node(id: $id){... on Issue{parent{id}}}}","variables":{"id":"I_12"}}                                                                                               |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:AddSubIssueInput!){addSubIssue(input: $input){issue{id}}}","variables":{"input":{"issueId":"I_12","subIssueId":"I_1","replaceParent":true}}}                      |
+
+  @parent @error
+  Scenario: /parent #12 creating a cycle
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issue": {"id": "I_12"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": {"id": "I_5"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": {"id": "I_1"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/parent #12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/parent" for "issue_comment" event with arguments ["#12"] but returns this error: 'cannot set xunleii/github-quick-actions#12 as parent: it would create a cycle'
+
+  @parent @error
+  Scenario: /parent #1 on itself
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issue": {"id": "I_1"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": null}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/parent #1", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/parent" for "issue_comment" event with arguments ["#1"] but returns this error: 'cannot set xunleii/github-quick-actions#1 as parent: it would create a cycle'
+
+  @parent @error
+  Scenario: /parent with an invalid reference
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/parent 12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/parent" for "issue_comment" event with arguments ["12"] but returns this error: 'invalid issue-ref '12'; usage: `/parent #issue`'
+
+  @parent @error
+  Scenario: /parent without reference
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/parent", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/parent" for "issue_comment" event without argument but returns this error: 'missing argument `#issue`; usage: `/parent #issue`'
+
+  @parent
+  Scenario: /parent #12 on a pull request
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/parent #12", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "PR_1", "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/parent" for "issue_comment" event with arguments ["#12"] without sending anything
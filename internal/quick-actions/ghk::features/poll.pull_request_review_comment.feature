@@ -0,0 +1,55 @@
+@pull_request_review_comment
+Feature: create a reaction-based poll with /poll <question> <option> [<option>...] on pull request review comment
+
+  Background:
+    Given quick action "/poll" is registered for "pull_request_review_comment" events
+
+  @poll
+  Scenario: /poll "Which release name?" "Argon" "Boron" "Carbon"
+    Given Github replies to 'POST https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments' with '201 {"id": 42}'
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/poll \"Which release name?\" \"Argon\" \"Boron\" \"Carbon\"", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/poll" for "pull_request_review_comment" event with arguments ["Which release name?","Argon","Boron","Carbon"] by sending these following requests
+      | API request method | API request URL                                                                        | API request payload                                                                                                                                                                                                                                                                                                                                                                                                |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments            | {"body":"<!-- quick-actions:poll -->\\n#### :bar_chart: Which release name?\\n\\n_React to this comment to vote (only your first vote is counted)._\\n\\n\| Vote \| Option \|\\n\| :--: \| :----- \|\\n\| :+1: \| Argon \|\\n\| :-1: \| Boron \|\\n\| :smile: \| Carbon \|\\n\\n<!-- quick-actions:poll:data {\\"question\\":\\"Which release name?\\",\\"options\\":[\\"Argon\\",\\"Boron\\",\\"Carbon\\"]} -->"} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/42/reactions | {"content":"+1"}                                                                                                                                                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/42/reactions | {"content":"-1"}                                                                                                                                                                                                                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/42/reactions | {"content":"laugh"}                                                                                                                                                                                                                                                                                                                                                                                                |
+
+  @poll
+  Scenario: /poll close
+    Given Github replies to 'GET https://api.github.com/app' with '200 {"slug": "github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments' with '200 [{"id": 41, "body": "<!-- quick-actions:poll -->\n#### :bar_chart: Which release name?\n\n<!-- quick-actions:poll:data {\"question\":\"Which release name?\",\"options\":[\"Argon\",\"Boron\"]} -->", "user": {"login": "github-quick-actions[bot]", "type": "Bot"}}, {"id": 42, "body": "<!-- quick-actions:poll -->\n#### :bar_chart: Old question? (closed)\n\n<!-- quick-actions:poll:data {\"question\":\"Old question?\",\"options\":[\"Yes\",\"No\"],\"closed\":true,\"votes\":[1,0]} -->", "user": {"login": "github-quick-actions[bot]", "type": "Bot"}}, {"id": 43, "body": "<!-- quick-actions:poll -->\n#### :bar_chart: Forged?\n\n<!-- quick-actions:poll:data {\"question\":\"Forged?\",\"options\":[\"Argon\",\"Boron\"]} -->", "user": {"login": "mojombo", "type": "User"}}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/41/reactions' with '200 [{"content": "+1", "user": {"id": 1, "login": "github-quick-actions[bot]", "type": "Bot"}}, {"content": "-1", "user": {"id": 1, "login": "github-quick-actions[bot]", "type": "Bot"}}, {"content": "+1", "user": {"id": 2, "login": "xunleii", "type": "User"}}, {"content": "-1", "user": {"id": 3, "login": "mojombo", "type": "User"}}, {"content": "+1", "user": {"id": 3, "login": "mojombo", "type": "User"}}, {"content": "heart", "user": {"id": 4, "login": "octocat", "type": "User"}}, {"content": "-1", "user": {"id": 4, "login": "octocat", "type": "User"}}]'
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/poll close", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/poll" for "pull_request_review_comment" event with arguments ["close"] by sending these following requests
+      | API request method | API request URL                                                                                     | API request payload                                                                                                                                                                                                                                                                                                                                                                                                |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100            |                                                                                                                                                                                                                                                                                                                                                                                                                    |
+      | GET                | https://api.github.com/app                                                                          |                                                                                                                                                                                                                                                                                                                                                                                                                    |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/41/reactions?per_page=100 |                                                                                                                                                                                                                                                                                                                                                                                                                    |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/41                        | {"body":"<!-- quick-actions:poll -->\\n#### :bar_chart: Which release name? (closed)\\n\\n\| Vote \| Option \| Votes \|\\n\| :--: \| :----- \| ----: \|\\n\| :+1: \| Argon \| 1 (33%) \|\\n\| :-1: \| Boron \| 2 (66%) \|\\n\\n**Total:** 3 vote(s)\\n\\n<!-- quick-actions:poll:data {\\"question\\":\\"Which release name?\\",\\"options\\":[\\"Argon\\",\\"Boron\\"],\\"closed\\":true,\\"votes\\":[1,2]} -->"} |
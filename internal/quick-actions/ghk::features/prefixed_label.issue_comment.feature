@@ -0,0 +1,98 @@
+@issue_comment
+Feature: manage label families with /<family> label [label...] on issue comment
+
+  Background:
+    Given quick action "/priority" is registered for "issue_comment" events
+    And quick action "/area" is registered for "issue_comment" events
+
+  @prefixed_label
+  Scenario: /priority high replaces the current priority
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/priority high", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "labels": [{ "name": "kind/bug" }, { "name": "priority/low" }] },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/priority" for "issue_comment" event with arguments ["high"] by sending these following requests
+      | API request method | API request URL                                                                        | API request payload |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels              | ["priority/high"]   |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/priority/low |                     |
+
+  @prefixed_label
+  Scenario: /priority ~priority/high without current priority
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/priority ~priority/high", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "labels": [{ "name": "kind/bug" }] },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/priority" for "issue_comment" event with arguments ["~priority/high"] by sending these following requests
+      | API request method | API request URL                                                           | API request payload |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["priority/high"]   |
+
+  @prefixed_label
+  Scenario: /area api ui keeps other areas on non-exclusive family
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/area api ui", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "labels": [{ "name": "area/docs" }] },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/area" for "issue_comment" event with arguments ["api","ui"] by sending these following requests
+      | API request method | API request URL                                                           | API request payload    |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["area/api","area/ui"] |
+
+  @prefixed_label @error
+  Scenario: /priority high low on exclusive family
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/priority high low", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "labels": [] },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/priority" for "issue_comment" event with arguments ["high","low"] but returns this error: 'only one `priority` label can be set at a time (got [priority/high priority/low])'
+
+  @prefixed_label @error
+  Scenario: /priority without argument
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/priority", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "labels": [] },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/priority" for "issue_comment" event without argument but returns this error: 'missing argument `~label`; usage: `/priority ~label [~label...]`'
@@ -0,0 +1,27 @@
+@issues
+Feature: manage label families with /<family> label [label...] on issue description
+
+  Background:
+    Given quick action "/priority" is registered for "issues" events
+
+  @prefixed_label
+  Scenario: /priority high
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "created",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {
+          "body": "/priority high",
+          "number": 1,
+          "user": { "login":"xunleii" }
+         },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/priority" for "issues" event with arguments ["high"] by sending these following requests
+      | API request method | API request URL                                                           | API request payload |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["priority/high"]   |
@@ -0,0 +1,165 @@
+@issue_comment
+Feature: add issue to a project with /project <title> [field=value...] on issue comment
+
+  Background:
+    Given quick action "/project" is registered for "issue_comment" events
+
+  @project
+  Scenario: /project "Roadmap" status="In progress" iteration=@current notes="Needs design review"
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repositoryOwner": {"projectsV2": {"nodes": [{"id": "PVT_1", "title": "Roadmap", "fields": {"nodes": [{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"}, {"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT", "options": [{"id": "f75ad846", "name": "Todo"}, {"id": "47fc9ee4", "name": "In progress"}, {"id": "98236657", "name": "Done"}]}, {"id": "PVTIF_iteration", "name": "Iteration", "dataType": "ITERATION", "configuration": {"iterations": [{"id": "c7a1", "title": "Iteration 1", "startDate": "2026-10-12", "duration": 14}, {"id": "c7a2", "title": "Iteration 2", "startDate": "2026-10-26", "duration": 14}]}}, {"id": "PVTF_notes", "name": "Notes", "dataType": "TEXT"}]}}]}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"addProjectV2ItemById": {"item": {"id": "PVTI_1"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "PVTI_1"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/project \"Roadmap\" status=\"In progress\" iteration=@current notes=\"Needs design review\"", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_kwDOA" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/project" for "issue_comment" event with arguments ["Roadmap","status=In progress","iteration=@current","notes=Needs design review"] by sending these following requests
+      | API request method | API request URL                | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                |
+      | POST               | https://api.github.com/graphql | {"query":"query($owner:String!$title:String!){repositoryOwner(login: $owner){... on ProjectV2Owner{projectsV2(first: 20, query: $title){nodes{id,title,fields(first: 50){nodes{... on ProjectV2FieldCommon{id,name,dataType},... on ProjectV2SingleSelectField{options{id,name}},... on ProjectV2IterationField{configuration{iterations{id,title,startDate,duration}}}}}}}}}}","variables":{"owner":"xunleii","title":"Roadmap"}} |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:AddProjectV2ItemByIdInput!){addProjectV2ItemById(input: $input){item{id}}}","variables":{"input":{"projectId":"PVT_1","contentId":"I_kwDOA"}}}                                                                                                                                                                                                                                                           |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:UpdateProjectV2ItemFieldValueInput!){updateProjectV2ItemFieldValue(input: $input){projectV2Item{id}}}","variables":{"input":{"projectId":"PVT_1","itemId":"PVTI_1","fieldId":"PVTSSF_status","value":{"singleSelectOptionId":"47fc9ee4"}}}}                                                                                                                                                              |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:UpdateProjectV2ItemFieldValueInput!){updateProjectV2ItemFieldValue(input: $input){projectV2Item{id}}}","variables":{"input":{"projectId":"PVT_1","itemId":"PVTI_1","fieldId":"PVTIF_iteration","value":{"iterationId":"c7a1"}}}}                                                                                                                                                                         |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:UpdateProjectV2ItemFieldValueInput!){updateProjectV2ItemFieldValue(input: $input){projectV2Item{id}}}","variables":{"input":{"projectId":"PVT_1","itemId":"PVTI_1","fieldId":"PVTF_notes","value":{"text":"Needs design review"}}}}                                                                                                                                                                      |
+
+  @project
+  Scenario: /project Roadmap iteration="Iteration 2"
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repositoryOwner": {"projectsV2": {"nodes": [{"id": "PVT_1", "title": "Roadmap", "fields": {"nodes": [{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"}, {"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT", "options": [{"id": "f75ad846", "name": "Todo"}, {"id": "47fc9ee4", "name": "In progress"}, {"id": "98236657", "name": "Done"}]}, {"id": "PVTIF_iteration", "name": "Iteration", "dataType": "ITERATION", "configuration": {"iterations": [{"id": "c7a1", "title": "Iteration 1", "startDate": "2026-10-12", "duration": 14}, {"id": "c7a2", "title": "Iteration 2", "startDate": "2026-10-26", "duration": 14}]}}, {"id": "PVTF_notes", "name": "Notes", "dataType": "TEXT"}]}}]}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"addProjectV2ItemById": {"item": {"id": "PVTI_1"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "PVTI_1"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/project Roadmap iteration=\"Iteration 2\"", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_kwDOA" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/project" for "issue_comment" event with arguments ["Roadmap","iteration=Iteration 2"] by sending these following requests
+      | API request method | API request URL                | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                |
+      | POST               | https://api.github.com/graphql | {"query":"query($owner:String!$title:String!){repositoryOwner(login: $owner){... on ProjectV2Owner{projectsV2(first: 20, query: $title){nodes{id,title,fields(first: 50){nodes{... on ProjectV2FieldCommon{id,name,dataType},... on ProjectV2SingleSelectField{options{id,name}},... on ProjectV2IterationField{configuration{iterations{id,title,startDate,duration}}}}}}}}}}","variables":{"owner":"xunleii","title":"Roadmap"}} |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:AddProjectV2ItemByIdInput!){addProjectV2ItemById(input: $input){item{id}}}","variables":{"input":{"projectId":"PVT_1","contentId":"I_kwDOA"}}}                                                                                                                                                                                                                                                           |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:UpdateProjectV2ItemFieldValueInput!){updateProjectV2ItemFieldValue(input: $input){projectV2Item{id}}}","variables":{"input":{"projectId":"PVT_1","itemId":"PVTI_1","fieldId":"PVTIF_iteration","value":{"iterationId":"c7a2"}}}}                                                                                                                                                                         |
+
+  @project
+  Scenario: /project Roadmap
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repositoryOwner": {"projectsV2": {"nodes": [{"id": "PVT_1", "title": "Roadmap", "fields": {"nodes": [{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"}, {"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT", "options": [{"id": "f75ad846", "name": "Todo"}, {"id": "47fc9ee4", "name": "In progress"}, {"id": "98236657", "name": "Done"}]}, {"id": "PVTIF_iteration", "name": "Iteration", "dataType": "ITERATION", "configuration": {"iterations": [{"id": "c7a1", "title": "Iteration 1", "startDate": "2026-10-12", "duration": 14}, {"id": "c7a2", "title": "Iteration 2", "startDate": "2026-10-26", "duration": 14}]}}, {"id": "PVTF_notes", "name": "Notes", "dataType": "TEXT"}]}}]}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"addProjectV2ItemById": {"item": {"id": "PVTI_1"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/project Roadmap", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_kwDOA" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/project" for "issue_comment" event with arguments ["Roadmap"] by sending these following requests
+      | API request method | API request URL                | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                |
+      | POST               | https://api.github.com/graphql | {"query":"query($owner:String!$title:String!){repositoryOwner(login: $owner){... on ProjectV2Owner{projectsV2(first: 20, query: $title){nodes{id,title,fields(first: 50){nodes{... on ProjectV2FieldCommon{id,name,dataType},... on ProjectV2SingleSelectField{options{id,name}},... on ProjectV2IterationField{configuration{iterations{id,title,startDate,duration}}}}}}}}}}","variables":{"owner":"xunleii","title":"Roadmap"}} |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:AddProjectV2ItemByIdInput!){addProjectV2ItemById(input: $input){item{id}}}","variables":{"input":{"projectId":"PVT_1","contentId":"I_kwDOA"}}}                                                                                                                                                                                                                                                           |
+
+  @project @error
+  Scenario: /project without title
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/project", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_kwDOA" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/project" for "issue_comment" event without argument but returns this error: '/project requires a project title (like `/project "Roadmap" status="In progress"`)'
+
+  @project @error
+  Scenario: /project Roadmap with an invalid assignment
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/project Roadmap status", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_kwDOA" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/project" for "issue_comment" event with arguments ["Roadmap","status"] but returns this error: 'invalid field assignment 'status' for /project (expected `field=value`)'
+
+  @project @error
+  Scenario: /project on an unknown project
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repositoryOwner": {"projectsV2": {"nodes": [{"id": "PVT_1", "title": "Roadmap", "fields": {"nodes": [{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"}, {"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT", "options": [{"id": "f75ad846", "name": "Todo"}, {"id": "47fc9ee4", "name": "In progress"}, {"id": "98236657", "name": "Done"}]}, {"id": "PVTIF_iteration", "name": "Iteration", "dataType": "ITERATION", "configuration": {"iterations": [{"id": "c7a1", "title": "Iteration 1", "startDate": "2026-10-12", "duration": 14}, {"id": "c7a2", "title": "Iteration 2", "startDate": "2026-10-26", "duration": 14}]}}, {"id": "PVTF_notes", "name": "Notes", "dataType": "TEXT"}]}}]}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/project Backlog", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_kwDOA" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/project" for "issue_comment" event with arguments ["Backlog"] but returns this error: 'project 'Backlog' not found for 'xunleii''
+
+  @project @error
+  Scenario: /project Roadmap with an unknown field
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repositoryOwner": {"projectsV2": {"nodes": [{"id": "PVT_1", "title": "Roadmap", "fields": {"nodes": [{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"}, {"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT", "options": [{"id": "f75ad846", "name": "Todo"}, {"id": "47fc9ee4", "name": "In progress"}, {"id": "98236657", "name": "Done"}]}, {"id": "PVTIF_iteration", "name": "Iteration", "dataType": "ITERATION", "configuration": {"iterations": [{"id": "c7a1", "title": "Iteration 1", "startDate": "2026-10-12", "duration": 14}, {"id": "c7a2", "title": "Iteration 2", "startDate": "2026-10-26", "duration": 14}]}}, {"id": "PVTF_notes", "name": "Notes", "dataType": "TEXT"}]}}]}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/project Roadmap estimate=3", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_kwDOA" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/project" for "issue_comment" event with arguments ["Roadmap","estimate=3"] but returns this error: 'unknown field 'estimate' in project 'Roadmap' (available fields: Title, Status, Iteration, Notes)'
+
+  @project @error
+  Scenario: /project Roadmap with an unknown option
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repositoryOwner": {"projectsV2": {"nodes": [{"id": "PVT_1", "title": "Roadmap", "fields": {"nodes": [{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"}, {"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT", "options": [{"id": "f75ad846", "name": "Todo"}, {"id": "47fc9ee4", "name": "In progress"}, {"id": "98236657", "name": "Done"}]}, {"id": "PVTIF_iteration", "name": "Iteration", "dataType": "ITERATION", "configuration": {"iterations": [{"id": "c7a1", "title": "Iteration 1", "startDate": "2026-10-12", "duration": 14}, {"id": "c7a2", "title": "Iteration 2", "startDate": "2026-10-26", "duration": 14}]}}, {"id": "PVTF_notes", "name": "Notes", "dataType": "TEXT"}]}}]}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/project Roadmap status=Blocked", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_kwDOA" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/project" for "issue_comment" event with arguments ["Roadmap","status=Blocked"] but returns this error: 'unknown option 'Blocked' for field 'Status' (available options: Todo, In progress, Done)'
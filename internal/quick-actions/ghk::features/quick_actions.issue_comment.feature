@@ -0,0 +1,41 @@
+@issue_comment
+Feature: show the quick actions configuration with /quick_actions config on issue comment
+
+  Background:
+    Given quick action "/quick_actions" is registered for "issue_comment" events
+
+  @quick_actions
+  Scenario: /quick_actions config
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/quick_actions config", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/quick_actions" for "issue_comment" event with arguments ["config"] by sending these following requests
+      | API request method | API request URL                                                             | API request payload                                                                                                |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments | {"body":"#### Quick actions configuration\\n\\n_No configuration file found, the default configuration is used._"} |
+
+  @quick_actions @error
+  Scenario: /quick_actions unknown
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/quick_actions unknown", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/quick_actions" for "issue_comment" event with arguments ["unknown"] but returns this error: 'unknown subcommand 'unknown' (expected `/quick_actions config`)'
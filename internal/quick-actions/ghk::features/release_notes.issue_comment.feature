@@ -0,0 +1,53 @@
+@issue_comment
+Feature: render the release notes between two tags with /release_notes <from> <to> on issue comment
+
+  Background:
+    Given quick action "/release_notes" is registered for "issue_comment" events
+
+  @release_notes
+  Scenario: /release_notes v1.0.0 v1.1.0
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/compare/v1.0.0...v1.1.0' with '200 {"commits": [{"sha": "aaa"}, {"sha": "bbb"}, {"sha": "ccc"}, {"sha": "ddd"}]}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/commits/aaa/pulls' with '200 [{"number": 12, "body": "Add poll\n\n<!-- quick-actions:changelog:data {\"kind\":\"added\",\"text\":\"Support for /poll\"} -->", "merged_at": "2026-10-01T10:00:00Z"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/commits/bbb/pulls' with '200 [{"number": 12, "body": "Add poll\n\n<!-- quick-actions:changelog:data {\"kind\":\"added\",\"text\":\"Support for /poll\"} -->", "merged_at": "2026-10-01T10:00:00Z"}, {"number": 15, "body": "Not merged"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/commits/ccc/pulls' with '200 [{"number": 13, "body": "Fix crash\n\n<!-- quick-actions:changelog:data {\"kind\":\"fixed\",\"text\":\"Crash on empty comments\"} -->", "merged_at": "2026-10-02T10:00:00Z"}, {"number": 14, "body": "Bump deps\n\n<!-- quick-actions:changelog:data {\"none\":true} -->", "merged_at": "2026-10-03T10:00:00Z"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/commits/ddd/pulls' with '200 [{"number": 16, "body": "No entry", "merged_at": "2026-10-04T10:00:00Z"}]'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/release_notes v1.0.0 v1.1.0", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/release_notes" for "issue_comment" event with arguments ["v1.0.0","v1.1.0"] by sending these following requests
+      | API request method | API request URL                                                                                | API request payload                                                                                                                                                                               |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/compare/v1.0.0...v1.1.0?per_page=100 |                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/commits/aaa/pulls                    |                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/commits/bbb/pulls                    |                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/commits/ccc/pulls                    |                                                                                                                                                                                                   |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/commits/ddd/pulls                    |                                                                                                                                                                                                   |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments                    | {"body":"## Changelog (v1.0.0...v1.1.0)\\n\\n### Added\\n\\n- Support for /poll (#12)\\n\\n### Fixed\\n\\n- Crash on empty comments (#13)\\n\\n_Pull requests without changelog entry: #16._\\n"} |
+
+  @release_notes @error
+  Scenario: /release_notes with a single tag
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/release_notes v1.1.0", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/release_notes" for "issue_comment" event with arguments ["v1.1.0"] but returns this error: '/release_notes requires two tags (like `/release_notes v1.0.0 v1.1.0`)'
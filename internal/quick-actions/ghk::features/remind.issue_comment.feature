@@ -0,0 +1,131 @@
+@issue_comment
+Feature: schedule a reminder with /remind me|@user <message> in <n> <unit>|on <date> on issue comment
+
+  Background:
+    Given quick action "/remind" is registered for "issue_comment" events
+
+  @remind
+  Scenario: /remind me "check the release" in 3 days
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remind me \"check the release\" in 3 days", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login":"xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remind" for "issue_comment" event with arguments ["me","check the release","in","3","days"] without sending anything
+
+  @remind
+  Scenario: /remind @octocat to review on 2026-11-01
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remind @octocat to review on 2026-11-01", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login":"xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remind" for "issue_comment" event with arguments ["@octocat","to","review","on","2026-11-01"] without sending anything
+
+  @remind
+  Scenario: /remind on a pull request
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remind me \"merge it\" in 2 hours", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login":"xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remind" for "issue_comment" event with arguments ["me","merge it","in","2","hours"] without sending anything
+
+  @remind @error
+  Scenario: /remind without date
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remind me", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login":"xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remind" for "issue_comment" event with arguments ["me"] but returns this error: 'missing argument `<message>`; usage: `/remind @user <message> <date>`'
+
+  @remind @error
+  Scenario: /remind with an invalid user
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remind octocat \"review\" in 3 days", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login":"xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remind" for "issue_comment" event with arguments ["octocat","review","in","3","days"] but returns this error: 'invalid user 'octocat'; usage: `/remind @user <message> <date>`'
+
+  @remind @error
+  Scenario: /remind with an invalid date
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remind me \"review\" next week", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login":"xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remind" for "issue_comment" event with arguments ["me","review","next","week"] but returns this error: 'missing argument `<date>`; usage: `/remind @user <message> <date>`'
+
+  @remind @error
+  Scenario: /remind with a past date
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remind me \"review\" on 2026-01-01", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login":"xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remind" for "issue_comment" event with arguments ["me","review","on","2026-01-01"] but returns this error: 'reminder date 2026-01-01 is in the past'
@@ -0,0 +1,27 @@
+@issues
+Feature: schedule a reminder with /remind me|@user <message> in <n> <unit>|on <date> on issue description
+
+  Background:
+    Given quick action "/remind" is registered for "issues" events
+
+  @remind
+  Scenario: /remind me "triage" in 1 week
+    When Github sends an event "issues" with
+      """
+      {
+        "action": "created",
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": {
+          "body": "Some description\n/remind me \"triage\" in 1 week",
+          "number": 1,
+          "user": { "login":"xunleii" },
+          "created_at": "2026-10-19T10:00:00Z"
+        },
+        "sender": { "login":"xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remind" for "issues" event with arguments ["me","triage","in","1","week"] without sending anything
@@ -0,0 +1,53 @@
+@issue_comment
+Feature: remove due date with /remove_due_date on issue comment
+
+  Background:
+    Given quick action "/remove_due_date" is registered for "issue_comment" events
+
+  @remove_due_date
+  Scenario: /remove_due_date
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:due:data {\"date\":\"2026-10-01\"} -->"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issueOrPullRequest": {"projectItems": {"nodes": []}}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remove_due_date", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:due:data {\"date\":\"2026-10-01\"} -->" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remove_due_date" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description"}                                                                                                                                                                                                                                                                                                                                                                                                                                                      |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
+
+  @remove_due_date
+  Scenario: /remove_due_date on an issue in a project
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:due:data {\"date\":\"2026-10-01\"} -->"}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"repository": {"issueOrPullRequest": {"projectItems": {"nodes": [{"id": "PVTI_1", "project": {"id": "PVT_1", "field": {"id": "PVTF_1"}}}]}}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"clearProjectV2ItemFieldValue": {"projectV2Item": {"id": "PVTI_1"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remove_due_date", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:due:data {\"date\":\"2026-10-01\"} -->" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remove_due_date" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                    | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                                  |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1 | {"body":"Some description"}                                                                                                                                                                                                                                                                                                                                                                                                                                                      |
+      | POST               | https://api.github.com/graphql                                     | {"query":"query($field:String!$name:String!$number:Int!$owner:String!){repository(owner: $owner, name: $name){issueOrPullRequest(number: $number){... on Issue{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}},... on PullRequest{projectItems(first: 20){nodes{id,project{id,field(name: $field){... on ProjectV2Field{id}}}}}}}}}","variables":{"field":"Due date","name":"github-quick-actions","number":1,"owner":"xunleii"}} |
+      | POST               | https://api.github.com/graphql                                     | {"query":"mutation($input:ClearProjectV2ItemFieldValueInput!){clearProjectV2ItemFieldValue(input: $input){projectV2Item{id}}}","variables":{"input":{"projectId":"PVT_1","itemId":"PVTI_1","fieldId":"PVTF_1"}}}                                                                                                                                                                                                                                                                 |
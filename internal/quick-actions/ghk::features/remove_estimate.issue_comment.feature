@@ -0,0 +1,31 @@
+@issue_comment
+Feature: remove time estimate with /remove_estimate on issue comment
+
+  Background:
+    Given quick action "/remove_estimate" is registered for "issue_comment" events
+
+  @remove_estimate
+  Scenario: /remove_estimate
+    Given Github replies to 'GET https://api.github.com/app' with '200 {"slug": "github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100' with '200 [{"id": 42, "user": {"login": "mojombo", "type": "User"}, "body": "<!-- quick-actions:time_tracking --> forged"}, {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43' with '200 {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remove_estimate", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remove_estimate" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                          | API request payload                                                                                                                                                                                                                                                                                                                                    |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100 |                                                                                                                                                                                                                                                                                                                                                        |
+      | GET                | https://api.github.com/app                                                               |                                                                                                                                                                                                                                                                                                                                                        |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43             |                                                                                                                                                                                                                                                                                                                                                        |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43             | {"body":"<!-- quick-actions:time_tracking -->\\n#### Time tracking\\n\\n**Estimate:** _none_<br>\\n**Time spent:** 1h 30m\\n\\n\| User \| Time spent \|\\n\| :--- \| ---------: \|\\n\| @mojombo \| 1h 30m \|\\n\\n<!-- quick-actions:time_tracking:data {\\"spent\\":[{\\"user\\":\\"mojombo\\",\\"minutes\\":90,\\"date\\":\\"2026-10-18\\"}]} -->"} |
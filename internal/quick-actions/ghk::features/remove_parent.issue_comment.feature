@@ -0,0 +1,47 @@
+@issue_comment
+Feature: remove parent issue with /remove_parent on issue comment
+
+  Background:
+    Given quick action "/remove_parent" is registered for "issue_comment" events
+
+  @remove_parent
+  Scenario: /remove_parent
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": {"id": "I_12"}}}}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"removeSubIssue": {"issue": {"id": "I_12"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remove_parent", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remove_parent" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                | API request payload                                                                                                                                     |
+      | POST               | https://api.github.com/graphql | {"query":"query($id:ID!){node(id: $id){... on Issue{parent{id}}}}","variables":{"id":"I_1"}}                                                            |
+      | POST               | https://api.github.com/graphql | {"query":"mutation($input:RemoveSubIssueInput!){removeSubIssue(input: $input){issue{id}}}","variables":{"input":{"issueId":"I_12","subIssueId":"I_1"}}} |
+
+  @remove_parent
+  Scenario: /remove_parent without parent
+    Given Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"node": {"parent": null}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remove_parent", "user": { "login":"xunleii" } },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "node_id": "I_1" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remove_parent" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                | API request payload                                                                          |
+      | POST               | https://api.github.com/graphql | {"query":"query($id:ID!){node(id: $id){... on Issue{parent{id}}}}","variables":{"id":"I_1"}} |
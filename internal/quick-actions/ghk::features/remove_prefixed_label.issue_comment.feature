@@ -0,0 +1,60 @@
+@issue_comment
+Feature: remove label families with /remove-<family> [label...] on issue comment
+
+  Background:
+    Given quick action "/remove-priority" is registered for "issue_comment" events
+
+  @remove_prefixed_label
+  Scenario: /remove-priority
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remove-priority", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "labels": [{ "name": "kind/bug" }, { "name": "priority/high" }] },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remove-priority" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                         | API request payload |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/priority/high |                     |
+
+  @remove_prefixed_label
+  Scenario: /remove-priority low
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remove-priority low", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "labels": [{ "name": "priority/low" }, { "name": "priority/high" }] },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remove-priority" for "issue_comment" event with arguments ["low"] by sending these following requests
+      | API request method | API request URL                                                                        | API request payload |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/priority/low |                     |
+
+  @remove_prefixed_label
+  Scenario: /remove-priority without priority label
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/remove-priority", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "labels": [{ "name": "kind/bug" }] },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/remove-priority" for "issue_comment" event without argument without sending anything
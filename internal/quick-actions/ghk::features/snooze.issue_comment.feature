@@ -0,0 +1,148 @@
+@issue_comment
+Feature: hide an issue from triage with /snooze <date> [reason] on issue comment
+
+  Background:
+    Given quick action "/snooze" is registered for "issue_comment" events
+
+  @snooze
+  Scenario: /snooze 2w waiting for upstream
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/snooze 2w waiting for upstream", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description", "labels": [{ "name": "needs-triage" }, { "name": "kind/bug" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/snooze" for "issue_comment" event with arguments ["2w","waiting","for","upstream"] by sending these following requests
+      | API request method | API request URL                                                                        | API request payload                                                                                                                                                                    |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                     |                                                                                                                                                                                        |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                     | {"body":"Some description\\n\\n<!-- quick-actions:snooze:data {\\"until\\":\\"2026-11-02T10:00:00Z\\",\\"labels\\":[\\"needs-triage\\"],\\"reason\\":\\"waiting for upstream\\"} -->"} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels              | ["snoozed"]                                                                                                                                                                            |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/needs-triage |                                                                                                                                                                                        |
+
+  @snooze
+  Scenario: /snooze 2026-11-01
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/snooze 2026-11-01", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description", "labels": [{ "name": "kind/bug" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/snooze" for "issue_comment" event with arguments ["2026-11-01"] by sending these following requests
+      | API request method | API request URL                                                           | API request payload                                                                                          |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1        |                                                                                                              |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1        | {"body":"Some description\\n\\n<!-- quick-actions:snooze:data {\\"until\\":\\"2026-11-01T00:00:00Z\\"} -->"} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels | ["snoozed"]                                                                                                  |
+
+  @snooze
+  Scenario: /snooze 1mo on an already snoozed issue
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/snooze 1mo", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->", "labels": [{ "name": "snoozed" }, { "name": "needs-information" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/snooze" for "issue_comment" event with arguments ["1mo"] by sending these following requests
+      | API request method | API request URL                                                                             | API request payload                                                                                                                                                    |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                          |                                                                                                                                                                        |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                          | {"body":"Some description\\n\\n<!-- quick-actions:snooze:data {\\"until\\":\\"2026-11-19T10:00:00Z\\",\\"labels\\":[\\"needs-triage\\",\\"needs-information\\"]} -->"} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels                   | ["snoozed"]                                                                                                                                                            |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/needs-information |                                                                                                                                                                        |
+
+  @snooze
+  Scenario: /snooze on a pull request
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/snooze 2w", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description", "labels": [{ "name": "needs-triage" }], "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/snooze" for "issue_comment" event with arguments ["2w"] without sending anything
+
+  @snooze @error
+  Scenario: /snooze without duration
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/snooze", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description", "labels": [{ "name": "needs-triage" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/snooze" for "issue_comment" event without argument but returns this error: 'missing argument `<date>`; usage: `/snooze <date> [<reason>]`'
+
+  @snooze @error
+  Scenario: /snooze with an invalid duration
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/snooze soon", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description", "labels": [{ "name": "needs-triage" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/snooze" for "issue_comment" event with arguments ["soon"] but returns this error: 'invalid date 'soon'; usage: `/snooze <date> [<reason>]`'
+
+  @snooze @error
+  Scenario: /snooze with a past date
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/snooze 2026-01-01", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description", "labels": [{ "name": "needs-triage" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/snooze" for "issue_comment" event with arguments ["2026-01-01"] but returns this error: 'snooze date 2026-01-01 is in the past'
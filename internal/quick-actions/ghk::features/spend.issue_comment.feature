@@ -0,0 +1,98 @@
+@issue_comment
+Feature: add spent time with /spend <duration> [date] on issue comment
+
+  Background:
+    Given quick action "/spend" is registered for "issue_comment" events
+
+  @spend
+  Scenario: /spend 30m
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/spend 30m", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/spend" for "issue_comment" event with arguments ["30m"] by sending these following requests
+      | API request method | API request URL                                                                          | API request payload                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100 |                                                                                                                                                                                                                                                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments              | {"body":"<!-- quick-actions:time_tracking -->\\n#### Time tracking\\n\\n**Estimate:** _none_<br>\\n**Time spent:** 30m\\n\\n\| User \| Time spent \|\\n\| :--- \| ---------: \|\\n\| @xunleii \| 30m \|\\n\\n<!-- quick-actions:time_tracking:data {\\"spent\\":[{\\"user\\":\\"xunleii\\",\\"minutes\\":30,\\"date\\":\\"2026-10-19\\"}]} -->"} |
+
+  @spend
+  Scenario: /spend 1h 2026-10-01 with an existing summary
+    Given Github replies to 'GET https://api.github.com/app' with '200 {"slug": "github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100' with '200 [{"id": 42, "user": {"login": "mojombo", "type": "User"}, "body": "<!-- quick-actions:time_tracking --> forged"}, {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43' with '200 {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/spend 1h 2026-10-01", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/spend" for "issue_comment" event with arguments ["1h","2026-10-01"] by sending these following requests
+      | API request method | API request URL                                                                          | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                     |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                         |
+      | GET                | https://api.github.com/app                                                               |                                                                                                                                                                                                                                                                                                                                                                                                                                                                         |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43             |                                                                                                                                                                                                                                                                                                                                                                                                                                                                         |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43             | {"body":"<!-- quick-actions:time_tracking -->\\n#### Time tracking\\n\\n**Estimate:** 1d 2h<br>\\n**Time spent:** 2h 30m\\n\\n\| User \| Time spent \|\\n\| :--- \| ---------: \|\\n\| @mojombo \| 1h 30m \|\\n\| @xunleii \| 1h \|\\n\\n<!-- quick-actions:time_tracking:data {\\"estimate\\":600,\\"spent\\":[{\\"user\\":\\"mojombo\\",\\"minutes\\":90,\\"date\\":\\"2026-10-18\\"},{\\"user\\":\\"xunleii\\",\\"minutes\\":60,\\"date\\":\\"2026-10-01\\"}]} -->"} |
+
+  @spend
+  Scenario: /spend -30m with an existing summary
+    Given Github replies to 'GET https://api.github.com/app' with '200 {"slug": "github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100' with '200 [{"id": 42, "user": {"login": "mojombo", "type": "User"}, "body": "<!-- quick-actions:time_tracking --> forged"}, {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}]'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43' with '200 {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/spend -30m", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/spend" for "issue_comment" event with arguments ["-30m"] by sending these following requests
+      | API request method | API request URL                                                                          | API request payload                                                                                                                                                                                                                                                                                                                                                                                                                                                    |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100 |                                                                                                                                                                                                                                                                                                                                                                                                                                                                        |
+      | GET                | https://api.github.com/app                                                               |                                                                                                                                                                                                                                                                                                                                                                                                                                                                        |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43             |                                                                                                                                                                                                                                                                                                                                                                                                                                                                        |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/comments/43             | {"body":"<!-- quick-actions:time_tracking -->\\n#### Time tracking\\n\\n**Estimate:** 1d 2h<br>\\n**Time spent:** 1h\\n\\n\| User \| Time spent \|\\n\| :--- \| ---------: \|\\n\| @mojombo \| 1h 30m \|\\n\| @xunleii \| -30m \|\\n\\n<!-- quick-actions:time_tracking:data {\\"estimate\\":600,\\"spent\\":[{\\"user\\":\\"mojombo\\",\\"minutes\\":90,\\"date\\":\\"2026-10-18\\"},{\\"user\\":\\"xunleii\\",\\"minutes\\":-30,\\"date\\":\\"2026-10-19\\"}]} -->"} |
+
+  @spend @error
+  Scenario: /spend -2h with less time spent
+    Given Github replies to 'GET https://api.github.com/app' with '200 {"slug": "github-quick-actions"}'
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100' with '200 [{"id": 42, "user": {"login": "mojombo", "type": "User"}, "body": "<!-- quick-actions:time_tracking --> forged"}, {"id": 43, "user": {"login": "github-quick-actions[bot]", "type": "Bot"}, "body": "<!-- quick-actions:time_tracking -->\n#### Time tracking\n\n<!-- quick-actions:time_tracking:data {\"estimate\":600,\"spent\":[{\"user\":\"mojombo\",\"minutes\":90,\"date\":\"2026-10-18\"}]} -->"}]'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/spend -2h", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/spend" for "issue_comment" event with arguments ["-2h"] but returns this error: 'total time spent cannot be negative'
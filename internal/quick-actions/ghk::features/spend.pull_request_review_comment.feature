@@ -0,0 +1,26 @@
+@pull_request_review_comment
+Feature: add spent time with /spend <duration> [date] on pull request review comment
+
+  Background:
+    Given quick action "/spend" is registered for "pull_request_review_comment" events
+
+  @spend
+  Scenario: /spend 30m
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/spend 30m", "user": { "login":"xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/spend" for "pull_request_review_comment" event with arguments ["30m"] by sending these following requests
+      | API request method | API request URL                                                                          | API request payload                                                                                                                                                                                                                                                                                                                              |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments?per_page=100 |                                                                                                                                                                                                                                                                                                                                                  |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments              | {"body":"<!-- quick-actions:time_tracking -->\\n#### Time tracking\\n\\n**Estimate:** _none_<br>\\n**Time spent:** 30m\\n\\n\| User \| Time spent \|\\n\| :--- \| ---------: \|\\n\| @xunleii \| 30m \|\\n\\n<!-- quick-actions:time_tracking:data {\\"spent\\":[{\\"user\\":\\"xunleii\\",\\"minutes\\":30,\\"date\\":\\"2026-10-19\\"}]} -->"} |
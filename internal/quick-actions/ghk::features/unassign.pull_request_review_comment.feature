@@ -136,7 +136,7 @@ Feature: unassign someone with /unassign @user [@user...] on pull request review
         "installation": { "id": 123456789 }
       }
       """
-    Then Github Quick Actions should handle command "/unassign" for "pull_request_review_comment" event with arguments ["mojombo"] without sending anything
+    Then Github Quick Actions should handle command "/unassign" for "pull_request_review_comment" event with arguments ["mojombo"] but returns this error: 'invalid user 'mojombo'; usage: `/unassign [@user [@user...]]`'
 
   @unassign @error
   Scenario: error handling on /unassign
@@ -0,0 +1,49 @@
+@pull_request_review_comment
+Feature: release a held pull request with /unhold on pull request review comment
+
+  Background:
+    Given quick action "/unhold" is registered for "pull_request_review_comment" events
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}, "base": {"ref": "main"}}'
+
+  @unhold
+  Scenario: /unhold
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/unhold", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/unhold" for "pull_request_review_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                      |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                          |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/do-not-merge/hold                 |                                                                                          |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"success","description":"Pull request not held","context":"quick-actions/hold"} |
+
+  @unhold
+  Scenario: /unhold when the label is already removed
+    Given Github replies to 'DELETE https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/do-not-merge/hold' with '404 {"message": "Label does not exist", "documentation_url": ""}'
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/unhold", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/unhold" for "pull_request_review_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                                             | API request payload                                                                      |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                           |                                                                                          |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/do-not-merge/hold                 |                                                                                          |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e | {"state":"success","description":"Pull request not held","context":"quick-actions/hold"} |
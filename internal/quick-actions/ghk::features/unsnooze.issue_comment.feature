@@ -0,0 +1,128 @@
+@issue_comment
+Feature: wake a snoozed issue up when someone comments
+
+  Background:
+    Given event handler "unsnooze" is registered for "issue_comment" events
+    And quick action "/snooze" is registered for "issue_comment" events
+
+  @unsnooze
+  Scenario: comment on a snoozed issue
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "Any news?", "user": { "login": "octocat", "type": "User" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->", "labels": [{ "name": "snoozed" }, { "name": "kind/bug" }] },
+        "sender": { "login": "octocat", "type": "User" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "unsnooze" for "issue_comment" event by sending these following requests
+      | API request method | API request URL                                                                   | API request payload         |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/snoozed |                             |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels         | ["needs-triage"]            |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                |                             |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                |                             |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                | {"body":"Some description"} |
+
+  @unsnooze
+  Scenario: comment on an issue not snoozed
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "Any news?", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description", "labels": [{ "name": "needs-triage" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "unsnooze" for "issue_comment" event without sending anything
+
+  @unsnooze
+  Scenario: comment from a bot on a snoozed issue
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "Still snoozed", "user": { "login": "github-quick-actions[bot]", "type": "Bot" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->", "labels": [{ "name": "snoozed" }] },
+        "sender": { "login": "github-quick-actions[bot]", "type": "Bot" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "unsnooze" for "issue_comment" event without sending anything
+
+  @unsnooze
+  Scenario: snooze again a snoozed issue
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/snooze 1w", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->", "labels": [{ "name": "snoozed" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "unsnooze" for "issue_comment" event without sending anything
+
+  @unsnooze
+  Scenario: /snooze quoted in a code block on a snoozed issue
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/issues/1' with '200 {"number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->"}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "Try:\n```\n/snooze 1w\n```", "user": { "login": "octocat", "type": "User" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->", "labels": [{ "name": "snoozed" }, { "name": "kind/bug" }] },
+        "sender": { "login": "octocat", "type": "User" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should run event handler "unsnooze" for "issue_comment" event by sending these following requests
+      | API request method | API request URL                                                                   | API request payload         |
+      | DELETE             | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels/snoozed |                             |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/labels         | ["needs-triage"]            |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                |                             |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                |                             |
+      | PATCH              | https://api.github.com/repos/xunleii/github-quick-actions/issues/1                | {"body":"Some description"} |
+
+  @unsnooze
+  Scenario: edited comments are ignored
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "edited",
+        "comment": { "body": "Any news?", "user": { "login": "xunleii" }, "created_at": "2026-10-19T10:00:00Z" },
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-10-26T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->", "labels": [{ "name": "snoozed" }] },
+        "sender": { "login": "xunleii" },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions shouldn't do anything
@@ -0,0 +1,128 @@
+@issue_comment
+Feature: update pull request branch with /update_branch [--rebase] on issue comment
+
+  Background:
+    Given quick action "/update_branch" is registered for "issue_comment" events
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "node_id": "PR_kwDOA", "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}, "base": {"ref": "main"}}'
+
+  @update_branch
+  Scenario: /update_branch
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e' with '200 {"behind_by": 2}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/update_branch", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/update_branch" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                                                   | API request payload                                                                            |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                                 |                                                                                                |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e |                                                                                                |
+      | PUT                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/update-branch                                   | {"expected_head_sha":"6dcb09b5b57875f334f61aebed695e2e4193db5e"}                               |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments                                       | {"body":"Branch `feature` was 2 commit(s) behind `main`; update by merge has been requested."} |
+
+  @update_branch
+  Scenario: /update_branch --rebase
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e' with '200 {"behind_by": 1}'
+    And Github replies to 'POST https://api.github.com/graphql' with '200 {"data": {"updatePullRequestBranch": {"pullRequest": {"id": "PR_kwDOA"}}}}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/update_branch --rebase", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/update_branch" for "issue_comment" event with arguments ["--rebase"] by sending these following requests
+      | API request method | API request URL                                                                                                   | API request payload                                                                                                                                                                                                                                         |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                                 |                                                                                                                                                                                                                                                             |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e |                                                                                                                                                                                                                                                             |
+      | POST               | https://api.github.com/graphql                                                                                    | {"query":"mutation($input:UpdatePullRequestBranchInput!){updatePullRequestBranch(input: $input){pullRequest{id}}}","variables":{"input":{"pullRequestId":"PR_kwDOA","expectedHeadOid":"6dcb09b5b57875f334f61aebed695e2e4193db5e","updateMethod":"REBASE"}}} |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments                                       | {"body":"Branch `feature` was 1 commit(s) behind `main`; update by rebase has been requested."}                                                                                                                                                             |
+
+  @update_branch
+  Scenario: /update_branch when branch is already up to date
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e' with '200 {"behind_by": 0}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/update_branch", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/update_branch" for "issue_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                                                   | API request payload                                            |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                                 |                                                                |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e |                                                                |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments                                       | {"body":"Branch `feature` is already up to date with `main`."} |
+
+  @update_branch
+  Scenario: /update_branch on an issue
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/update_branch", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/update_branch" for "issue_comment" event without argument without sending anything
+
+  @update_branch @error
+  Scenario: /update_branch with invalid argument
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/update_branch --squash", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/update_branch" for "issue_comment" event with arguments ["--squash"] but returns this error: 'invalid argument '--squash' for /update_branch'
+
+  @update_branch @error
+  Scenario: error handling on /update_branch
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e' with '200 {"behind_by": 2}'
+    And Github replies to 'PUT https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/update-branch' with '422 {"message": "expected head sha didn't match current head ref.", "documentation_url": ""}'
+    When Github sends an event "issue_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/update_branch", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "issue": { "number": 1, "pull_request": { "url": "https://api.github.com/repos/xunleii/github-quick-actions/pulls/1" } },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/update_branch" for "issue_comment" event without argument but returns this error: 'PUT https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/update-branch: 422 expected head sha didn't match current head ref. []'
@@ -0,0 +1,29 @@
+@pull_request_review_comment
+Feature: update pull request branch with /update_branch [--rebase] on pull request review comment
+
+  Background:
+    Given quick action "/update_branch" is registered for "pull_request_review_comment" events
+    And Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/pulls/1' with '200 {"number": 1, "node_id": "PR_kwDOA", "head": {"ref": "feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}, "base": {"ref": "main"}}'
+
+  @update_branch
+  Scenario: /update_branch
+    Given Github replies to 'GET https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e' with '200 {"behind_by": 2}'
+    When Github sends an event "pull_request_review_comment" with
+      """
+      {
+        "action": "created",
+        "comment": { "body": "/update_branch", "user": { "login":"xunleii" }},
+        "repository": {
+          "owner": { "login": "xunleii" },
+          "name": "github-quick-actions"
+        },
+        "pull_request": { "number": 1 },
+        "installation": { "id": 123456789 }
+      }
+      """
+    Then Github Quick Actions should handle command "/update_branch" for "pull_request_review_comment" event without argument by sending these following requests
+      | API request method | API request URL                                                                                                   | API request payload                                                                            |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1                                                 |                                                                                                |
+      | GET                | https://api.github.com/repos/xunleii/github-quick-actions/compare/main...6dcb09b5b57875f334f61aebed695e2e4193db5e |                                                                                                |
+      | PUT                | https://api.github.com/repos/xunleii/github-quick-actions/pulls/1/update-branch                                   | {"expected_head_sha":"6dcb09b5b57875f334f61aebed695e2e4193db5e"}                               |
+      | POST               | https://api.github.com/repos/xunleii/github-quick-actions/issues/1/comments                                       | {"body":"Branch `feature` was 2 commit(s) behind `main`; update by merge has been requested."} |
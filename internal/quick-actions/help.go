@@ -0,0 +1,185 @@
+package quick_actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+type (
+	// HelpQuickAction implements QuickAction interface for /help command.
+	// This quick action replies with the list of quick actions available
+	// for the current event type, or with the details of one of them.
+	HelpQuickAction struct {
+		githubEventHelper
+
+		// QuickActions contains all registered quick actions.
+		QuickActions *GithubQuickActions
+	}
+)
+
+func (qa HelpQuickAction) TriggerOnEvents() []EventType {
+	return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
+}
+
+func (qa HelpQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "command", Kind: ArgumentKindText}}
+}
+
+func (qa HelpQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/help [<command>]"},
+		Description: "List the quick actions available here, or describe the given one.",
+		Examples:    []string{"/help", "/help label"},
+	}
+}
+
+func (qa HelpQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "help").
+		Logger()
+
+	logger.Info().Msgf("handle `/help` (args: %v)", command.Arguments)
+
+	prefix := ctx.Config.CommandPrefix()
+	eventType := command.Payload.Type()
+	var body string
+	if arg, ok := command.Values.Get("command"); ok {
+		name := strings.TrimPrefix(arg.Value, prefix)
+		if canonical, isAlias := ctx.Config.Alias(name); isAlias {
+			name = canonical
+		}
+
+		info, exists := qa.findCommand(ctx, eventType, name)
+		if !exists || !ctx.Config.IsEnabled(info.Command) {
+			return fmt.Errorf("quick action '%s%s' doesn't exist on %s events (see `%shelp`)", prefix, name, eventType, prefix)
+		}
+		body = quickActionHelp(prefix, ctx.Config.Describe(info))
+	} else {
+		// NOTE: commands disabled on the repository are not listed
+		var infos []QuickActionInfo
+		for _, info := range qa.QuickActions.QuickActions(eventType) {
+			if ctx.Config.IsEnabled(info.Command) {
+				infos = append(infos, ctx.Config.Describe(info))
+			}
+		}
+		var repositoryInfos []QuickActionInfo
+		for _, info := range ctx.Config.RepositoryCommands(eventType) {
+			if ctx.Config.IsEnabled(info.Command) {
+				repositoryInfos = append(repositoryInfos, ctx.Config.Describe(info))
+			}
+		}
+		body = quickActionsHelp(prefix, eventType, infos, repositoryInfos)
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+	return qa.createComment(ctx, client, command.Payload, body)
+}
+
+// findCommand returns the description of the given quick action, or of the
+// given macro or plugin of the repository.
+func (qa HelpQuickAction) findCommand(ctx *EventContext, eventType EventType, name string) (QuickActionInfo, bool) {
+	if info, exists := qa.QuickActions.QuickAction(eventType, name); exists {
+		return info, true
+	}
+	for _, info := range ctx.Config.RepositoryCommands(eventType) {
+		if info.Command == name {
+			return info, true
+		}
+	}
+	return QuickActionInfo{}, false
+}
+
+// quickActionsHelp generates the Markdown tables listing the given quick
+// actions and the given repository commands (macros and plugins).
+func quickActionsHelp(prefix string, eventType EventType, infos, repositoryInfos []QuickActionInfo) string {
+	var help strings.Builder
+
+	fmt.Fprintf(&help, "#### Quick actions available on `%s` events\n\n", eventType)
+	writeCommandsTable(&help, infos)
+	if len(repositoryInfos) > 0 {
+		help.WriteString("\n#### Repository commands\n\n")
+		writeCommandsTable(&help, repositoryInfos)
+	}
+	fmt.Fprintf(&help, "\n_Use `%shelp <command>` to get more details about a quick action._", prefix)
+	return help.String()
+}
+
+// writeCommandsTable writes a Markdown table listing the usages and the
+// descriptions of the given commands.
+func writeCommandsTable(help *strings.Builder, infos []QuickActionInfo) {
+	help.WriteString("| Command | Description |\n")
+	help.WriteString("| :------ | :---------- |\n")
+	for _, info := range infos {
+		fmt.Fprintf(help, "| %s | %s |\n", markdownCell(codeList(info.Usage, "<br>")), markdownCell(info.Description))
+	}
+}
+
+// quickActionHelp generates the Markdown description of the given quick
+// action.
+func quickActionHelp(prefix string, info QuickActionInfo) string {
+	var help strings.Builder
+
+	fmt.Fprintf(&help, "#### `%s%s`\n\n%s\n", prefix, info.Command, info.Description)
+	if info.Details != "" {
+		fmt.Fprintf(&help, "_%s_\n", info.Details)
+	}
+
+	help.WriteString("\n**Usage:**\n")
+	for _, usage := range info.Usage {
+		fmt.Fprintf(&help, "- `%s`\n", usage)
+	}
+	if len(info.Aliases) > 0 {
+		var aliases []string
+		for _, alias := range info.Aliases {
+			aliases = append(aliases, prefix+alias)
+		}
+		fmt.Fprintf(&help, "\n**Aliases:** %s\n", codeList(aliases, ", "))
+	}
+	if len(info.Examples) > 0 {
+		help.WriteString("\n**Examples:**\n")
+		for _, example := range info.Examples {
+			fmt.Fprintf(&help, "- `%s`\n", example)
+		}
+	}
+	if permission := info.Restriction.Permission; permission != "" {
+		fmt.Fprintf(&help, "\n**Required permission:** `%s`", permission)
+		if info.Restriction.AllowAuthor {
+			help.WriteString(" (or being the author of the issue or pull request)")
+		}
+		help.WriteString("\n")
+	}
+	fmt.Fprintf(&help, "\n**Available on:** %s", codeList(eventTypesToStrings(info.Events), ", "))
+	return help.String()
+}
+
+// codeList formats all given items as inline code, joined by the given
+// separator.
+func codeList(items []string, sep string) string {
+	var codes []string
+	for _, item := range items {
+		codes = append(codes, "`"+item+"`")
+	}
+	return strings.Join(codes, sep)
+}
+
+// markdownCell escapes the given text to be used inside a Markdown table
+// cell.
+func markdownCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", "<br>").Replace(text)
+}
+
+// eventTypesToStrings converts the given event types into strings.
+func eventTypesToStrings(eventTypes []EventType) []string {
+	var events []string
+	for _, eventType := range eventTypes {
+		events = append(events, string(eventType))
+	}
+	return events
+}
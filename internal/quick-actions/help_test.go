@@ -0,0 +1,139 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestHelpQuickAction_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		HelpQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestQuickActionHelp(t *testing.T) {
+	info := QuickActionInfo{
+		Command:     "unlabel",
+		Events:      []EventType{EventTypeIssueComment},
+		Restriction: Restriction{Permission: PermissionTriage, AllowAuthor: true},
+		Metadata: Metadata{
+			Usage:       []string{"/unlabel [~label [~label...]]"},
+			Description: "Remove specified labels, or all of them.",
+			Details:     "Label names can also start without a tilde (`~`).",
+			Aliases:     []string{"remove_label"},
+			Examples:    []string{"/unlabel ~bug"},
+		},
+	}
+
+	assert.Equal(t, "#### `/unlabel`\n\n"+
+		"Remove specified labels, or all of them.\n"+
+		"_Label names can also start without a tilde (`~`)._\n\n"+
+		"**Usage:**\n- `/unlabel [~label [~label...]]`\n\n"+
+		"**Aliases:** `/remove_label`\n\n"+
+		"**Examples:**\n- `/unlabel ~bug`\n\n"+
+		"**Required permission:** `triage` (or being the author of the issue or pull request)\n\n"+
+		"**Available on:** `issue_comment`",
+		quickActionHelp("/", info),
+	)
+}
+
+func TestQuickActionHelp_repositoryConfig(t *testing.T) {
+	info := QuickActionInfo{
+		Command:     "unlabel",
+		Events:      []EventType{EventTypeIssueComment},
+		Restriction: Restriction{Permission: PermissionTriage},
+		Metadata: Metadata{
+			Usage:       []string{"/unlabel [~label [~label...]]"},
+			Description: "Remove specified labels, or all of them.",
+			Aliases:     []string{"remove_label"},
+			Examples:    []string{"/unlabel ~bug"},
+		},
+	}
+	config := &RepositoryConfig{
+		Prefix:      "!",
+		Aliases:     map[string]string{"untag": "unlabel", "tag": "label"},
+		Permissions: map[string]Permission{"unlabel": PermissionWrite},
+	}
+
+	assert.Equal(t, "#### `!unlabel`\n\n"+
+		"Remove specified labels, or all of them.\n\n"+
+		"**Usage:**\n- `!unlabel [~label [~label...]]`\n\n"+
+		"**Aliases:** `!remove_label`, `!untag`\n\n"+
+		"**Examples:**\n- `!unlabel ~bug`\n\n"+
+		"**Required permission:** `write`\n\n"+
+		"**Available on:** `issue_comment`",
+		quickActionHelp(config.CommandPrefix(), config.Describe(info)),
+	)
+	assert.Equal(t, []string{"remove_label"}, info.Aliases)
+}
+
+func TestQuickActionsHelp(t *testing.T) {
+	infos := []QuickActionInfo{
+		{Command: "remind", Metadata: Metadata{Usage: []string{"/remind me|@user <message> in <n> <unit>"}, Description: "Post a reminder."}},
+		{Command: "lgtm", Metadata: Metadata{Usage: []string{"/lgtm", "/lgtm cancel"}, Description: "Add the `lgtm` label."}},
+	}
+
+	assert.Equal(t, "#### Quick actions available on `issue_comment` events\n\n"+
+		"| Command | Description |\n"+
+		"| :------ | :---------- |\n"+
+		"| `/remind me\\|@user <message> in <n> <unit>` | Post a reminder. |\n"+
+		"| `/lgtm`<br>`/lgtm cancel` | Add the `lgtm` label. |\n\n"+
+		"_Use `/help <command>` to get more details about a quick action._",
+		quickActionsHelp("/", EventTypeIssueComment, infos, nil),
+	)
+}
+
+func TestQuickActionsHelp_repositoryCommands(t *testing.T) {
+	infos := []QuickActionInfo{
+		{Command: "lgtm", Metadata: Metadata{Usage: []string{"/lgtm"}, Description: "Add the `lgtm` label."}},
+	}
+	config := &RepositoryConfig{Macros: map[string][]string{"triage": {"/label ~triage", "/assign $author"}}}
+
+	assert.Equal(t, "#### Quick actions available on `issue_comment` events\n\n"+
+		"| Command | Description |\n"+
+		"| :------ | :---------- |\n"+
+		"| `/lgtm` | Add the `lgtm` label. |\n\n"+
+		"#### Repository commands\n\n"+
+		"| Command | Description |\n"+
+		"| :------ | :---------- |\n"+
+		"| `/triage [<argument>...]` | Macro running `/label ~triage`, `/assign $author`. |\n\n"+
+		"_Use `/help <command>` to get more details about a quick action._",
+		quickActionsHelp("/", EventTypeIssueComment, infos, config.RepositoryCommands(EventTypeIssueComment)),
+	)
+}
+
+func TestHelpFeature(t *testing.T) {
+	events := HelpQuickAction{}.TriggerOnEvents()
+
+	quickActions := NewGithubQuickActions(nil)
+	quickActions.AddQuickAction("label", &LabelQuickAction{})
+	quickActions.AddQuickAction("unlabel", &UnlabelQuickAction{})
+	quickActions.AddQuickAction("duplicate", &DuplicateQuickAction{})
+	help := &HelpQuickAction{QuickActions: quickActions}
+	quickActions.AddQuickAction("help", help)
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"help": help}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("help && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
@@ -1,7 +1,13 @@
 package quick_actions
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v39/github"
 
@@ -11,7 +17,16 @@ import (
 type (
 	githubEventHelper struct{}
 
+	// issueReference identifies an issue (or a pull request), possibly in
+	// another repository.
+	issueReference struct {
+		Owner  string
+		Repo   string
+		Number int
+	}
+
 	githubInstallationInterface interface{ GetInstallation() *github.Installation }
+	githubSenderInterface       interface{ GetSender() *github.User }
 )
 
 func (githubEventHelper) newInstallationClient(ctx *EventContext, payload EventPayload) (*github.Client, error) {
@@ -22,3 +37,312 @@ func (githubEventHelper) newInstallationClient(ctx *EventContext, payload EventP
 		return nil, fmt.Errorf("invalid event type %T", event)
 	}
 }
+
+// getInstallationID returns the ID of the Github Application installation
+// where the event comes from.
+func (githubEventHelper) getInstallationID(payload EventPayload) int64 {
+	if event, valid := payload.Raw().(githubInstallationInterface); valid {
+		return event.GetInstallation().GetID()
+	}
+	return 0
+}
+
+// getSender returns the login of the user who triggered the event.
+func (githubEventHelper) getSender(payload EventPayload) string {
+	if event, valid := payload.Raw().(githubSenderInterface); valid {
+		return event.GetSender().GetLogin()
+	}
+	return ""
+}
+
+// getIssueAuthor returns the login of the issue or pull request author.
+func (githubEventHelper) getIssueAuthor(payload EventPayload) string {
+	switch event := payload.Raw().(type) {
+	case *github.IssuesEvent:
+		return event.GetIssue().GetUser().GetLogin()
+	case *github.IssueCommentEvent:
+		return event.GetIssue().GetUser().GetLogin()
+	case *github.PullRequestEvent:
+		return event.GetPullRequest().GetUser().GetLogin()
+	case *github.PullRequestReviewCommentEvent:
+		return event.GetPullRequest().GetUser().GetLogin()
+	default:
+		return ""
+	}
+}
+
+// getIssueReference returns the reference of the issue or pull request
+// where the event comes from.
+func (githubEventHelper) getIssueReference(payload EventPayload) issueReference {
+	return issueReference{Owner: payload.RepositoryOwner(), Repo: payload.RepositoryName(), Number: payload.IssueNumber()}
+}
+
+// getIssueNodeID returns the GraphQL node ID of the issue or pull request
+// where the event comes from.
+func (githubEventHelper) getIssueNodeID(payload EventPayload) string {
+	switch event := payload.Raw().(type) {
+	case *github.IssuesEvent:
+		return event.GetIssue().GetNodeID()
+	case *github.IssueCommentEvent:
+		return event.GetIssue().GetNodeID()
+	case *github.PullRequestEvent:
+		return event.GetPullRequest().GetNodeID()
+	case *github.PullRequestReviewCommentEvent:
+		return event.GetPullRequest().GetNodeID()
+	default:
+		return ""
+	}
+}
+
+// getIssueBody returns the description of the issue or pull request where
+// the event comes from.
+func (githubEventHelper) getIssueBody(payload EventPayload) string {
+	switch event := payload.Raw().(type) {
+	case *github.IssuesEvent:
+		return event.GetIssue().GetBody()
+	case *github.IssueCommentEvent:
+		return event.GetIssue().GetBody()
+	case *github.PullRequestEvent:
+		return event.GetPullRequest().GetBody()
+	case *github.PullRequestReviewCommentEvent:
+		return event.GetPullRequest().GetBody()
+	default:
+		return ""
+	}
+}
+
+// getEventDate returns the date of the comment (or the issue/pull request
+// for descriptions) that triggered the event.
+func (githubEventHelper) getEventDate(payload EventPayload) time.Time {
+	var createdAt *time.Time
+	switch event := payload.Raw().(type) {
+	case *github.IssuesEvent:
+		createdAt = event.GetIssue().CreatedAt
+	case *github.IssueCommentEvent:
+		createdAt = event.GetComment().CreatedAt
+	case *github.PullRequestEvent:
+		createdAt = event.GetPullRequest().CreatedAt
+	case *github.PullRequestReviewCommentEvent:
+		createdAt = event.GetComment().CreatedAt
+	}
+
+	if createdAt == nil {
+		return time.Now().UTC()
+	}
+	return createdAt.UTC()
+}
+
+// isPullRequest returns true if the event has been triggered on a pull
+// request (issue comments are shared between issues and pull requests).
+func (githubEventHelper) isPullRequest(payload EventPayload) bool {
+	switch event := payload.Raw().(type) {
+	case *github.IssueCommentEvent:
+		return event.GetIssue().IsPullRequest()
+	case *github.PullRequestEvent, *github.PullRequestReviewCommentEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// getPullRequest fetches the pull request where the event comes from.
+func (githubEventHelper) getPullRequest(ctx *EventContext, client *github.Client, payload EventPayload) (*github.PullRequest, error) {
+	pr, _, err := client.PullRequests.Get(ctx, payload.RepositoryOwner(), payload.RepositoryName(), payload.IssueNumber())
+	return pr, err
+}
+
+// createComment replies to the issue or the pull request where the event
+// comes from.
+func (githubEventHelper) createComment(ctx *EventContext, client *github.Client, payload EventPayload, body string) error {
+	_, _, err := client.Issues.CreateComment(
+		ctx,
+		payload.RepositoryOwner(),
+		payload.RepositoryName(),
+		payload.IssueNumber(),
+		&github.IssueComment{Body: github.String(body)},
+	)
+	return err
+}
+
+// setCommitStatus creates a commit status on the given commit SHA.
+func (githubEventHelper) setCommitStatus(ctx *EventContext, client *github.Client, payload EventPayload, sha string, status *github.RepoStatus) error {
+	_, _, err := client.Repositories.CreateStatus(
+		ctx,
+		payload.RepositoryOwner(),
+		payload.RepositoryName(),
+		sha,
+		status,
+	)
+	return err
+}
+
+// findBotComment returns the first comment, written by the application,
+// containing the given marker (nil if not found).
+func (qa githubEventHelper) findBotComment(ctx *EventContext, client *github.Client, payload EventPayload, marker string) (*github.IssueComment, error) {
+	comments, err := qa.listBotComments(ctx, client, payload, marker)
+	if err != nil || len(comments) == 0 {
+		return nil, err
+	}
+	return comments[0], nil
+}
+
+// listBotComments returns all comments, written by the application,
+// containing the given marker (from the oldest to the newest).
+func (githubEventHelper) listBotComments(ctx *EventContext, client *github.Client, payload EventPayload, marker string) ([]*github.IssueComment, error) {
+	var botComments []*github.IssueComment
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := client.Issues.ListComments(
+			ctx,
+			payload.RepositoryOwner(),
+			payload.RepositoryName(),
+			payload.IssueNumber(),
+			opts,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, comment := range comments {
+			if comment.GetUser().GetType() != "Bot" || !strings.Contains(comment.GetBody(), marker) {
+				continue
+			}
+
+			// NOTE: only comments of the application itself are used to
+			//		 avoid forged ones, from users or from other bots
+			login, err := ctx.AppLogin()
+			if err != nil {
+				return nil, err
+			}
+			if comment.GetUser().GetLogin() == login {
+				botComments = append(botComments, comment)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return botComments, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// upsertBotComment updates the given bot comment or creates a new one
+// if it doesn't exist.
+func (qa githubEventHelper) upsertBotComment(ctx *EventContext, client *github.Client, payload EventPayload, comment *github.IssueComment, body string) error {
+	if comment == nil {
+		return qa.createComment(ctx, client, payload, body)
+	}
+
+	_, _, err := client.Issues.EditComment(
+		ctx,
+		payload.RepositoryOwner(),
+		payload.RepositoryName(),
+		comment.GetID(),
+		&github.IssueComment{Body: github.String(body)},
+	)
+	return err
+}
+
+// updateIssueMetadata replaces the given metadata of the referenced issue
+// by the block returned by update (removed if empty), which receives the
+// current description.
+// NOTE: the description is fetched just before being edited, because the
+//		 event one can be stale and would drop the metadata stored by
+//		 other quick actions in the meantime.
+func (githubEventHelper) updateIssueMetadata(ctx *EventContext, client *github.Client, ref issueReference, name string, update func(body string) (string, error)) error {
+	issue, _, err := client.Issues.Get(ctx, ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		return fmt.Errorf("failed to find issue %s: %w", ref, err)
+	}
+
+	block, err := update(issue.GetBody())
+	if err != nil {
+		return err
+	}
+
+	body := replaceMetadata(issue.GetBody(), name, block)
+	_, _, err = client.Issues.Edit(ctx, ref.Owner, ref.Repo, ref.Number, &github.IssueRequest{Body: github.String(body)})
+	return err
+}
+
+// resolveIssueReference parses the given issue reference; references
+// without repository (like `#12`) belong to the given one.
+func resolveIssueReference(ref string, owner, repo string) (issueReference, error) {
+	arg, valid := ParseIssueReference(ref, owner, repo)
+	if !valid {
+		return issueReference{}, fmt.Errorf("invalid issue reference '%s' (expected `#12`, `owner/repo#12` or an issue URL)", ref)
+	}
+	return issueReferenceFrom(arg), nil
+}
+
+// issueReferenceFrom returns the reference of the given issue-ref argument.
+func issueReferenceFrom(arg Argument) issueReference {
+	return issueReference{Owner: arg.Owner, Repo: arg.Repo, Number: arg.Number}
+}
+
+// issueReferenceOf returns the reference of the given issue, based on its
+// repository API URL.
+func issueReferenceOf(issue *github.Issue) issueReference {
+	ref := issueReference{Number: issue.GetNumber()}
+	parts := strings.Split(strings.TrimSuffix(issue.GetRepositoryURL(), "/"), "/")
+	if len(parts) >= 2 {
+		ref.Owner, ref.Repo = parts[len(parts)-2], parts[len(parts)-1]
+	}
+	return ref
+}
+
+func (ref issueReference) String() string {
+	return fmt.Sprintf("%s/%s#%d", ref.Owner, ref.Repo, ref.Number)
+}
+
+// isNotFound returns true if the error is a Github 404 error.
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// metadataMarker returns the hidden marker used to identify data managed by
+// the given quick action.
+func metadataMarker(name string) string { return fmt.Sprintf("<!-- quick-actions:%s -->", name) }
+
+// encodeMetadata encodes the given data in a hidden Markdown block.
+func encodeMetadata(name string, data interface{}) (string, error) {
+	// NOTE: json.Marshal escapes HTML characters, so the JSON can never
+	//		 close the HTML comment
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s metadata: %w", name, err)
+	}
+	return fmt.Sprintf("<!-- quick-actions:%s:data %s -->", name, raw), nil
+}
+
+// decodeMetadata extracts the data encoded by encodeMetadata from the given
+// Markdown. It returns false if no data has been found.
+func decodeMetadata(markdown, name string, data interface{}) (bool, error) {
+	re := regexp.MustCompile(fmt.Sprintf(`<!-- quick-actions:%s:data (.*?) -->`, regexp.QuoteMeta(name)))
+	match := re.FindStringSubmatch(markdown)
+	if match == nil {
+		return false, nil
+	}
+
+	if err := json.Unmarshal([]byte(match[1]), data); err != nil {
+		return false, fmt.Errorf("failed to decode %s metadata: %w", name, err)
+	}
+	return true, nil
+}
+
+// replaceMetadata replaces the data encoded by encodeMetadata in the given
+// Markdown by the given block (appended if no data has been found). An empty
+// block only removes the current data.
+func replaceMetadata(markdown, name, block string) string {
+	re := regexp.MustCompile(fmt.Sprintf(`\n*<!-- quick-actions:%s:data .*? -->`, regexp.QuoteMeta(name)))
+	markdown = re.ReplaceAllString(markdown, "")
+
+	if block == "" {
+		return markdown
+	}
+	if markdown == "" {
+		return block
+	}
+	return markdown + "\n\n" + block
+}
@@ -0,0 +1,173 @@
+package quick_actions
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/rs/zerolog"
+	"github.com/thoas/go-funk"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+const (
+	// holdLabel is the label added on held pull requests.
+	holdLabel = "do-not-merge/hold"
+	// holdStatusContext is the commit status used to block the merge of
+	// held pull requests through the branch protection.
+	holdStatusContext = "quick-actions/hold"
+)
+
+type (
+	holdHelper struct{ labelsHelper }
+
+	// HoldQuickAction implements QuickAction interface for /hold command.
+	// This quick action prevents a PR to be merged by adding the
+	// `do-not-merge/hold` label and a failing commit status.
+	// It also implements EventHandler in order to keep the failing status on
+	// new commits while the PR is held.
+	HoldQuickAction struct{ holdHelper }
+	// UnholdQuickAction implements QuickAction interface for /unhold command.
+	// This quick action removes the hold set by /hold.
+	UnholdQuickAction struct{ holdHelper }
+)
+
+func (qa HoldQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage, AllowAuthor: true}
+}
+
+func (qa HoldQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/hold"},
+		Description: "Prevent the pull request to be merged.",
+		Details:     "Adds the `do-not-merge/hold` label and a failing `quick-actions/hold` commit status, kept on new commits.",
+	}
+}
+
+func (qa HoldQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "hold").
+		Logger()
+
+	logger.Info().Msgf("handle `/hold` (args: %v)", command.Arguments)
+
+	if !qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/hold can only be used on pull requests; ignored")
+		return nil
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	pr, err := qa.getPullRequest(ctx, client, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	err = qa.addLabels(ctx, client, command.Payload, holdLabel)
+	if err != nil {
+		return err
+	}
+
+	return qa.setHoldStatus(ctx, client, command.Payload, pr.GetHead().GetSHA(), true)
+}
+
+func (qa HoldQuickAction) TriggerOnActions() map[EventType][]EventAction {
+	return map[EventType][]EventAction{EventTypePullRequest: {EventActionSynchronize}}
+}
+func (qa HoldQuickAction) HandledCommand() (string, bool) {
+	return "hold", false
+}
+func (qa HoldQuickAction) HandleEvent(ctx *EventContext, payload EventPayload) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("event_handler", "hold").
+		Logger()
+
+	event, valid := payload.Raw().(*github.PullRequestEvent)
+	if !valid {
+		return fmt.Errorf("invalid event type %T", payload.Raw())
+	}
+
+	if !funk.ContainsString(qa.getExistingLabels(payload), holdLabel) {
+		logger.Debug().Msgf("pull request not held; ignored")
+		return nil
+	}
+
+	client, err := qa.newInstallationClient(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("keep hold on new head %s", event.GetPullRequest().GetHead().GetSHA())
+	return qa.setHoldStatus(ctx, client, payload, event.GetPullRequest().GetHead().GetSHA(), true)
+}
+
+func (qa UnholdQuickAction) Restriction() Restriction {
+	// NOTE: unlike /hold, the author can't release the pull request; a hold
+	//		 set by a reviewer must not be bypassed by the author
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa UnholdQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/unhold"},
+		Description: "Release a pull request held with `/hold`.",
+		Details:     "Requires the `triage` permission, even for the author of the pull request.",
+	}
+}
+
+func (qa UnholdQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "unhold").
+		Logger()
+
+	logger.Info().Msgf("handle `/unhold` (args: %v)", command.Arguments)
+
+	if !qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/unhold can only be used on pull requests; ignored")
+		return nil
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	pr, err := qa.getPullRequest(ctx, client, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	err = qa.removeLabels(ctx, client, command.Payload, holdLabel)
+	if err != nil && !isNotFound(err) {
+		// NOTE: the label can be already removed manually
+		return err
+	}
+
+	return qa.setHoldStatus(ctx, client, command.Payload, pr.GetHead().GetSHA(), false)
+}
+
+func (qa holdHelper) setHoldStatus(ctx *EventContext, client *github.Client, payload EventPayload, sha string, hold bool) error {
+	status := &github.RepoStatus{
+		State:       github.String("success"),
+		Context:     github.String(holdStatusContext),
+		Description: github.String("Pull request not held"),
+	}
+	if hold {
+		status.State = github.String("failure")
+		status.Description = github.String("Pull request held; use /unhold to release it")
+	}
+
+	return qa.setCommitStatus(ctx, client, payload, sha, status)
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("hold", &HoldQuickAction{})
+	registerQuickAction("unhold", &UnholdQuickAction{})
+
+	// NOTE: register event handlers
+	registerEventHandler("hold", &HoldQuickAction{})
+}
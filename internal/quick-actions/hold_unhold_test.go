@@ -0,0 +1,102 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestHold_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		HoldQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestHold_TriggerOnActions(t *testing.T) {
+	assert.Equal(t,
+		map[EventType][]EventAction{EventTypePullRequest: {EventActionSynchronize}},
+		HoldQuickAction{}.TriggerOnActions(),
+	)
+}
+
+func TestHoldFeature(t *testing.T) {
+	events := HoldQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"hold": &HoldQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("hold && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestHoldEventHandlerFeature(t *testing.T) {
+	for event := range (HoldQuickAction{}).TriggerOnActions() {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializerWithHandlers(nil, map[string]EventHandler{"hold": &HoldQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("hold && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestUnhold_Restriction(t *testing.T) {
+	// NOTE: the author must not be able to release a hold set by a reviewer
+	assert.Equal(t, Restriction{Permission: PermissionTriage}, UnholdQuickAction{}.Restriction())
+}
+
+func TestUnhold_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		UnholdQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestUnholdFeature(t *testing.T) {
+	events := UnholdQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"unhold": &UnholdQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("unhold && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package quick_actions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LabelPolicy defines how /label handles labels missing from the
+// repository.
+type LabelPolicy string
+
+const (
+	// LabelPolicyAllow adds missing labels anyway; Github creates them
+	// without color nor description, unless a color is given (like
+	// LabelPolicyCreate).
+	LabelPolicyAllow LabelPolicy = "allow"
+	// LabelPolicyCreate creates missing labels with the given color and
+	// description (like `~bug:d73a4a="Something isn't working"`); missing
+	// labels without color are rejected.
+	LabelPolicyCreate LabelPolicy = "create"
+	// LabelPolicyReject rejects missing labels, even with a color.
+	LabelPolicyReject LabelPolicy = "reject"
+)
+
+type (
+	// labelOptions contains the repository options of /label.
+	labelOptions struct {
+		// Policy overrides the label policy on the repository.
+		Policy LabelPolicy `yaml:"policy"`
+	}
+
+	// labelSpec describes a label given to /label, with its optional
+	// color and description used to create it.
+	labelSpec struct {
+		Name        string
+		Color       string
+		Description string
+	}
+)
+
+// defaultLabelPolicy is the policy used by /label when none is defined on
+// the quick action.
+var defaultLabelPolicy = LabelPolicyAllow
+
+// labelColorRegexp matches labels with a color, like `bug:d73a4a`.
+var labelColorRegexp = regexp.MustCompile(`^(.+):#?([0-9a-fA-F]{6})$`)
+
+// SetDefaultLabelPolicy defines the policy used by /label on all
+// repositories (one of `allow`, `create` or `reject`).
+func SetDefaultLabelPolicy(policy string) error {
+	if err := LabelPolicy(policy).validate(); err != nil {
+		return err
+	}
+	defaultLabelPolicy = LabelPolicy(policy)
+	return nil
+}
+
+// validate checks that the label policy is a known one.
+func (policy LabelPolicy) validate() error {
+	switch policy {
+	case LabelPolicyAllow, LabelPolicyCreate, LabelPolicyReject:
+		return nil
+	default:
+		return fmt.Errorf("invalid label policy '%s' (expected `allow`, `create` or `reject`)", policy)
+	}
+}
+
+// Validate implements the validation of the repository options.
+func (options labelOptions) Validate() error {
+	if options.Policy == "" {
+		return nil
+	}
+	return options.Policy.validate()
+}
+
+// parseLabelSpec parses a label with its optional color and description,
+// like `bug:d73a4a=Something isn't working`.
+func parseLabelSpec(label string) labelSpec {
+	var spec labelSpec
+	if idx := strings.Index(label, "="); idx > 0 {
+		label, spec.Description = label[:idx], label[idx+1:]
+	}
+
+	spec.Name = label
+	if match := labelColorRegexp.FindStringSubmatch(label); match != nil {
+		spec.Name, spec.Color = match[1], strings.ToLower(match[2])
+	}
+	return spec
+}
+
+// closeLabels returns the labels (at most 3) with a name close to the given
+// one, sorted by similarity.
+func closeLabels(name string, labels []string) []string {
+	type candidate struct {
+		label    string
+		distance int
+	}
+
+	name = strings.ToLower(name)
+	var candidates []candidate
+	for _, label := range labels {
+		lower := strings.ToLower(label)
+		distance := levenshtein(name, lower)
+
+		switch {
+		case distance <= 2 && distance < len(name):
+		case len(name) >= 3 && (strings.Contains(lower, name) || strings.Contains(name, lower)):
+		default:
+			continue
+		}
+		candidates = append(candidates, candidate{label, distance})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].label < candidates[j].label
+	})
+
+	var matches []string
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		matches = append(matches, candidates[i].label)
+	}
+	return matches
+}
+
+// levenshtein returns the edit distance between the two given strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// missingLabelError returns the error describing why the given missing label
+// is refused, with the closest existing labels.
+func missingLabelError(name string, policy LabelPolicy, labels []string) error {
+	msg := fmt.Sprintf("label '%s' doesn't exist", name)
+
+	if matches := closeLabels(name, labels); len(matches) > 0 {
+		for i := range matches {
+			matches[i] = "`~" + matches[i] + "`"
+		}
+		msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(matches, " or "))
+	}
+
+	if policy == LabelPolicyCreate {
+		msg += fmt.Sprintf("; use `~%s:<color>` (like `~%s:d73a4a`) to create it", name, name)
+	}
+	return errors.New(msg)
+}
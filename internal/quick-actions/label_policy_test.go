@@ -0,0 +1,210 @@
+package quick_actions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+	gqa_httptest "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx/httptest"
+)
+
+func TestParseLabelSpec(t *testing.T) {
+	ts := map[string]labelSpec{
+		"bug":                                    {Name: "bug"},
+		"bug:d73a4a":                             {Name: "bug", Color: "d73a4a"},
+		"bug:#D73A4A":                            {Name: "bug", Color: "d73a4a"},
+		"bug:critical":                           {Name: "bug:critical"},
+		"kind:bug:d73a4a":                        {Name: "kind:bug", Color: "d73a4a"},
+		"needs-info:fbca04=Waiting for reporter": {Name: "needs-info", Color: "fbca04", Description: "Waiting for reporter"},
+		"needs-info=Waiting for reporter":        {Name: "needs-info", Description: "Waiting for reporter"},
+		"bug:d73a4":                              {Name: "bug:d73a4"},
+	}
+
+	for label, spec := range ts {
+		label, spec := label, spec
+		t.Run(label, func(t *testing.T) {
+			assert.Equal(t, spec, parseLabelSpec(label))
+		})
+	}
+}
+
+func TestCloseLabels(t *testing.T) {
+	labels := []string{"bug", "Feature", "documentation", "good first issue", "ci", "ui"}
+
+	ts := map[string][]string{
+		"bgu":      {"bug"},
+		"bugs":     {"bug"},
+		"feature":  {"Feature"},
+		"featrue":  {"Feature"},
+		"doc":      {"documentation"},
+		"cd":       {"ci"},
+		"question": nil,
+		"x":        nil,
+	}
+
+	for name, matches := range ts {
+		name, matches := name, matches
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, matches, closeLabels(name, labels))
+		})
+	}
+}
+
+func TestSetDefaultLabelPolicy(t *testing.T) {
+	defer func() { defaultLabelPolicy = LabelPolicyAllow }()
+
+	require.NoError(t, SetDefaultLabelPolicy("reject"))
+	assert.Equal(t, LabelPolicyReject, LabelQuickAction{}.policy(&EventCommand{}))
+	assert.Equal(t, LabelPolicyCreate, LabelQuickAction{Policy: LabelPolicyCreate}.policy(&EventCommand{}))
+	assert.Equal(t, LabelPolicyAllow, LabelQuickAction{Policy: LabelPolicyCreate}.policy(&EventCommand{Options: &labelOptions{Policy: LabelPolicyAllow}}))
+
+	assert.EqualError(t, SetDefaultLabelPolicy("deny"), "invalid label policy 'deny' (expected `allow`, `create` or `reject`)")
+}
+
+func TestLabelOptions_Validate(t *testing.T) {
+	assert.NoError(t, labelOptions{}.Validate())
+	assert.NoError(t, labelOptions{Policy: LabelPolicyReject}.Validate())
+	assert.EqualError(t, labelOptions{Policy: "deny"}.Validate(), "invalid label policy 'deny' (expected `allow`, `create` or `reject`)")
+}
+
+func TestLabelQuickAction_Policy(t *testing.T) {
+	payload, err := PayloadFactory(EventTypeIssueComment, []byte(`{
+		"action": "created",
+		"repository": { "owner": { "login": "xunleii" }, "name": "github-quick-actions" },
+		"issue": { "number": 1 },
+		"installation": { "id": 123456789 }
+	}`))
+	require.NoError(t, err)
+
+	ts := map[string]struct {
+		policy    LabelPolicy
+		options   *labelOptions
+		prefix    string
+		arguments []string
+		requests  []string
+		err       string
+	}{
+		"allow unknown label": {
+			policy:    LabelPolicyAllow,
+			arguments: []string{"~bgu", "~bug:critical"},
+			requests:  []string{`POST /repos/xunleii/github-quick-actions/issues/1/labels ["bgu","bug:critical"]`},
+		},
+		"allow labels with color": {
+			policy:    LabelPolicyAllow,
+			arguments: []string{"~bug:d73a4a", "~question:d876e3=Further information is requested", "~bgu"},
+			requests: []string{
+				`POST /repos/xunleii/github-quick-actions/labels {"name":"question","color":"d876e3","description":"Further information is requested"}`,
+				`POST /repos/xunleii/github-quick-actions/issues/1/labels ["bug","question","bgu"]`,
+			},
+		},
+		"reject known labels": {
+			policy:    LabelPolicyReject,
+			arguments: []string{"~bug", "~feature"},
+			requests:  []string{`POST /repos/xunleii/github-quick-actions/issues/1/labels ["bug","Feature"]`},
+		},
+		"reject unknown labels": {
+			policy:    LabelPolicyReject,
+			arguments: []string{"~bgu", "~feature", "~question"},
+			err: "2 errors occurred:\n" +
+				"\t* label 'bgu' doesn't exist (did you mean `~bug`?)\n" +
+				"\t* label 'question' doesn't exist\n\n",
+		},
+		"reject labels with color": {
+			policy:    LabelPolicyReject,
+			arguments: []string{"~bgu:d73a4a"},
+			err:       "1 error occurred:\n\t* label 'bgu' doesn't exist (did you mean `~bug`?)\n\n",
+		},
+		"reject known labels with color": {
+			policy:    LabelPolicyReject,
+			arguments: []string{"~bug:d73a4a"},
+			requests:  []string{`POST /repos/xunleii/github-quick-actions/issues/1/labels ["bug"]`},
+		},
+		"reject unknown prefixed labels from the options": {
+			policy:    LabelPolicyAllow,
+			options:   &labelOptions{Policy: LabelPolicyReject},
+			prefix:    "priority",
+			arguments: []string{"hgih"},
+			err:       "1 error occurred:\n\t* label 'priority/hgih' doesn't exist (did you mean `~priority/high`?)\n\n",
+		},
+		"reject known prefixed labels from the options": {
+			policy:    LabelPolicyAllow,
+			options:   &labelOptions{Policy: LabelPolicyReject},
+			prefix:    "priority",
+			arguments: []string{"HIGH"},
+			requests:  []string{`POST /repos/xunleii/github-quick-actions/issues/1/labels ["priority/high"]`},
+		},
+		"create prefixed labels with color": {
+			policy:    LabelPolicyCreate,
+			prefix:    "priority",
+			arguments: []string{"low:0e8a16"},
+			requests: []string{
+				`POST /repos/xunleii/github-quick-actions/labels {"name":"priority/low","color":"0e8a16"}`,
+				`POST /repos/xunleii/github-quick-actions/issues/1/labels ["priority/low"]`,
+			},
+		},
+		"create unknown labels": {
+			policy:    LabelPolicyCreate,
+			arguments: []string{"~bug:d73a4a", "~question:d876e3=Further information is requested"},
+			requests: []string{
+				`POST /repos/xunleii/github-quick-actions/labels {"name":"question","color":"d876e3","description":"Further information is requested"}`,
+				`POST /repos/xunleii/github-quick-actions/issues/1/labels ["bug","question"]`,
+			},
+		},
+		"create unknown labels without color": {
+			policy:    LabelPolicyCreate,
+			arguments: []string{"~bgu", "~question:d876e3"},
+			err:       "1 error occurred:\n\t* label 'bgu' doesn't exist (did you mean `~bug`?); use `~bgu:<color>` (like `~bgu:d73a4a`) to create it\n\n",
+		},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mux := http.NewServeMux()
+			mux.HandleFunc("/repos/xunleii/github-quick-actions/labels", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`[{"name": "bug"}, {"name": "Feature"}, {"name": "documentation"}, {"name": "priority/high"}]`))
+					return
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, bytes.TrimSpace(body)))
+				_, _ = w.Write(body)
+			})
+			mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/1/labels", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, bytes.TrimSpace(body)))
+				_, _ = w.Write([]byte(`[]`))
+			})
+
+			srv := gqa_httptest.NewServer(mux)
+			ctx := &EventContext{Context: context.TODO(), ClientCreator: &gqa_scenario_context.ClientCreator{Client: srv.Client()}}
+			command := &EventCommand{Command: "label", Arguments: tc.arguments, Payload: payload}
+			if tc.options != nil {
+				command.Options = tc.options
+			}
+
+			var action QuickAction = LabelQuickAction{Policy: tc.policy}
+			if tc.prefix != "" {
+				action = PrefixedLabelQuickAction{LabelQuickAction: LabelQuickAction{Policy: tc.policy}, Prefix: tc.prefix}
+			}
+			err := action.HandleCommand(ctx, command)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Empty(t, requests)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.requests, requests)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package quick_actions
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/google/go-github/v39/github"
 	"github.com/hashicorp/go-multierror"
 	"github.com/rs/zerolog"
 	"github.com/thoas/go-funk"
@@ -14,8 +16,16 @@ type (
 	labelsHelper struct{ githubEventHelper }
 
 	// LabelQuickAction implements QuickAction interface for /label command.
-	// This quick action adds one or several labels to an issue or a PR.
-	LabelQuickAction struct{ labelsHelper }
+	// This quick action adds one or several labels to an issue or a PR;
+	// labels missing from the repository are handled according to the
+	// label policy.
+	LabelQuickAction struct {
+		labelsHelper
+
+		// Policy defines how missing labels are handled (the default
+		// label policy is used if empty).
+		Policy LabelPolicy
+	}
 	// UnlabelQuickAction implements QuickAction interface for /unlabel or /remove_label command.
 	// This quick action removes one or several labels to an issue or a PR.
 	UnlabelQuickAction struct{ labelsHelper }
@@ -25,6 +35,25 @@ func (qa LabelQuickAction) TriggerOnEvents() []EventType {
 	// NOTE: adding label should be triggered on issues & pull requests description too
 	return []EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment}
 }
+func (qa LabelQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "label", Kind: ArgumentKindLabel, Required: true, Variadic: true}}
+}
+func (qa LabelQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+func (qa LabelQuickAction) Options() interface{} {
+	return &labelOptions{}
+}
+
+func (qa LabelQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/label ~label [~label...]", `/label ~label:color[="description"] [~label...]`},
+		Description: "Add one or more labels.",
+		Details:     "Label names can also start without a tilde (`~`). Labels missing from the repository are added anyway (`allow`), refused with the closest existing labels (`reject`) or only created with a color (`create`), depending on the label policy (see `GQA_LABEL_POLICY` or the `policy` option); unless refused, they are created with the given color and description, like `~bug:d73a4a=\"Something isn't working\"`.",
+		Examples:    []string{"/label ~bug ~help-wanted"},
+	}
+}
+
 func (qa LabelQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
 	logger := zerolog.Ctx(ctx).With().
 		Str("quick_action", "label").
@@ -43,15 +72,111 @@ func (qa LabelQuickAction) HandleCommand(ctx *EventContext, command *EventComman
 		return err
 	}
 
-	_, _, err = client.Issues.AddLabelsToIssue(
-		ctx,
-		command.Payload.RepositoryOwner(),
-		command.Payload.RepositoryName(),
-		command.Payload.IssueNumber(),
-		labels,
-	)
+	policy := qa.policy(command)
+	logger.Debug().Msgf("check labels against the repository (policy: %s)", policy)
 
-	return err
+	labels, err = qa.resolveLabels(ctx, client, command.Payload, policy, labels)
+	if err != nil {
+		return err
+	}
+
+	return qa.addLabels(ctx, client, command.Payload, labels...)
+}
+
+// policy returns the label policy used by this quick action: the one of the
+// repository options, the one of the quick action or the default one.
+func (qa LabelQuickAction) policy(command *EventCommand) LabelPolicy {
+	if options, valid := command.Options.(*labelOptions); valid && options.Policy != "" {
+		return options.Policy
+	}
+	if qa.Policy == "" {
+		return defaultLabelPolicy
+	}
+	return qa.Policy
+}
+
+// resolveLabels returns the repository names of the given labels, parsed
+// with their optional color and description, creating the missing ones if
+// the policy allows it. Nothing is created if any label is refused.
+func (qa LabelQuickAction) resolveLabels(ctx *EventContext, client *github.Client, payload EventPayload, policy LabelPolicy, labels []string) ([]string, error) {
+	var specs []labelSpec
+	withColor := false
+	for _, label := range labels {
+		spec := parseLabelSpec(label)
+		specs = append(specs, spec)
+		withColor = withColor || spec.Color != ""
+	}
+
+	// NOTE: with the allow policy, repository labels are only required to
+	//		 create the missing labels with their color
+	if policy == LabelPolicyAllow && !withColor {
+		return labelNames(labels), nil
+	}
+
+	existing, err := qa.listRepositoryLabels(ctx, client, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: Github label names are case-insensitive
+	names := map[string]string{}
+	for _, label := range existing {
+		names[strings.ToLower(label)] = label
+	}
+
+	errs := &multierror.Error{}
+	for _, spec := range specs {
+		_, found := names[strings.ToLower(spec.Name)]
+		if !found && (policy == LabelPolicyReject || policy == LabelPolicyCreate && spec.Color == "") {
+			errs = multierror.Append(errs, missingLabelError(spec.Name, policy, existing))
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	var resolved []string
+	for _, spec := range specs {
+		if name, found := names[strings.ToLower(spec.Name)]; found {
+			resolved = append(resolved, name)
+			continue
+		}
+		if spec.Color == "" {
+			// NOTE: only allowed by the allow policy; Github creates it
+			resolved = append(resolved, spec.Name)
+			continue
+		}
+
+		request := &github.Label{Name: github.String(spec.Name), Color: github.String(spec.Color)}
+		if spec.Description != "" {
+			request.Description = github.String(spec.Description)
+		}
+
+		label, _, err := client.Issues.CreateLabel(ctx, payload.RepositoryOwner(), payload.RepositoryName(), request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create label '%s': %w", spec.Name, err)
+		}
+		names[strings.ToLower(spec.Name)] = label.GetName()
+		resolved = append(resolved, label.GetName())
+	}
+	return funk.UniqString(resolved), nil
+}
+
+func (qa UnlabelQuickAction) Arguments() []ArgumentSpec {
+	// NOTE: all labels are removed if no label is given
+	return []ArgumentSpec{{Name: "label", Kind: ArgumentKindLabel, Variadic: true}}
+}
+func (qa UnlabelQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa UnlabelQuickAction) Metadata() Metadata {
+	return Metadata{
+		Description: "Remove specified labels, or all of them.",
+		Details:     "Label names can also start without a tilde (`~`).",
+		Aliases:     []string{"remove_label"},
+		Examples:    []string{"/unlabel ~bug", "/unlabel"},
+	}
 }
 
 func (qa UnlabelQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
@@ -69,22 +194,7 @@ func (qa UnlabelQuickAction) HandleCommand(ctx *EventContext, command *EventComm
 	}
 
 	if len(labels) > 0 {
-		errs := multierror.Group{}
-
-		for _, label := range labels {
-			label := label
-			errs.Go(func() error {
-				_, err := client.Issues.RemoveLabelForIssue(
-					ctx,
-					command.Payload.RepositoryOwner(),
-					command.Payload.RepositoryName(),
-					command.Payload.IssueNumber(),
-					label,
-				)
-				return err
-			})
-		}
-		return errs.Wait().ErrorOrNil()
+		return qa.removeLabels(ctx, client, command.Payload, labels...)
 	}
 
 	_, err = client.Issues.RemoveLabelsForIssue(
@@ -100,6 +210,76 @@ func (labelsHelper) TriggerOnEvents() []EventType {
 	// NOTE: all label changes should be triggered on comment
 	return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
 }
+func (qa labelsHelper) addLabels(ctx *EventContext, client *github.Client, payload EventPayload, labels ...string) error {
+	return qa.addIssueLabels(ctx, client, qa.getIssueReference(payload), labels...)
+}
+func (qa labelsHelper) removeLabels(ctx *EventContext, client *github.Client, payload EventPayload, labels ...string) error {
+	return qa.removeIssueLabels(ctx, client, qa.getIssueReference(payload), labels...)
+}
+
+// addIssueLabels adds the given labels to the referenced issue (or pull
+// request); it can be used outside of any Github event.
+func (labelsHelper) addIssueLabels(ctx *EventContext, client *github.Client, ref issueReference, labels ...string) error {
+	_, _, err := client.Issues.AddLabelsToIssue(ctx, ref.Owner, ref.Repo, ref.Number, labels)
+	return err
+}
+
+// removeIssueLabels removes the given labels from the referenced issue (or
+// pull request); it can be used outside of any Github event.
+func (labelsHelper) removeIssueLabels(ctx *EventContext, client *github.Client, ref issueReference, labels ...string) error {
+	errs := multierror.Group{}
+
+	for _, label := range labels {
+		label := label
+		errs.Go(func() error {
+			_, err := client.Issues.RemoveLabelForIssue(ctx, ref.Owner, ref.Repo, ref.Number, label)
+			return err
+		})
+	}
+	return errs.Wait().ErrorOrNil()
+}
+
+// listRepositoryLabels returns the names of all labels defined on the
+// repository.
+func (labelsHelper) listRepositoryLabels(ctx *EventContext, client *github.Client, payload EventPayload) ([]string, error) {
+	var labels []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.Issues.ListLabels(ctx, payload.RepositoryOwner(), payload.RepositoryName(), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, label := range page {
+			labels = append(labels, label.GetName())
+		}
+
+		if resp.NextPage == 0 {
+			return labels, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+func (labelsHelper) getExistingLabels(payload EventPayload) []string {
+	var ghLabels []*github.Label
+	switch event := payload.Raw().(type) {
+	case *github.IssuesEvent:
+		ghLabels = event.GetIssue().Labels
+	case *github.IssueCommentEvent:
+		ghLabels = event.GetIssue().Labels
+	case *github.PullRequestEvent:
+		ghLabels = event.GetPullRequest().Labels
+	case *github.PullRequestReviewCommentEvent:
+		ghLabels = event.GetPullRequest().Labels
+	}
+
+	var labels []string
+	for _, label := range ghLabels {
+		labels = append(labels, label.GetName())
+	}
+
+	return labels
+}
 func (labelsHelper) getLabels(command *EventCommand) []string {
 	var labels []string
 	for _, label := range command.Arguments {
@@ -120,5 +300,4 @@ func init() {
 	// NOTE: register quick actions
 	registerQuickAction("label", &LabelQuickAction{})
 	registerQuickAction("unlabel", &UnlabelQuickAction{})
-	registerQuickAction("remove_label", &UnlabelQuickAction{})
 }
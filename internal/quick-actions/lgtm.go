@@ -0,0 +1,117 @@
+package quick_actions
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/thoas/go-funk"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+// lgtmLabel is the label added on pull requests that "looks good to me".
+const lgtmLabel = "lgtm"
+
+type (
+	// LgtmQuickAction implements QuickAction interface for /lgtm command.
+	// This quick action adds (or removes with `/lgtm cancel`) the `lgtm`
+	// label on a PR.
+	// It also implements EventHandler in order to remove the label when new
+	// commits are pushed.
+	LgtmQuickAction struct{ labelsHelper }
+)
+
+func (qa LgtmQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionWrite}
+}
+
+func (qa LgtmQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/lgtm", "/lgtm cancel"},
+		Description: "Add (or remove) the `lgtm` label on the pull request.",
+		Details:     "The pull request author cannot use it and the label is removed when new commits are pushed.",
+	}
+}
+
+func (qa LgtmQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "lgtm").
+		Logger()
+
+	logger.Info().Msgf("handle `/lgtm` (args: %v)", command.Arguments)
+
+	if !qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/lgtm can only be used on pull requests; ignored")
+		return nil
+	}
+
+	cancel := false
+	switch {
+	case len(command.Arguments) == 0:
+	case len(command.Arguments) == 1 && command.Arguments[0] == "cancel":
+		cancel = true
+	default:
+		return fmt.Errorf("invalid arguments %v for /lgtm", command.Arguments)
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	if cancel {
+		err = qa.removeLabels(ctx, client, command.Payload, lgtmLabel)
+		if isNotFound(err) {
+			// NOTE: the label can be already removed
+			return nil
+		}
+		return err
+	}
+
+	author, sender := qa.getIssueAuthor(command.Payload), qa.getSender(command.Payload)
+	if author != "" && author == sender {
+		logger.Debug().Msgf("@%s cannot /lgtm their own pull request; rejected", sender)
+		return qa.createComment(ctx, client, command.Payload,
+			fmt.Sprintf("@%s you cannot `/lgtm` your own pull request.", sender),
+		)
+	}
+
+	return qa.addLabels(ctx, client, command.Payload, lgtmLabel)
+}
+
+func (qa LgtmQuickAction) TriggerOnActions() map[EventType][]EventAction {
+	return map[EventType][]EventAction{EventTypePullRequest: {EventActionSynchronize}}
+}
+func (qa LgtmQuickAction) HandledCommand() (string, bool) {
+	return "lgtm", false
+}
+func (qa LgtmQuickAction) HandleEvent(ctx *EventContext, payload EventPayload) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("event_handler", "lgtm").
+		Logger()
+
+	if !funk.ContainsString(qa.getExistingLabels(payload), lgtmLabel) {
+		logger.Debug().Msgf("no `%s` label found; ignored", lgtmLabel)
+		return nil
+	}
+
+	client, err := qa.newInstallationClient(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("new commits pushed, remove `%s` label", lgtmLabel)
+	err = qa.removeLabels(ctx, client, payload, lgtmLabel)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("lgtm", &LgtmQuickAction{})
+
+	// NOTE: register event handlers
+	registerEventHandler("lgtm", &LgtmQuickAction{})
+}
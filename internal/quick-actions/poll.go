@@ -0,0 +1,256 @@
+package quick_actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/rs/zerolog"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+// pollName is the name used to identify the poll comments.
+const pollName = "poll"
+
+type (
+	// PollQuickAction implements QuickAction interface for /poll command.
+	// This quick action posts a poll where users vote with reactions, like
+	// `/poll "Question?" "Option A" "Option B"`; `/poll close` closes the
+	// last open poll and tallies the results.
+	PollQuickAction struct{ githubEventHelper }
+
+	// pollData contains the poll, persisted in the poll comment.
+	pollData struct {
+		Question string   `json:"question"`
+		Options  []string `json:"options"`
+		Closed   bool     `json:"closed,omitempty"`
+		// Votes contains the votes per option, set once the poll is closed.
+		Votes []int `json:"votes,omitempty"`
+	}
+)
+
+// pollReactions contains the reactions (and their Markdown emoji) used to
+// vote for each option, ordered like the Github reaction picker.
+var pollReactions = []struct{ content, emoji string }{
+	{"+1", ":+1:"},
+	{"-1", ":-1:"},
+	{"laugh", ":smile:"},
+	{"hooray", ":tada:"},
+	{"confused", ":confused:"},
+	{"heart", ":heart:"},
+	{"rocket", ":rocket:"},
+	{"eyes", ":eyes:"},
+}
+
+func (qa PollQuickAction) TriggerOnEvents() []EventType {
+	return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
+}
+
+func (qa PollQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/poll <question> <option> [<option>...]", "/poll close"},
+		Description: "Post a poll where users vote with reactions (one per option, up to 8 options).",
+		Details:     "`/poll close` closes the last open poll and tallies the results; each user is counted once (only their first vote is kept).",
+		Examples:    []string{`/poll "Which release name?" "Argon" "Boron"`, "/poll close"},
+	}
+}
+
+func (qa PollQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "poll").
+		Logger()
+
+	logger.Info().Msgf("handle `/poll` (args: %v)", command.Arguments)
+
+	if len(command.Arguments) == 1 && command.Arguments[0] == "close" {
+		return qa.closePoll(ctx, command)
+	}
+
+	if len(command.Arguments) < 3 {
+		return fmt.Errorf("/poll requires a question and at least 2 options (like `/poll \"Question?\" \"Option A\" \"Option B\"`)")
+	}
+	if len(command.Arguments)-1 > len(pollReactions) {
+		return fmt.Errorf("/poll supports at most %d options (one per reaction)", len(pollReactions))
+	}
+
+	data := pollData{Question: command.Arguments[0], Options: command.Arguments[1:]}
+	body, err := data.render()
+	if err != nil {
+		return err
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	comment, _, err := client.Issues.CreateComment(
+		ctx,
+		command.Payload.RepositoryOwner(),
+		command.Payload.RepositoryName(),
+		command.Payload.IssueNumber(),
+		&github.IssueComment{Body: github.String(body)},
+	)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: reactions are added by the bot to make voting easier; they are
+	//		 ignored by the tally
+	for i := range data.Options {
+		_, _, err := client.Reactions.CreateIssueCommentReaction(
+			ctx,
+			command.Payload.RepositoryOwner(),
+			command.Payload.RepositoryName(),
+			comment.GetID(),
+			pollReactions[i].content,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closePoll closes the last open poll and updates its comment with the
+// results.
+func (qa PollQuickAction) closePoll(ctx *EventContext, command *EventCommand) error {
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	comments, err := qa.listBotComments(ctx, client, command.Payload, metadataMarker(pollName))
+	if err != nil {
+		return err
+	}
+
+	var comment *github.IssueComment
+	data := &pollData{}
+	for i := len(comments) - 1; i >= 0 && comment == nil; i-- {
+		data = &pollData{}
+		if _, err := decodeMetadata(comments[i].GetBody(), pollName, data); err != nil {
+			return err
+		}
+		if !data.Closed {
+			comment = comments[i]
+		}
+	}
+	if comment == nil {
+		return fmt.Errorf("no open poll found")
+	}
+
+	var reactions []*github.Reaction
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.Reactions.ListIssueCommentReactions(
+			ctx,
+			command.Payload.RepositoryOwner(),
+			command.Payload.RepositoryName(),
+			comment.GetID(),
+			opts,
+		)
+		if err != nil {
+			return err
+		}
+		reactions = append(reactions, page...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	data.Closed = true
+	data.Votes = data.tally(reactions)
+	body, err := data.render()
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.Issues.EditComment(
+		ctx,
+		command.Payload.RepositoryOwner(),
+		command.Payload.RepositoryName(),
+		comment.GetID(),
+		&github.IssueComment{Body: github.String(body)},
+	)
+	return err
+}
+
+// tally counts the votes per option from the given reactions. Each user is
+// counted once (only their first vote is kept) and bots are ignored.
+func (data pollData) tally(reactions []*github.Reaction) []int {
+	votes := make([]int, len(data.Options))
+	voters := map[int64]bool{}
+	for _, reaction := range reactions {
+		user := reaction.GetUser()
+		if user.GetType() == "Bot" || voters[user.GetID()] {
+			continue
+		}
+
+		for i := range data.Options {
+			if pollReactions[i].content == reaction.GetContent() {
+				votes[i]++
+				voters[user.GetID()] = true
+				break
+			}
+		}
+	}
+	return votes
+}
+
+// render generates the poll comment.
+func (data pollData) render() (string, error) {
+	metadata, err := encodeMetadata(pollName, data)
+	if err != nil {
+		return "", err
+	}
+
+	poll := &strings.Builder{}
+	poll.WriteString(metadataMarker(pollName) + "\n")
+
+	if !data.Closed {
+		fmt.Fprintf(poll, "#### :bar_chart: %s\n\n", data.Question)
+		poll.WriteString("_React to this comment to vote (only your first vote is counted)._\n\n")
+		poll.WriteString("| Vote | Option |\n")
+		poll.WriteString("| :--: | :----- |\n")
+		for i, option := range data.Options {
+			fmt.Fprintf(poll, "| %s | %s |\n", pollReactions[i].emoji, escapeTableCell(option))
+		}
+		poll.WriteString("\n")
+		poll.WriteString(metadata)
+		return poll.String(), nil
+	}
+
+	total := 0
+	for _, votes := range data.Votes {
+		total += votes
+	}
+
+	fmt.Fprintf(poll, "#### :bar_chart: %s (closed)\n\n", data.Question)
+	poll.WriteString("| Vote | Option | Votes |\n")
+	poll.WriteString("| :--: | :----- | ----: |\n")
+	for i, option := range data.Options {
+		percent := 0
+		if total > 0 {
+			percent = data.Votes[i] * 100 / total
+		}
+		fmt.Fprintf(poll, "| %s | %s | %d (%d%%) |\n", pollReactions[i].emoji, escapeTableCell(option), data.Votes[i], percent)
+	}
+	fmt.Fprintf(poll, "\n**Total:** %d vote(s)\n\n", total)
+	poll.WriteString(metadata)
+	return poll.String(), nil
+}
+
+// escapeTableCell escapes the given text in order to be used inside a
+// Markdown table cell.
+func escapeTableCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("poll", &PollQuickAction{})
+}
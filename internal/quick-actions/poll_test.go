@@ -0,0 +1,62 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestPollData_tally(t *testing.T) {
+	reaction := func(content string, id int64, kind string) *github.Reaction {
+		return &github.Reaction{Content: github.String(content), User: &github.User{ID: github.Int64(id), Type: github.String(kind)}}
+	}
+
+	data := pollData{Question: "Which release name?", Options: []string{"Argon", "Boron", "Carbon"}}
+	votes := data.tally([]*github.Reaction{
+		reaction("+1", 1, "Bot"),
+		reaction("-1", 1, "Bot"),
+		reaction("laugh", 1, "Bot"),
+		reaction("+1", 2, "User"),
+		reaction("-1", 3, "User"),
+		reaction("+1", 3, "User"),
+		reaction("heart", 4, "User"),
+		reaction("laugh", 4, "User"),
+		reaction("rocket", 5, "User"),
+	})
+	assert.Equal(t, []int{1, 1, 1}, votes)
+}
+
+func TestPoll_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		PollQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestPollFeature(t *testing.T) {
+	events := PollQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"poll": &PollQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("poll && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
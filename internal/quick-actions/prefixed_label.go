@@ -0,0 +1,204 @@
+package quick_actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/thoas/go-funk"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+type (
+	// PrefixedLabelQuickAction implements QuickAction interface for label
+	// family commands, like /kind, /priority or /area.
+	// This quick action adds one or several labels prefixed by the family
+	// name (`/priority high` adds `priority/high`). If the family is
+	// exclusive, all other labels of the same family are removed.
+	PrefixedLabelQuickAction struct {
+		LabelQuickAction
+
+		// Prefix is the label family name, added before all labels.
+		Prefix string
+		// Exclusive allows only one label of the family at a time.
+		Exclusive bool
+	}
+	// RemovePrefixedLabelQuickAction implements QuickAction interface for
+	// /remove-<family> commands.
+	// This quick action removes the specified labels of the family, or
+	// all of them if no label is given.
+	RemovePrefixedLabelQuickAction struct {
+		labelsHelper
+
+		// Prefix is the label family name, added before all labels.
+		Prefix string
+	}
+)
+
+func (qa PrefixedLabelQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa PrefixedLabelQuickAction) Metadata() Metadata {
+	if qa.Exclusive {
+		return Metadata{
+			Usage:       []string{fmt.Sprintf("/%s ~label", qa.Prefix)},
+			Description: fmt.Sprintf("Set the `%s` label.", qa.Prefix),
+			Details:     fmt.Sprintf("`/%[1]s high` adds the `%[1]s/high` label and removes all other `%[1]s/*` labels.", qa.Prefix),
+			Examples:    []string{fmt.Sprintf("/%s high", qa.Prefix)},
+		}
+	}
+	return Metadata{
+		Usage:       []string{fmt.Sprintf("/%s ~label [~label...]", qa.Prefix)},
+		Description: fmt.Sprintf("Add one or more labels prefixed by `%s/`.", qa.Prefix),
+		Details:     fmt.Sprintf("`/%[1]s bug` adds the `%[1]s/bug` label.", qa.Prefix),
+		Examples:    []string{fmt.Sprintf("/%s bug", qa.Prefix)},
+	}
+}
+
+func (qa PrefixedLabelQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", qa.Prefix).
+		Logger()
+
+	logger.Info().Msgf("handle `/%s` (args: %v)", qa.Prefix, command.Arguments)
+
+	labels := prefixLabels(qa.Prefix, qa.getLabels(command))
+	if len(labels) == 0 {
+		logger.Debug().Msgf("no labels found; ignored")
+		return nil
+	}
+	if qa.Exclusive && len(labelNames(labels)) > 1 {
+		return fmt.Errorf("only one `%s` label can be set at a time (got %v)", qa.Prefix, labelNames(labels))
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: like /label, missing labels are handled according to the
+	//		 label policy
+	policy := qa.policy(command)
+	logger.Debug().Msgf("check labels against the repository (policy: %s)", policy)
+
+	labels, err = qa.resolveLabels(ctx, client, command.Payload, policy, labels)
+	if err != nil {
+		return err
+	}
+
+	err = qa.addLabels(ctx, client, command.Payload, labels...)
+	if err != nil || !qa.Exclusive {
+		return err
+	}
+
+	var others []string
+	for _, label := range familyLabels(qa.Prefix, qa.getExistingLabels(command.Payload)) {
+		if !funk.ContainsString(labels, label) {
+			others = append(others, label)
+		}
+	}
+
+	if len(others) == 0 {
+		return nil
+	}
+
+	logger.Debug().Msgf("remove other `%s` labels: %v", qa.Prefix, others)
+	err = qa.removeLabels(ctx, client, command.Payload, others...)
+	if isNotFound(err) {
+		// NOTE: labels can be removed since the event has been sent
+		return nil
+	}
+	return err
+}
+
+func (qa RemovePrefixedLabelQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa RemovePrefixedLabelQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{fmt.Sprintf("/remove-%s [~label...]", qa.Prefix)},
+		Description: fmt.Sprintf("Remove specified `%s/*` labels, or all of them.", qa.Prefix),
+		Examples:    []string{fmt.Sprintf("/remove-%s", qa.Prefix)},
+	}
+}
+
+func (qa RemovePrefixedLabelQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "remove-"+qa.Prefix).
+		Logger()
+
+	logger.Info().Msgf("handle `/remove-%s` (args: %v)", qa.Prefix, command.Arguments)
+
+	labels := prefixLabels(qa.Prefix, qa.getLabels(command))
+	if len(command.Arguments) == 0 {
+		// NOTE: if no argument are used, remove the whole family
+		labels = familyLabels(qa.Prefix, qa.getExistingLabels(command.Payload))
+	}
+
+	if len(labels) == 0 {
+		logger.Debug().Msgf("no labels found; ignored")
+		return nil
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	err = qa.removeLabels(ctx, client, command.Payload, labels...)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// prefixLabels adds the family prefix to all given labels, if not already
+// prefixed.
+func prefixLabels(prefix string, labels []string) []string {
+	var prefixed []string
+	for _, label := range labels {
+		if !strings.HasPrefix(label, prefix+"/") {
+			label = prefix + "/" + label
+		}
+		prefixed = append(prefixed, label)
+	}
+	return funk.UniqString(prefixed)
+}
+
+// labelNames returns the names of the given labels, without their optional
+// color and description.
+func labelNames(labels []string) []string {
+	var names []string
+	for _, label := range labels {
+		names = append(names, parseLabelSpec(label).Name)
+	}
+	return funk.UniqString(names)
+}
+
+// familyLabels returns all labels that belong to the given family.
+func familyLabels(prefix string, labels []string) []string {
+	var family []string
+	for _, label := range labels {
+		if strings.HasPrefix(label, prefix+"/") {
+			family = append(family, label)
+		}
+	}
+	return family
+}
+
+// registerPrefixedLabelQuickActions registers both /<command> and
+// /remove-<command> quick actions for the given label family.
+func registerPrefixedLabelQuickActions(command, prefix string, exclusive bool) {
+	registerQuickAction(command, &PrefixedLabelQuickAction{Prefix: prefix, Exclusive: exclusive})
+	registerQuickAction("remove-"+command, &RemovePrefixedLabelQuickAction{Prefix: prefix})
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerPrefixedLabelQuickActions("kind", "kind", false)
+	registerPrefixedLabelQuickActions("priority", "priority", true)
+	registerPrefixedLabelQuickActions("area", "area", false)
+}
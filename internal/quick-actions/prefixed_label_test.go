@@ -0,0 +1,103 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestPrefixLabels(t *testing.T) {
+	ts := map[string]struct {
+		labels   []string
+		prefixed []string
+	}{
+		"simple label":         {labels: []string{"high"}, prefixed: []string{"priority/high"}},
+		"already prefixed":     {labels: []string{"priority/high"}, prefixed: []string{"priority/high"}},
+		"mixed labels":         {labels: []string{"high", "priority/low"}, prefixed: []string{"priority/high", "priority/low"}},
+		"duplicated labels":    {labels: []string{"high", "priority/high"}, prefixed: []string{"priority/high"}},
+		"other family prefix":  {labels: []string{"kind/bug"}, prefixed: []string{"priority/kind/bug"}},
+		"no labels":            {labels: nil, prefixed: []string{}},
+		"prefix without slash": {labels: []string{"priorityhigh"}, prefixed: []string{"priority/priorityhigh"}},
+	}
+
+	for name, tc := range ts {
+		t.Run(name, func(t *testing.T) {
+			assert.ElementsMatch(t, tc.prefixed, prefixLabels("priority", tc.labels))
+		})
+	}
+}
+
+func TestFamilyLabels(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]string{"priority/high", "priority/low"},
+		familyLabels("priority", []string{"kind/bug", "priority/high", "priority", "priority/low", "area/priority"}),
+	)
+}
+
+func TestPrefixedLabel_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment},
+		PrefixedLabelQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestPrefixedLabelFeature(t *testing.T) {
+	events := PrefixedLabelQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{
+					"priority": &PrefixedLabelQuickAction{Prefix: "priority", Exclusive: true},
+					"area":     &PrefixedLabelQuickAction{Prefix: "area"},
+				}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("prefixed_label && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestRemovePrefixedLabel_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		RemovePrefixedLabelQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestRemovePrefixedLabelFeature(t *testing.T) {
+	events := RemovePrefixedLabelQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{
+					"remove-priority": &RemovePrefixedLabelQuickAction{Prefix: "priority"},
+				}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("remove_prefixed_label && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
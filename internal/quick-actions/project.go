@@ -0,0 +1,197 @@
+package quick_actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+type (
+	// ProjectQuickAction implements QuickAction interface for /project command.
+	// This quick action adds the issue (or pull request) to a Projects v2
+	// board and updates its fields, like
+	// `/project "Roadmap" status="In progress" iteration=@current`.
+	ProjectQuickAction struct{ projectsHelper }
+
+	// projectFieldAssignment represents a field value given to /project.
+	projectFieldAssignment struct {
+		field string
+		value string
+	}
+)
+
+func (qa ProjectQuickAction) TriggerOnEvents() []EventType {
+	// NOTE: project should be triggered on issues & pull requests description too
+	return []EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment}
+}
+
+func (qa ProjectQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa ProjectQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/project <title> [<field>=<value> ...]"},
+		Description: "Add the issue or pull request to a project (Projects v2) of the repository owner and set its fields by name.",
+		Details:     "Only text, date, single select and iteration (by title, `@current` or `@next`) fields can be set.",
+		Examples:    []string{`/project "Roadmap" status="In progress" iteration=@current`},
+	}
+}
+
+func (qa ProjectQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "project").
+		Logger()
+
+	logger.Info().Msgf("handle `/project` (args: %v)", command.Arguments)
+
+	if len(command.Arguments) == 0 {
+		return fmt.Errorf("/project requires a project title (like `/project \"Roadmap\" status=\"In progress\"`)")
+	}
+
+	title := command.Arguments[0]
+	var assignments []projectFieldAssignment
+	for _, arg := range command.Arguments[1:] {
+		idx := strings.Index(arg, "=")
+		if idx <= 0 {
+			return fmt.Errorf("invalid field assignment '%s' for /project (expected `field=value`)", arg)
+		}
+		assignments = append(assignments, projectFieldAssignment{field: arg[:idx], value: arg[idx+1:]})
+	}
+
+	client, err := ctx.NewGraphQLClient(command.Payload)
+	if err != nil {
+		return err
+	}
+
+	project, err := qa.findProject(ctx, client, command.Payload.RepositoryOwner(), title)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: all values are resolved before updating the project in order to
+	//		 avoid partial updates
+	now := qa.getEventDate(command.Payload)
+	fields := make([]projectItemField, len(assignments))
+	values := make([]projectV2FieldValue, len(assignments))
+	for i, assignment := range assignments {
+		field, err := project.field(assignment.field)
+		if err != nil {
+			return err
+		}
+
+		values[i], err = field.parseValue(assignment.value, now)
+		if err != nil {
+			return err
+		}
+		fields[i] = projectItemField{ProjectID: project.ID, FieldID: field.Common.ID}
+	}
+
+	itemID, err := qa.addProjectItem(ctx, client, project.ID, qa.getIssueNodeID(command.Payload))
+	if err != nil {
+		return err
+	}
+
+	for i := range fields {
+		fields[i].ItemID = itemID
+		if err := qa.setProjectItemValue(ctx, client, fields[i], values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// field returns the project field with the given name (case-insensitive).
+func (project projectV2) field(name string) (*projectV2Field, error) {
+	var names []string
+	for _, field := range project.Fields.Nodes {
+		if strings.EqualFold(field.Common.Name, name) {
+			field := field
+			return &field, nil
+		}
+		names = append(names, field.Common.Name)
+	}
+	return nil, fmt.Errorf("unknown field '%s' in project '%s' (available fields: %s)", name, project.Title, strings.Join(names, ", "))
+}
+
+// parseValue converts the given value into a field value, depending on the
+// field type. Iteration fields accept `@current` and `@next` in addition to
+// iteration titles.
+func (field projectV2Field) parseValue(value string, now time.Time) (projectV2FieldValue, error) {
+	switch field.Common.DataType {
+	case "TEXT":
+		return projectV2FieldValue{Text: githubv4.NewString(githubv4.String(value))}, nil
+
+	case "DATE":
+		date, valid := ParseDate(value)
+		if !valid {
+			return projectV2FieldValue{}, fmt.Errorf("invalid date '%s' for field '%s' (expected `YYYY-MM-DD`, `tomorrow` or `in <n> days|weeks|months|years`)", value, field.Common.Name)
+		}
+		return projectV2FieldValue{Date: githubv4.NewString(githubv4.String(date.Time(now).Format("2006-01-02")))}, nil
+
+	case "SINGLE_SELECT":
+		var names []string
+		for _, option := range field.SingleSelect.Options {
+			if strings.EqualFold(option.Name, value) {
+				return projectV2FieldValue{SingleSelectOptionID: githubv4.NewString(githubv4.String(option.ID))}, nil
+			}
+			names = append(names, option.Name)
+		}
+		return projectV2FieldValue{}, fmt.Errorf("unknown option '%s' for field '%s' (available options: %s)", value, field.Common.Name, strings.Join(names, ", "))
+
+	case "ITERATION":
+		iteration, err := field.iteration(value, now)
+		if err != nil {
+			return projectV2FieldValue{}, err
+		}
+		return projectV2FieldValue{IterationID: githubv4.NewString(githubv4.String(iteration.ID))}, nil
+
+	default:
+		return projectV2FieldValue{}, fmt.Errorf("field '%s' cannot be set by /project (unsupported type %s)", field.Common.Name, strings.ToLower(field.Common.DataType))
+	}
+}
+
+// iteration returns the iteration matching the given value.
+func (field projectV2Field) iteration(value string, now time.Time) (*projectV2Iteration, error) {
+	iterations := append([]projectV2Iteration{}, field.Iteration.Configuration.Iterations...)
+	sort.Slice(iterations, func(i, j int) bool { return iterations[i].StartDate < iterations[j].StartDate })
+
+	switch value {
+	case "@current", "@next":
+		for i, iteration := range iterations {
+			start, err := time.Parse("2006-01-02", iteration.StartDate)
+			if err != nil {
+				return nil, fmt.Errorf("invalid iteration '%s' for field '%s': %w", iteration.Title, field.Common.Name, err)
+			}
+
+			switch {
+			case value == "@current" && !now.Before(start) && now.Before(start.AddDate(0, 0, iteration.Duration)):
+				return &iterations[i], nil
+			case value == "@next" && now.Before(start):
+				return &iterations[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no %s iteration for field '%s'", value[1:], field.Common.Name)
+
+	default:
+		var titles []string
+		for i, iteration := range iterations {
+			if strings.EqualFold(iteration.Title, value) {
+				return &iterations[i], nil
+			}
+			titles = append(titles, iteration.Title)
+		}
+		return nil, fmt.Errorf("unknown iteration '%s' for field '%s' (available iterations: %s)", value, field.Common.Name, strings.Join(titles, ", "))
+	}
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("project", &ProjectQuickAction{})
+}
@@ -0,0 +1,80 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestProjectV2Field_iteration(t *testing.T) {
+	field := projectV2Field{}
+	field.Common.Name = "Iteration"
+	field.Iteration.Configuration.Iterations = []projectV2Iteration{
+		{ID: "c7a2", Title: "Iteration 2", StartDate: "2026-10-26", Duration: 14},
+		{ID: "c7a1", Title: "Iteration 1", StartDate: "2026-10-12", Duration: 14},
+	}
+
+	ts := map[string]struct {
+		value string
+		now   time.Time
+		id    string
+		err   error
+	}{
+		"current":             {value: "@current", now: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), id: "c7a1"},
+		"current (first day)": {value: "@current", now: time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC), id: "c7a2"},
+		"next":                {value: "@next", now: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), id: "c7a2"},
+		"by title":            {value: "iteration 2", now: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), id: "c7a2"},
+		"no current":          {value: "@current", now: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), err: fmt.Errorf("no current iteration for field 'Iteration'")},
+		"no next":             {value: "@next", now: time.Date(2026, 10, 27, 0, 0, 0, 0, time.UTC), err: fmt.Errorf("no next iteration for field 'Iteration'")},
+		"unknown":             {value: "Iteration 3", err: fmt.Errorf("unknown iteration 'Iteration 3' for field 'Iteration' (available iterations: Iteration 1, Iteration 2)")},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			iteration, err := field.iteration(tc.value, tc.now)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.id, iteration.ID)
+		})
+	}
+}
+
+func TestProject_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment},
+		ProjectQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestProjectFeature(t *testing.T) {
+	events := ProjectQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"project": &ProjectQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("project && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
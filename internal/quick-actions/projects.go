@@ -0,0 +1,238 @@
+package quick_actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+type (
+	// projectsHelper implements methods to manage Projects v2 items
+	// through the GraphQL API.
+	projectsHelper struct{ githubEventHelper }
+
+	// projectItemField represents a field of a project item, where the
+	// item is the issue (or pull request) where the event comes from.
+	projectItemField struct {
+		ProjectID githubv4.ID
+		ItemID    githubv4.ID
+		FieldID   githubv4.ID
+	}
+
+	// projectV2FieldValue represents the value of a project item field;
+	// only one value must be set, depending on the field type.
+	projectV2FieldValue struct {
+		Text                 *githubv4.String `json:"text,omitempty"`
+		Date                 *githubv4.String `json:"date,omitempty"`
+		SingleSelectOptionID *githubv4.String `json:"singleSelectOptionId,omitempty"`
+		IterationID          *githubv4.String `json:"iterationId,omitempty"`
+	}
+
+	// projectV2 represents a Projects v2 board with its fields.
+	projectV2 struct {
+		ID     githubv4.ID
+		Title  string
+		Fields struct {
+			Nodes []projectV2Field
+		} `graphql:"fields(first: 50)"`
+	}
+
+	// projectV2Field represents any kind of Projects v2 field; fragments
+	// are filled depending on the field type.
+	projectV2Field struct {
+		Common struct {
+			ID       githubv4.ID
+			Name     string
+			DataType string
+		} `graphql:"... on ProjectV2FieldCommon"`
+		SingleSelect struct {
+			Options []struct {
+				ID   string
+				Name string
+			}
+		} `graphql:"... on ProjectV2SingleSelectField"`
+		Iteration struct {
+			Configuration struct {
+				Iterations []projectV2Iteration
+			}
+		} `graphql:"... on ProjectV2IterationField"`
+	}
+
+	// projectV2Iteration represents an iteration of a Projects v2 iteration
+	// field.
+	projectV2Iteration struct {
+		ID        string
+		Title     string
+		StartDate string
+		Duration  int
+	}
+
+	// projectV2Items represents the Projects v2 items of an issue or a pull
+	// request, with the given field of their project.
+	projectV2Items struct {
+		Nodes []struct {
+			ID      githubv4.ID
+			Project struct {
+				ID    githubv4.ID
+				Field struct {
+					ProjectV2Field struct {
+						ID githubv4.ID
+					} `graphql:"... on ProjectV2Field"`
+				} `graphql:"field(name: $field)"`
+			}
+		}
+	}
+)
+
+// findProject returns the Projects v2 board of the given owner (user or
+// organization) with the given title.
+func (projectsHelper) findProject(ctx *EventContext, client *githubv4.Client, owner, title string) (*projectV2, error) {
+	var query struct {
+		RepositoryOwner struct {
+			ProjectV2Owner struct {
+				ProjectsV2 struct {
+					Nodes []projectV2
+				} `graphql:"projectsV2(first: 20, query: $title)"`
+			} `graphql:"... on ProjectV2Owner"`
+		} `graphql:"repositoryOwner(login: $owner)"`
+	}
+
+	err := client.Query(ctx, &query, map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"title": githubv4.String(title),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: the query is a fuzzy search; only the exact title is accepted
+	for _, project := range query.RepositoryOwner.ProjectV2Owner.ProjectsV2.Nodes {
+		if strings.EqualFold(project.Title, title) {
+			project := project
+			return &project, nil
+		}
+	}
+	return nil, fmt.Errorf("project '%s' not found for '%s'", title, owner)
+}
+
+// addProjectItem adds the given issue or pull request (content) to the given
+// project and returns the project item ID (existing items are returned as is).
+func (projectsHelper) addProjectItem(ctx *EventContext, client *githubv4.Client, projectID githubv4.ID, contentID string) (githubv4.ID, error) {
+	var mutation struct {
+		AddProjectV2ItemByID struct {
+			Item struct{ ID githubv4.ID }
+		} `graphql:"addProjectV2ItemById(input: $input)"`
+	}
+
+	// NOTE: same as setProjectItemValue, githubv4 doesn't manage this type yet
+	type AddProjectV2ItemByIdInput struct {
+		ProjectID githubv4.ID `json:"projectId"`
+		ContentID githubv4.ID `json:"contentId"`
+	}
+
+	input := AddProjectV2ItemByIdInput{ProjectID: projectID, ContentID: contentID}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return nil, err
+	}
+	return mutation.AddProjectV2ItemByID.Item.ID, nil
+}
+
+// getProjectItemFields returns, for each project containing the issue (or
+// pull request) where the event comes from, the item field with the given
+// name. Projects without such field are ignored.
+func (qa projectsHelper) getProjectItemFields(ctx *EventContext, client *githubv4.Client, payload EventPayload, field string) ([]projectItemField, error) {
+	var query struct {
+		Repository struct {
+			IssueOrPullRequest struct {
+				Issue struct {
+					ProjectItems projectV2Items `graphql:"projectItems(first: 20)"`
+				} `graphql:"... on Issue"`
+				PullRequest struct {
+					ProjectItems projectV2Items `graphql:"projectItems(first: 20)"`
+				} `graphql:"... on PullRequest"`
+			} `graphql:"issueOrPullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	err := client.Query(ctx, &query, map[string]interface{}{
+		"owner":  githubv4.String(payload.RepositoryOwner()),
+		"name":   githubv4.String(payload.RepositoryName()),
+		"number": githubv4.Int(payload.IssueNumber()),
+		"field":  githubv4.String(field),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := query.Repository.IssueOrPullRequest.Issue.ProjectItems
+	if qa.isPullRequest(payload) {
+		items = query.Repository.IssueOrPullRequest.PullRequest.ProjectItems
+	}
+
+	var fields []projectItemField
+	for _, item := range items.Nodes {
+		if item.Project.Field.ProjectV2Field.ID == nil {
+			continue
+		}
+
+		fields = append(fields, projectItemField{
+			ProjectID: item.Project.ID,
+			ItemID:    item.ID,
+			FieldID:   item.Project.Field.ProjectV2Field.ID,
+		})
+	}
+	return fields, nil
+}
+
+// setProjectItemValue sets the given value on the given project item field.
+func (projectsHelper) setProjectItemValue(ctx *EventContext, client *githubv4.Client, field projectItemField, value projectV2FieldValue) error {
+	var mutation struct {
+		UpdateProjectV2ItemFieldValue struct {
+			ProjectV2Item struct{ ID githubv4.ID } `graphql:"projectV2Item"`
+		} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+	}
+
+	// NOTE: githubv4 doesn't manage Projects v2 yet; this local type keeps
+	//		 the same GraphQL type name (required by githubv4 to generate
+	//		 the mutation).
+	type UpdateProjectV2ItemFieldValueInput struct {
+		ProjectID githubv4.ID         `json:"projectId"`
+		ItemID    githubv4.ID         `json:"itemId"`
+		FieldID   githubv4.ID         `json:"fieldId"`
+		Value     projectV2FieldValue `json:"value"`
+	}
+
+	input := UpdateProjectV2ItemFieldValueInput{
+		ProjectID: field.ProjectID,
+		ItemID:    field.ItemID,
+		FieldID:   field.FieldID,
+		Value:     value,
+	}
+	return client.Mutate(ctx, &mutation, input, nil)
+}
+
+// clearProjectItemField clears the value of the given project item field.
+func (projectsHelper) clearProjectItemField(ctx *EventContext, client *githubv4.Client, field projectItemField) error {
+	var mutation struct {
+		ClearProjectV2ItemFieldValue struct {
+			ProjectV2Item struct{ ID githubv4.ID } `graphql:"projectV2Item"`
+		} `graphql:"clearProjectV2ItemFieldValue(input: $input)"`
+	}
+
+	// NOTE: same as setProjectItemValue, githubv4 doesn't manage this type yet
+	type ClearProjectV2ItemFieldValueInput struct {
+		ProjectID githubv4.ID `json:"projectId"`
+		ItemID    githubv4.ID `json:"itemId"`
+		FieldID   githubv4.ID `json:"fieldId"`
+	}
+
+	input := ClearProjectV2ItemFieldValueInput{
+		ProjectID: field.ProjectID,
+		ItemID:    field.ItemID,
+		FieldID:   field.FieldID,
+	}
+	return client.Mutate(ctx, &mutation, input, nil)
+}
@@ -0,0 +1,89 @@
+package quick_actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+type (
+	// QuickActionsQuickAction implements QuickAction interface for
+	// /quick_actions command.
+	// This quick action replies with the configuration used on the
+	// repository, once merged with the organization one.
+	QuickActionsQuickAction struct{ githubEventHelper }
+)
+
+func (qa QuickActionsQuickAction) TriggerOnEvents() []EventType {
+	return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
+}
+
+func (qa QuickActionsQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "subcommand", Kind: ArgumentKindText, Required: true}}
+}
+
+// NOTE: the configuration can contain settings inherited from the
+//		 organization, which must not be disclosed to everyone
+func (qa QuickActionsQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa QuickActionsQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/quick_actions config"},
+		Description: "Show the quick actions configuration used on this repository.",
+		Details:     "The configuration is the organization one (`.github` repository) merged with the repository one.",
+		Examples:    []string{"/quick_actions config"},
+	}
+}
+
+func (qa QuickActionsQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "quick_actions").
+		Logger()
+
+	logger.Info().Msgf("handle `/quick_actions` (args: %v)", command.Arguments)
+
+	subcommand, _ := command.Values.Get("subcommand")
+	if subcommand.Value != "config" {
+		return fmt.Errorf("unknown subcommand '%s' (expected `/quick_actions config`)", subcommand.Value)
+	}
+
+	body, err := repositoryConfigSummary(ctx.Config)
+	if err != nil {
+		return err
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+	return qa.createComment(ctx, client, command.Payload, body)
+}
+
+// repositoryConfigSummary generates the Markdown description of the given
+// configuration and of the files it comes from.
+func repositoryConfigSummary(config *RepositoryConfig) (string, error) {
+	var summary strings.Builder
+
+	summary.WriteString("#### Quick actions configuration\n\n")
+	if config == nil || len(config.Sources) == 0 {
+		summary.WriteString("_No configuration file found, the default configuration is used._")
+		return summary.String(), nil
+	}
+
+	content, err := config.YAML()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	fmt.Fprintf(&summary, "**Sources:** %s\n\n", codeList(config.Sources, ", "))
+	fmt.Fprintf(&summary, "```yaml\n%s```", content)
+	return summary.String(), nil
+}
+
+func init() {
+	registerQuickAction("quick_actions", &QuickActionsQuickAction{})
+}
@@ -0,0 +1,64 @@
+package quick_actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+)
+
+func TestQuickActionsQuickAction_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		QuickActionsQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestQuickActionsQuickAction_Restriction(t *testing.T) {
+	assert.Equal(t, Restriction{Permission: PermissionTriage}, QuickActionsQuickAction{}.Restriction())
+}
+
+func TestRepositoryConfigSummary(t *testing.T) {
+	summary, err := repositoryConfigSummary(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "#### Quick actions configuration\n\n_No configuration file found, the default configuration is used._", summary)
+
+	summary, err = repositoryConfigSummary(&RepositoryConfig{
+		Disabled: []string{"poll"},
+		Aliases:  map[string]string{"tag": "label"},
+		Sources:  []string{"xunleii/.github:quick-actions.yml", ".github/quick-actions.yml"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "#### Quick actions configuration\n\n"+
+		"**Sources:** `xunleii/.github:quick-actions.yml`, `.github/quick-actions.yml`\n\n"+
+		"```yaml\ndisabled:\n  - poll\naliases:\n  tag: label\n```",
+		summary,
+	)
+}
+
+func TestQuickActionsFeature(t *testing.T) {
+	events := QuickActionsQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"quick_actions": &QuickActionsQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("quick_actions && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
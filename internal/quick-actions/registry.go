@@ -4,8 +4,16 @@ import (
 	v2 "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
 )
 
-// registry is a shared registry containing all default Github quick actions
-var registry = map[string]v2.QuickAction{}
+var (
+	// registry is a shared registry containing all default Github quick actions
+	registry = map[string]v2.QuickAction{}
+	// handlersRegistry is a shared registry containing all default Github event handlers
+	handlersRegistry = map[string]v2.EventHandler{}
+	// sweepersRegistry is a shared registry containing all default sweepers
+	sweepersRegistry = map[string]v2.Sweeper{}
+	// jobRunnersRegistry is a shared registry containing all default job runners
+	jobRunnersRegistry = map[string]v2.JobRunner{}
+)
 
 // registerQuickAction add quick action to the internal registry.
 // NOTE: this is for internal use only
@@ -13,10 +21,40 @@ func registerQuickAction(command string, quickAction v2.QuickAction) {
 	registry[command] = quickAction
 }
 
-// InjectAll adds all defined Github quick actions to
-// the given GithubQuickActions instance.
+// registerEventHandler add event handler to the internal registry.
+// NOTE: this is for internal use only
+func registerEventHandler(name string, eventHandler v2.EventHandler) {
+	handlersRegistry[name] = eventHandler
+}
+
+// registerSweeper add sweeper to the internal registry.
+// NOTE: this is for internal use only
+func registerSweeper(name string, sweeper v2.Sweeper) {
+	sweepersRegistry[name] = sweeper
+}
+
+// registerJobRunner add job runner to the internal registry.
+// NOTE: this is for internal use only
+func registerJobRunner(kind string, runner v2.JobRunner) {
+	jobRunnersRegistry[kind] = runner
+}
+
+// InjectAll adds all defined Github quick actions, event handlers, sweepers
+// and job runners to the given GithubQuickActions instance.
 func InjectAll(gh *v2.GithubQuickActions) {
 	for command, action := range registry {
 		gh.AddQuickAction(command, action)
 	}
+	// NOTE: /help is registered apart because it lists the quick actions
+	//		 of the given instance
+	gh.AddQuickAction("help", &HelpQuickAction{QuickActions: gh})
+	for name, handler := range handlersRegistry {
+		gh.AddEventHandler(name, handler)
+	}
+	for name, sweeper := range sweepersRegistry {
+		gh.AddSweeper(name, sweeper)
+	}
+	for kind, runner := range jobRunnersRegistry {
+		gh.AddJobRunner(kind, runner)
+	}
 }
@@ -0,0 +1,147 @@
+package quick_actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/rs/zerolog"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	"xnku.be/github-quick-actions/pkg/scheduler"
+)
+
+// remindJobKind is the kind of the jobs scheduled by /remind.
+const remindJobKind = "remind"
+
+type (
+	// RemindQuickAction implements QuickAction interface for /remind command.
+	// This quick action schedules a reminder, like
+	// `/remind me "check the release" in 3 days`.
+	// It also implements JobRunner in order to post the reminder once due.
+	RemindQuickAction struct{ githubEventHelper }
+
+	// remindData contains the reminder stored in the scheduled job.
+	remindData struct {
+		Owner  string `json:"owner"`
+		Repo   string `json:"repo"`
+		Number int    `json:"number"`
+		// User is the login of the user to remind.
+		User string `json:"user"`
+		// Author is the login of the user who scheduled the reminder.
+		Author string `json:"author"`
+		Text   string `json:"text"`
+	}
+)
+
+func (qa RemindQuickAction) TriggerOnEvents() []EventType {
+	// NOTE: remind should be triggered on issues & pull requests description too
+	return []EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment}
+}
+
+func (qa RemindQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{
+		{Name: "user", Kind: ArgumentKindUser, Required: true},
+		{Name: "message", Kind: ArgumentKindText, Required: true},
+		{Name: "date", Kind: ArgumentKindDate, Required: true},
+	}
+}
+
+func (qa RemindQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/remind me|@user <message> in <n> <unit>", "/remind me|@user <message> on <date>"},
+		Description: "Post a comment mentioning you (or the given user) with the message at the given date.",
+		Details:     "Valid units are `minutes`, `hours`, `days`, `weeks`, `months` and `years` (or `m`, `h`, `d`, `w`, `mo` and `y`, like `/remind me review 2d`); reminders require a store (see `GQA_STORE_PATH`).",
+		Examples:    []string{`/remind me "check the release" in 3 days`, `/remind @octocat "check the release" on 2026-11-01`},
+	}
+}
+
+func (qa RemindQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "remind").
+		Logger()
+
+	logger.Info().Msgf("handle `/remind` (args: %v)", command.Arguments)
+
+	if ctx.Store == nil {
+		return fmt.Errorf("/remind is disabled: no store configured")
+	}
+
+	author := qa.getSender(command.Payload)
+	user, _ := command.Values.Get("user")
+	if user.Value == "me" {
+		user.Value = author
+	}
+
+	text, _ := command.Values.Get("message")
+	date, _ := command.Values.Get("date")
+	from := qa.getEventDate(command.Payload)
+	dueAt := date.Time(from)
+	if !dueAt.After(from) {
+		return fmt.Errorf("reminder date %s is in the past", dueAt.Format("2006-01-02"))
+	}
+
+	data, err := json.Marshal(remindData{
+		Owner:  command.Payload.RepositoryOwner(),
+		Repo:   command.Payload.RepositoryName(),
+		Number: command.Payload.IssueNumber(),
+		User:   user.Value,
+		Author: author,
+		Text:   text.Value,
+	})
+	if err != nil {
+		return err
+	}
+
+	job := &scheduler.Job{Kind: remindJobKind, InstallationID: qa.getInstallationID(command.Payload), DueAt: dueAt, Data: data}
+	if err := ctx.Store.Schedule(ctx, job); err != nil {
+		return err
+	}
+
+	logger.Debug().Msgf("reminder %s scheduled for @%s on %s", job.ID, user.Value, dueAt.Format(time.RFC3339))
+	return nil
+}
+
+// RunJob implements the JobRunner interface by posting the reminder.
+func (qa RemindQuickAction) RunJob(ctx *EventContext, job *scheduler.Job) error {
+	client, err := ctx.NewInstallationClient(job.InstallationID)
+	if err != nil {
+		return err
+	}
+	return qa.remind(ctx, client, job)
+}
+
+// remind posts the reminder contained in the given job.
+func (qa RemindQuickAction) remind(ctx *EventContext, client *github.Client, job *scheduler.Job) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("job_runner", remindJobKind).
+		Logger()
+
+	data := remindData{}
+	if err := json.Unmarshal(job.Data, &data); err != nil {
+		return fmt.Errorf("invalid reminder %s: %w", job.ID, err)
+	}
+
+	body := fmt.Sprintf("@%s reminder: %s", data.User, data.Text)
+	if data.Author != "" && data.Author != data.User {
+		body = fmt.Sprintf("@%s reminder from @%s: %s", data.User, data.Author, data.Text)
+	}
+
+	_, _, err := client.Issues.CreateComment(ctx, data.Owner, data.Repo, data.Number, &github.IssueComment{Body: github.String(body)})
+	if isNotFound(err) {
+		// NOTE: the issue (or the repository) has been removed; the
+		//		 reminder is dropped instead of being retried forever
+		logger.Warn().Msgf("%s/%s#%d not found, reminder %s dropped", data.Owner, data.Repo, data.Number, job.ID)
+		return nil
+	}
+	return err
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("remind", &RemindQuickAction{})
+
+	// NOTE: register job runners
+	registerJobRunner(remindJobKind, &RemindQuickAction{})
+}
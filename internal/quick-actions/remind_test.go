@@ -0,0 +1,152 @@
+package quick_actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+	gqa_httptest "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx/httptest"
+	"xnku.be/github-quick-actions/pkg/scheduler"
+)
+
+func TestRemindQuickAction_Arguments(t *testing.T) {
+	from := time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC)
+	usage := "; usage: `/remind @user <message> <date>`"
+	ts := map[string]struct {
+		args []string
+		text string
+		date time.Time
+		err  error
+	}{
+		"in minutes":       {args: []string{"check", "in", "30", "minutes"}, text: "check", date: from.Add(30 * time.Minute)},
+		"in one hour":      {args: []string{"check", "in", "1", "hour"}, text: "check", date: from.Add(time.Hour)},
+		"in days":          {args: []string{"check the release", "in", "3", "days"}, text: "check the release", date: from.AddDate(0, 0, 3)},
+		"in weeks":         {args: []string{"check", "in", "2", "weeks"}, text: "check", date: from.AddDate(0, 0, 14)},
+		"in months":        {args: []string{"check", "in", "1", "month"}, text: "check", date: from.AddDate(0, 1, 0)},
+		"in years":         {args: []string{"check", "in", "1", "year"}, text: "check", date: from.AddDate(1, 0, 0)},
+		"on date":          {args: []string{"check", "On", "2026-11-01"}, text: "check", date: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		"unquoted message": {args: []string{"check", "in", "prod", "on", "2026-11-01"}, text: "check in prod", date: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		"zero delay":       {args: []string{"check", "in", "0", "days"}, err: fmt.Errorf("missing argument `<date>`" + usage)},
+		"invalid unit":     {args: []string{"check", "in", "2", "seconds"}, err: fmt.Errorf("missing argument `<date>`" + usage)},
+		"no message":       {args: []string{"in", "2", "days"}, err: fmt.Errorf("missing argument `<date>`" + usage)},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			values, err := ParseArguments("remind", RemindQuickAction{}.Arguments(), append([]string{"me"}, tc.args...), nil)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			text, _ := values.Get("message")
+			date, _ := values.Get("date")
+			assert.Equal(t, tc.text, text.Value)
+			assert.Equal(t, tc.date, date.Time(from))
+		})
+	}
+}
+
+func TestRemindQuickAction_HandleCommand(t *testing.T) {
+	payload, err := PayloadFactory(EventTypeIssueComment, []byte(`{
+		"action": "created",
+		"comment": { "body": "/remind @octocat review in 3 days", "created_at": "2026-10-19T10:00:00Z" },
+		"repository": { "owner": { "login": "xunleii" }, "name": "github-quick-actions" },
+		"issue": { "number": 1 },
+		"sender": { "login": "xunleii" },
+		"installation": { "id": 123456789 }
+	}`))
+	require.NoError(t, err)
+
+	store := scheduler.NewMemoryStore()
+	ctx := &EventContext{Context: context.TODO(), Store: store}
+	command := &EventCommand{Command: "remind", Arguments: []string{"@octocat", "review", "in", "3", "days"}, Payload: payload}
+	require.NoError(t, ValidateArguments(RemindQuickAction{}, command))
+	require.NoError(t, RemindQuickAction{}.HandleCommand(ctx, command))
+
+	jobs, err := store.Due(context.TODO(), time.Date(2026, 10, 22, 10, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, remindJobKind, jobs[0].Kind)
+	assert.Equal(t, int64(123456789), jobs[0].InstallationID)
+	assert.Equal(t, time.Date(2026, 10, 22, 10, 0, 0, 0, time.UTC), jobs[0].DueAt)
+	assert.JSONEq(t, `{"owner":"xunleii","repo":"github-quick-actions","number":1,"user":"octocat","author":"xunleii","text":"review"}`, string(jobs[0].Data))
+
+	err = RemindQuickAction{}.HandleCommand(&EventContext{Context: context.TODO()}, command)
+	assert.EqualError(t, err, "/remind is disabled: no store configured")
+}
+
+func TestRemindQuickAction_remind(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/repos/xunleii/removed/issues/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	srv := gqa_httptest.NewServer(mux)
+	client := github.NewClient(srv.Client())
+	ctx := &EventContext{Context: context.TODO()}
+
+	jobs := []remindData{
+		{Owner: "xunleii", Repo: "github-quick-actions", Number: 1, User: "xunleii", Author: "xunleii", Text: "check the release"},
+		{Owner: "xunleii", Repo: "github-quick-actions", Number: 2, User: "octocat", Author: "xunleii", Text: "review"},
+		{Owner: "xunleii", Repo: "removed", Number: 3, User: "xunleii", Author: "xunleii", Text: "dropped"},
+	}
+	for _, data := range jobs {
+		raw, _ := json.Marshal(data)
+		require.NoError(t, RemindQuickAction{}.remind(ctx, client, &scheduler.Job{Kind: remindJobKind, Data: raw}))
+	}
+
+	assert.Equal(t, []string{
+		"POST /repos/xunleii/github-quick-actions/issues/1/comments " + `{"body":"@xunleii reminder: check the release"}` + "\n",
+		"POST /repos/xunleii/github-quick-actions/issues/2/comments " + `{"body":"@octocat reminder from @xunleii: review"}` + "\n",
+	}, requests)
+}
+
+func TestRemind_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment},
+		RemindQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestRemindFeature(t *testing.T) {
+	events := RemindQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"remind": &RemindQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("remind && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
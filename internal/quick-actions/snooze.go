@@ -0,0 +1,292 @@
+package quick_actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/rs/zerolog"
+	"github.com/thoas/go-funk"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	"xnku.be/github-quick-actions/pkg/scheduler"
+)
+
+const (
+	// snoozedLabel is the label added on snoozed issues.
+	snoozedLabel = "snoozed"
+	// snoozeName is the name used to identify the snooze metadata and the
+	// wake-up jobs.
+	snoozeName = "snooze"
+)
+
+type (
+	// SnoozeQuickAction implements QuickAction interface for /snooze command.
+	// This quick action hides an issue from triage until a date, like
+	// `/snooze 2w waiting for upstream`, by replacing its triage labels by
+	// the `snoozed` label.
+	// It also implements EventHandler and JobRunner in order to wake the
+	// issue up when someone comments or when the date is reached.
+	SnoozeQuickAction struct{ labelsHelper }
+
+	// snoozeData contains the snooze stored in the issue description.
+	snoozeData struct {
+		Until string `json:"until"`
+		// Labels contains the triage labels removed by /snooze, restored
+		// when the issue wakes up.
+		Labels []string `json:"labels,omitempty"`
+		Reason string   `json:"reason,omitempty"`
+	}
+
+	// snoozeJobData contains the issue to wake up, stored in the scheduled
+	// job.
+	snoozeJobData struct {
+		Owner  string `json:"owner"`
+		Repo   string `json:"repo"`
+		Number int    `json:"number"`
+		Until  string `json:"until"`
+	}
+)
+
+var (
+	// triageLabelRegex matches "needs-triage" style labels, like
+	// `needs-triage`, `needs-information` or `triage/needs-repro`.
+	triageLabelRegex = regexp.MustCompile(`(?i)^(needs[-/].+|triage(/.+)?)$`)
+)
+
+func (qa SnoozeQuickAction) TriggerOnEvents() []EventType {
+	// NOTE: snooze is only available on issues comments
+	return []EventType{EventTypeIssueComment}
+}
+
+func (qa SnoozeQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{
+		{Name: "date", Kind: ArgumentKindDate, Required: true},
+		{Name: "reason", Kind: ArgumentKindText},
+	}
+}
+
+func (qa SnoozeQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa SnoozeQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/snooze <date> [reason]"},
+		Description: "Hide an issue from triage until a date. Examples of valid `<date>` include `12h`, `3d`, `2w`, `1mo`, `1y`, `in 2 weeks` and `2026-11-01`.",
+		Details:     "The triage labels (`needs-*`, `triage` and `triage/*`) are replaced by the `snoozed` label and restored when the date is reached or when anyone (except bots) comments; it requires a store (see `GQA_STORE_PATH`).",
+		Examples:    []string{"/snooze 2w waiting for upstream"},
+	}
+}
+
+func (qa SnoozeQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "snooze").
+		Logger()
+
+	logger.Info().Msgf("handle `/snooze` (args: %v)", command.Arguments)
+
+	if qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/snooze can only be used on issues; ignored")
+		return nil
+	}
+
+	if ctx.Store == nil {
+		return fmt.Errorf("/snooze is disabled: no store configured")
+	}
+
+	arg, _ := command.Values.Get("date")
+	from := qa.getEventDate(command.Payload)
+	until := arg.Time(from)
+	if !until.After(from) {
+		return fmt.Errorf("snooze date %s is in the past", until.Format("2006-01-02"))
+	}
+
+	var triageLabels []string
+	for _, label := range qa.getExistingLabels(command.Payload) {
+		if triageLabelRegex.MatchString(label) {
+			triageLabels = append(triageLabels, label)
+		}
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	ref := qa.getIssueReference(command.Payload)
+	data := &snoozeData{}
+	err = qa.updateIssueMetadata(ctx, client, ref, snoozeName, func(body string) (string, error) {
+		// NOTE: snoozing an already snoozed issue keeps the triage labels
+		//		 removed previously
+		if _, err := decodeMetadata(body, snoozeName, data); err != nil {
+			return "", err
+		}
+
+		data.Until = until.Format(time.RFC3339)
+		data.Labels = funk.UniqString(append(data.Labels, triageLabels...))
+		reason, _ := command.Values.Get("reason")
+		data.Reason = reason.Value
+		return encodeMetadata(snoozeName, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := qa.addIssueLabels(ctx, client, ref, snoozedLabel); err != nil {
+		return err
+	}
+	if len(triageLabels) > 0 {
+		if err := qa.removeIssueLabels(ctx, client, ref, triageLabels...); err != nil {
+			return err
+		}
+	}
+
+	raw, err := json.Marshal(snoozeJobData{Owner: ref.Owner, Repo: ref.Repo, Number: ref.Number, Until: data.Until})
+	if err != nil {
+		return err
+	}
+
+	job := &scheduler.Job{Kind: snoozeName, InstallationID: qa.getInstallationID(command.Payload), DueAt: until, Data: raw}
+	if err := ctx.Store.Schedule(ctx, job); err != nil {
+		return err
+	}
+
+	logger.Debug().Msgf("%s snoozed until %s", ref, data.Until)
+	return nil
+}
+
+func (qa SnoozeQuickAction) TriggerOnActions() map[EventType][]EventAction {
+	return map[EventType][]EventAction{EventTypeIssueComment: {EventActionCreated}}
+}
+func (qa SnoozeQuickAction) HandledCommand() (string, bool) {
+	return "snooze", false
+}
+func (qa SnoozeQuickAction) HandleEvent(ctx *EventContext, payload EventPayload) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("event_handler", "unsnooze").
+		Logger()
+
+	event, valid := payload.Raw().(*github.IssueCommentEvent)
+	if !valid {
+		return fmt.Errorf("invalid event type %T", payload.Raw())
+	}
+
+	if !funk.ContainsString(qa.getExistingLabels(payload), snoozedLabel) {
+		logger.Debug().Msgf("issue not snoozed; ignored")
+		return nil
+	}
+
+	// NOTE: comments from bots (like the application itself) must not wake
+	//		 the issue up
+	if event.GetSender().GetType() == "Bot" || event.GetComment().GetUser().GetType() == "Bot" {
+		logger.Debug().Msgf("comment written by a bot; ignored")
+		return nil
+	}
+
+	// NOTE: snoozing again a snoozed issue is managed by the quick action
+	for _, command := range ctx.Commands() {
+		if command.Command == "snooze" {
+			logger.Debug().Msgf("issue snoozed again; ignored")
+			return nil
+		}
+	}
+
+	client, err := qa.newInstallationClient(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	ref := qa.getIssueReference(payload)
+	issue, _, err := client.Issues.Get(ctx, ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		return fmt.Errorf("failed to find issue %s: %w", ref, err)
+	}
+
+	logger.Info().Msgf("wake up %s after a comment from @%s", ref, qa.getSender(payload))
+	return qa.wakeUp(ctx, client, ref, issue.GetBody())
+}
+
+// RunJob implements the JobRunner interface by waking the snoozed issue up.
+func (qa SnoozeQuickAction) RunJob(ctx *EventContext, job *scheduler.Job) error {
+	client, err := ctx.NewInstallationClient(job.InstallationID)
+	if err != nil {
+		return err
+	}
+	return qa.wakeUpJob(ctx, client, job)
+}
+
+// wakeUpJob wakes the issue contained in the given job up, if it is still
+// snoozed until the job date.
+func (qa SnoozeQuickAction) wakeUpJob(ctx *EventContext, client *github.Client, job *scheduler.Job) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("job_runner", snoozeName).
+		Logger()
+
+	jobData := snoozeJobData{}
+	if err := json.Unmarshal(job.Data, &jobData); err != nil {
+		return fmt.Errorf("invalid snooze %s: %w", job.ID, err)
+	}
+	ref := issueReference{Owner: jobData.Owner, Repo: jobData.Repo, Number: jobData.Number}
+
+	issue, _, err := client.Issues.Get(ctx, ref.Owner, ref.Repo, ref.Number)
+	if isNotFound(err) {
+		// NOTE: the issue (or the repository) has been removed; the job is
+		//		 dropped instead of being retried forever
+		logger.Warn().Msgf("%s not found, snooze %s dropped", ref, job.ID)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// NOTE: the issue can be woken up by a comment or snoozed again since
+	//		 the job has been scheduled
+	data := &snoozeData{}
+	found, err := decodeMetadata(issue.GetBody(), snoozeName, data)
+	if err != nil {
+		return err
+	}
+	if !found || data.Until != jobData.Until {
+		logger.Debug().Msgf("%s no longer snoozed until %s; ignored", ref, jobData.Until)
+		return nil
+	}
+
+	logger.Info().Msgf("wake up %s", ref)
+	return qa.wakeUp(ctx, client, ref, issue.GetBody())
+}
+
+// wakeUp removes the `snoozed` label from the referenced issue, restores
+// its triage labels and removes the snooze metadata from its description.
+func (qa SnoozeQuickAction) wakeUp(ctx *EventContext, client *github.Client, ref issueReference, body string) error {
+	data := &snoozeData{}
+	if _, err := decodeMetadata(body, snoozeName, data); err != nil {
+		return err
+	}
+
+	err := qa.removeIssueLabels(ctx, client, ref, snoozedLabel)
+	if err != nil && !isNotFound(err) {
+		// NOTE: the label can be already removed manually
+		return err
+	}
+
+	if len(data.Labels) > 0 {
+		if err := qa.addIssueLabels(ctx, client, ref, data.Labels...); err != nil {
+			return err
+		}
+	}
+
+	return qa.updateIssueMetadata(ctx, client, ref, snoozeName, func(string) (string, error) { return "", nil })
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("snooze", &SnoozeQuickAction{})
+
+	// NOTE: register event handlers
+	registerEventHandler("unsnooze", &SnoozeQuickAction{})
+
+	// NOTE: register job runners
+	registerJobRunner(snoozeName, &SnoozeQuickAction{})
+}
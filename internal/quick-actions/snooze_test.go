@@ -0,0 +1,195 @@
+package quick_actions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+	gqa_httptest "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx/httptest"
+	"xnku.be/github-quick-actions/pkg/scheduler"
+)
+
+func TestSnoozeQuickAction_Arguments(t *testing.T) {
+	from := time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC)
+	ts := map[string]struct {
+		args   []string
+		date   time.Time
+		reason string
+		err    error
+	}{
+		"hours":            {args: []string{"12h"}, date: from.Add(12 * time.Hour)},
+		"days":             {args: []string{"3d"}, date: from.AddDate(0, 0, 3)},
+		"weeks":            {args: []string{"2W"}, date: from.AddDate(0, 0, 14)},
+		"months":           {args: []string{"1mo"}, date: from.AddDate(0, 1, 0)},
+		"years":            {args: []string{"1y"}, date: from.AddDate(1, 0, 0)},
+		"relative date":    {args: []string{"in", "2", "weeks", "waiting", "for", "upstream"}, date: from.AddDate(0, 0, 14), reason: "waiting for upstream"},
+		"date":             {args: []string{"2026-11-01"}, date: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		"zero duration":    {args: []string{"0d"}, err: fmt.Errorf("invalid date '0d'; usage: `/snooze <date> [<reason>]`")},
+		"invalid unit":     {args: []string{"2s"}, err: fmt.Errorf("invalid date '2s'; usage: `/snooze <date> [<reason>]`")},
+		"invalid duration": {args: []string{"soon"}, err: fmt.Errorf("invalid date 'soon'; usage: `/snooze <date> [<reason>]`")},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			values, err := ParseArguments("snooze", SnoozeQuickAction{}.Arguments(), tc.args, nil)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			date, _ := values.Get("date")
+			reason, _ := values.Get("reason")
+			assert.Equal(t, tc.date, date.Time(from))
+			assert.Equal(t, tc.reason, reason.Value)
+		})
+	}
+}
+
+func TestSnoozeQuickAction_scheduleJob(t *testing.T) {
+	payload, err := PayloadFactory(EventTypeIssueComment, []byte(`{
+		"action": "created",
+		"comment": { "body": "/snooze 2w", "created_at": "2026-10-19T10:00:00Z" },
+		"repository": { "owner": { "login": "xunleii" }, "name": "github-quick-actions" },
+		"issue": { "number": 1, "labels": [{ "name": "needs-triage" }] },
+		"sender": { "login": "xunleii" },
+		"installation": { "id": 123456789 }
+	}`))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/1", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte(`{}`)) })
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/1/labels", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte(`[]`)) })
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/1/labels/", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte(`[]`)) })
+
+	srv := gqa_httptest.NewServer(mux)
+	store := scheduler.NewMemoryStore()
+	ctx := &EventContext{Context: context.TODO(), ClientCreator: &gqa_scenario_context.ClientCreator{Client: srv.Client()}, Store: store}
+	command := &EventCommand{Command: "snooze", Arguments: []string{"2w"}, Payload: payload}
+	require.NoError(t, ValidateArguments(SnoozeQuickAction{}, command))
+	require.NoError(t, SnoozeQuickAction{}.HandleCommand(ctx, command))
+
+	jobs, err := store.Due(context.TODO(), time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, snoozeName, jobs[0].Kind)
+	assert.Equal(t, int64(123456789), jobs[0].InstallationID)
+	assert.Equal(t, time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC), jobs[0].DueAt)
+	assert.JSONEq(t, `{"owner":"xunleii","repo":"github-quick-actions","number":1,"until":"2026-11-02T10:00:00Z"}`, string(jobs[0].Data))
+
+	err = SnoozeQuickAction{}.HandleCommand(&EventContext{Context: context.TODO()}, command)
+	assert.EqualError(t, err, "/snooze is disabled: no store configured")
+}
+
+func TestSnoozeQuickAction_wakeUpJob(t *testing.T) {
+	issues := map[string]string{
+		"/repos/xunleii/github-quick-actions/issues/1": `{"number": 1, "body": "Some description\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-11-02T10:00:00Z\",\"labels\":[\"needs-triage\"]} -->"}`,
+		"/repos/xunleii/github-quick-actions/issues/2": `{"number": 2, "body": "Snoozed again\n\n<!-- quick-actions:snooze:data {\"until\":\"2026-12-01T00:00:00Z\"} -->"}`,
+		"/repos/xunleii/github-quick-actions/issues/3": `{"number": 3, "body": "Already woken up"}`,
+	}
+
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(issues[r.URL.Path]))
+			return
+		}
+
+		var body []byte
+		if r.Body != nil {
+			body, _ = io.ReadAll(r.Body)
+		}
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+		if strings.Contains(r.URL.Path, "/labels") {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/repos/xunleii/removed/issues/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	srv := gqa_httptest.NewServer(mux)
+	client := github.NewClient(srv.Client())
+	ctx := &EventContext{Context: context.TODO()}
+
+	jobs := []string{
+		`{"owner":"xunleii","repo":"github-quick-actions","number":1,"until":"2026-11-02T10:00:00Z"}`,
+		`{"owner":"xunleii","repo":"github-quick-actions","number":2,"until":"2026-11-02T10:00:00Z"}`,
+		`{"owner":"xunleii","repo":"github-quick-actions","number":3,"until":"2026-11-02T10:00:00Z"}`,
+		`{"owner":"xunleii","repo":"removed","number":4,"until":"2026-11-02T10:00:00Z"}`,
+	}
+	for _, data := range jobs {
+		require.NoError(t, SnoozeQuickAction{}.wakeUpJob(ctx, client, &scheduler.Job{Kind: snoozeName, Data: []byte(data)}))
+	}
+
+	assert.Equal(t, []string{
+		"DELETE /repos/xunleii/github-quick-actions/issues/1/labels/snoozed ",
+		"POST /repos/xunleii/github-quick-actions/issues/1/labels " + `["needs-triage"]` + "\n",
+		"PATCH /repos/xunleii/github-quick-actions/issues/1 " + `{"body":"Some description"}` + "\n",
+	}, requests)
+}
+
+func TestSnooze_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment},
+		SnoozeQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestSnoozeFeature(t *testing.T) {
+	events := SnoozeQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"snooze": &SnoozeQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("snooze && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestUnsnoozeEventHandlerFeature(t *testing.T) {
+	for event := range (SnoozeQuickAction{}).TriggerOnActions() {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializerWithHandlers(map[string]QuickAction{"snooze": &SnoozeQuickAction{}}, map[string]EventHandler{"unsnooze": &SnoozeQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("unsnooze && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
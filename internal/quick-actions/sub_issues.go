@@ -0,0 +1,300 @@
+package quick_actions
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+// subIssuesMaxDepth is the maximum depth of a sub-issues hierarchy (defined by
+// Github).
+const subIssuesMaxDepth = 8
+
+type (
+	// ParentQuickAction implements QuickAction interface for /parent command.
+	// This quick action sets the parent issue of the current issue.
+	ParentQuickAction struct{ subIssuesHelper }
+	// ChildQuickAction implements QuickAction interface for /child command.
+	// This quick action adds sub-issues to the current issue.
+	ChildQuickAction struct{ subIssuesHelper }
+	// RemoveParentQuickAction implements QuickAction interface for /remove_parent
+	// command.
+	RemoveParentQuickAction struct{ subIssuesHelper }
+
+	// subIssuesHelper implements methods to manage the sub-issues hierarchy
+	// through the GraphQL API.
+	subIssuesHelper struct{ githubEventHelper }
+)
+
+func (qa ParentQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "issue", Kind: ArgumentKindIssueRef, Required: true}}
+}
+
+func (qa ParentQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa ParentQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/parent <issue>"},
+		Description: "Set the parent issue of the current issue (sub-issues).",
+		Details:     "The current parent is replaced and cycles in the hierarchy are rejected.",
+		Examples:    []string{"/parent #12", "/parent owner/repo#12", "/parent https://github.com/owner/repo/issues/12"},
+	}
+}
+
+func (qa ParentQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "parent").
+		Logger()
+
+	logger.Info().Msgf("handle `/parent` (args: %v)", command.Arguments)
+
+	if qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/parent can only be used on issues; ignored")
+		return nil
+	}
+
+	issue, _ := command.Values.Get("issue")
+	ref := issueReferenceFrom(issue)
+
+	client, err := ctx.NewGraphQLClient(command.Payload)
+	if err != nil {
+		return err
+	}
+
+	parentID, err := qa.getIssueID(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+
+	issueID := qa.getIssueNodeID(command.Payload)
+	ancestors, err := qa.getAncestors(ctx, client, parentID)
+	if err != nil {
+		return err
+	}
+	if parentID == issueID || ancestors[issueID] {
+		return fmt.Errorf("cannot set %s as parent: it would create a cycle", ref)
+	}
+
+	return qa.addSubIssue(ctx, client, parentID, issueID)
+}
+
+func (qa ChildQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "issue", Kind: ArgumentKindIssueRef, Required: true, Variadic: true}}
+}
+
+func (qa ChildQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa ChildQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/child <issue> [<issue>...]"},
+		Description: "Add one or more sub-issues to the current issue.",
+		Examples:    []string{"/child #34 #35"},
+	}
+}
+
+func (qa ChildQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "child").
+		Logger()
+
+	logger.Info().Msgf("handle `/child` (args: %v)", command.Arguments)
+
+	if qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/child can only be used on issues; ignored")
+		return nil
+	}
+
+	var refs []issueReference
+	for _, issue := range command.Values["issue"] {
+		refs = append(refs, issueReferenceFrom(issue))
+	}
+
+	client, err := ctx.NewGraphQLClient(command.Payload)
+	if err != nil {
+		return err
+	}
+
+	issueID := qa.getIssueNodeID(command.Payload)
+	ancestors, err := qa.getAncestors(ctx, client, issueID)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: all children are resolved before updating the hierarchy in order
+	//		 to avoid partial updates
+	var childIDs []githubv4.ID
+	for _, ref := range refs {
+		childID, err := qa.getIssueID(ctx, client, ref)
+		if err != nil {
+			return err
+		}
+
+		if childID == issueID || ancestors[childID] {
+			return fmt.Errorf("cannot add %s as child: it would create a cycle", ref)
+		}
+		childIDs = append(childIDs, childID)
+	}
+
+	errs := &multierror.Error{}
+	for _, childID := range childIDs {
+		errs = multierror.Append(errs, qa.addSubIssue(ctx, client, issueID, childID))
+	}
+	return errs.ErrorOrNil()
+}
+
+func (qa RemoveParentQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa RemoveParentQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/remove_parent"},
+		Description: "Remove the current issue from its parent issue.",
+	}
+}
+
+func (qa RemoveParentQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "remove_parent").
+		Logger()
+
+	logger.Info().Msgf("handle `/remove_parent` (args: %v)", command.Arguments)
+
+	if qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/remove_parent can only be used on issues; ignored")
+		return nil
+	}
+
+	client, err := ctx.NewGraphQLClient(command.Payload)
+	if err != nil {
+		return err
+	}
+
+	issueID := qa.getIssueNodeID(command.Payload)
+	parentID, err := qa.getParentID(ctx, client, issueID)
+	if err != nil {
+		return err
+	}
+	if parentID == nil {
+		logger.Debug().Msgf("issue has no parent; ignored")
+		return nil
+	}
+
+	return qa.removeSubIssue(ctx, client, parentID, issueID)
+}
+
+func (subIssuesHelper) TriggerOnEvents() []EventType {
+	// NOTE: sub-issues are only available on issues (description and comments)
+	return []EventType{EventTypeIssue, EventTypeIssueComment}
+}
+
+// getIssueID returns the GraphQL node ID of the referenced issue.
+func (subIssuesHelper) getIssueID(ctx *EventContext, client *githubv4.Client, ref issueReference) (githubv4.ID, error) {
+	var query struct {
+		Repository struct {
+			Issue struct{ ID githubv4.ID } `graphql:"issue(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	err := client.Query(ctx, &query, map[string]interface{}{
+		"owner":  githubv4.String(ref.Owner),
+		"name":   githubv4.String(ref.Repo),
+		"number": githubv4.Int(ref.Number),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue %s: %w", ref, err)
+	}
+	return query.Repository.Issue.ID, nil
+}
+
+// getParentID returns the GraphQL node ID of the parent of the given issue
+// (nil if the issue has no parent).
+func (subIssuesHelper) getParentID(ctx *EventContext, client *githubv4.Client, issueID githubv4.ID) (githubv4.ID, error) {
+	var query struct {
+		Node struct {
+			Issue struct {
+				Parent *struct{ ID githubv4.ID }
+			} `graphql:"... on Issue"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	err := client.Query(ctx, &query, map[string]interface{}{"id": issueID})
+	if err != nil || query.Node.Issue.Parent == nil {
+		return nil, err
+	}
+	return query.Node.Issue.Parent.ID, nil
+}
+
+// getAncestors returns all ancestors of the given issue, walking through the
+// sub-issues hierarchy.
+func (qa subIssuesHelper) getAncestors(ctx *EventContext, client *githubv4.Client, issueID githubv4.ID) (map[githubv4.ID]bool, error) {
+	ancestors := map[githubv4.ID]bool{}
+	for depth := 0; depth < subIssuesMaxDepth; depth++ {
+		parentID, err := qa.getParentID(ctx, client, issueID)
+		if err != nil {
+			return nil, err
+		}
+		if parentID == nil || ancestors[parentID] {
+			break
+		}
+
+		ancestors[parentID] = true
+		issueID = parentID
+	}
+	return ancestors, nil
+}
+
+// addSubIssue adds the given sub-issue to the given parent issue, replacing
+// its current parent.
+func (subIssuesHelper) addSubIssue(ctx *EventContext, client *githubv4.Client, parentID, subIssueID githubv4.ID) error {
+	var mutation struct {
+		AddSubIssue struct {
+			Issue struct{ ID githubv4.ID }
+		} `graphql:"addSubIssue(input: $input)"`
+	}
+
+	// NOTE: githubv4 doesn't manage sub-issues yet; this local type keeps
+	//		 the same GraphQL type name (required by githubv4 to generate
+	//		 the mutation).
+	type AddSubIssueInput struct {
+		IssueID       githubv4.ID      `json:"issueId"`
+		SubIssueID    githubv4.ID      `json:"subIssueId"`
+		ReplaceParent githubv4.Boolean `json:"replaceParent"`
+	}
+
+	input := AddSubIssueInput{IssueID: parentID, SubIssueID: subIssueID, ReplaceParent: true}
+	return client.Mutate(ctx, &mutation, input, nil)
+}
+
+// removeSubIssue removes the given sub-issue from the given parent issue.
+func (subIssuesHelper) removeSubIssue(ctx *EventContext, client *githubv4.Client, parentID, subIssueID githubv4.ID) error {
+	var mutation struct {
+		RemoveSubIssue struct {
+			Issue struct{ ID githubv4.ID }
+		} `graphql:"removeSubIssue(input: $input)"`
+	}
+
+	// NOTE: same as addSubIssue, githubv4 doesn't manage this type yet
+	type RemoveSubIssueInput struct {
+		IssueID    githubv4.ID `json:"issueId"`
+		SubIssueID githubv4.ID `json:"subIssueId"`
+	}
+
+	input := RemoveSubIssueInput{IssueID: parentID, SubIssueID: subIssueID}
+	return client.Mutate(ctx, &mutation, input, nil)
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("parent", &ParentQuickAction{})
+	registerQuickAction("child", &ChildQuickAction{})
+	registerQuickAction("remove_parent", &RemoveParentQuickAction{})
+}
@@ -0,0 +1,368 @@
+package quick_actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+const (
+	// timeTrackingName is the name used to identify the time tracking summary
+	// comment.
+	timeTrackingName = "time_tracking"
+	// timeTrackingMaxLength is the maximum length of the summary comment
+	// (Github comments are limited to 65536 characters).
+	timeTrackingMaxLength = 65536
+	// timeTrackingMaxAttempts is the maximum number of attempts to update
+	// the summary comment when it is edited concurrently.
+	timeTrackingMaxAttempts = 3
+)
+
+type (
+	timeTrackingHelper struct{ githubEventHelper }
+
+	// timeTrackingData contains all time tracking information, persisted
+	// in the summary comment.
+	timeTrackingData struct {
+		// Estimate is the estimated time, in minutes.
+		Estimate int         `json:"estimate,omitempty"`
+		Spent    []timeSpent `json:"spent,omitempty"`
+	}
+	// timeSpent is a time entry added by /spend.
+	timeSpent struct {
+		User    string `json:"user"`
+		Minutes int    `json:"minutes"`
+		Date    string `json:"date"`
+	}
+
+	// EstimateQuickAction implements QuickAction interface for /estimate command.
+	// This quick action sets the time estimate of an issue or a PR.
+	EstimateQuickAction struct{ timeTrackingHelper }
+	// RemoveEstimateQuickAction implements QuickAction interface for /remove_estimate command.
+	// This quick action removes the time estimate of an issue or a PR.
+	RemoveEstimateQuickAction struct{ timeTrackingHelper }
+	// SpendQuickAction implements QuickAction interface for /spend command.
+	// This quick action adds (or subtracts) spent time on an issue or a PR.
+	SpendQuickAction struct{ timeTrackingHelper }
+	// RemoveTimeSpentQuickAction implements QuickAction interface for /remove_time_spent command.
+	// This quick action removes all spent time of an issue or a PR.
+	RemoveTimeSpentQuickAction struct{ timeTrackingHelper }
+)
+
+func (qa EstimateQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{{Name: "duration", Kind: ArgumentKindDuration, Required: true, Variadic: true}}
+}
+
+func (qa EstimateQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa EstimateQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/estimate <1w 3d 2h 14m>"},
+		Description: "Set time estimate.",
+		Details:     "Time tracking is summarized in a comment maintained by the application.",
+		Examples:    []string{"/estimate 1d 4h"},
+	}
+}
+
+func (qa EstimateQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "estimate").
+		Logger()
+
+	logger.Info().Msgf("handle `/estimate` (args: %v)", command.Arguments)
+
+	estimate, err := durationMinutes(command.Values["duration"])
+	if err != nil {
+		return err
+	}
+	if estimate < 0 {
+		return fmt.Errorf("estimate cannot be negative")
+	}
+
+	return qa.updateTimeTracking(ctx, command, func(data *timeTrackingData) error {
+		data.Estimate = estimate
+		return nil
+	})
+}
+
+func (qa RemoveEstimateQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa RemoveEstimateQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/remove_estimate"},
+		Description: "Remove time estimate.",
+	}
+}
+
+func (qa RemoveEstimateQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "remove_estimate").
+		Logger()
+
+	logger.Info().Msgf("handle `/remove_estimate` (args: %v)", command.Arguments)
+
+	return qa.updateTimeTracking(ctx, command, func(data *timeTrackingData) error {
+		data.Estimate = 0
+		return nil
+	})
+}
+
+func (qa SpendQuickAction) Arguments() []ArgumentSpec {
+	return []ArgumentSpec{
+		{Name: "duration", Kind: ArgumentKindDuration, Required: true, Variadic: true},
+		{Name: "date", Kind: ArgumentKindDate},
+	}
+}
+
+func (qa SpendQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa SpendQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/spend <time(1h 30m | -1h 5m)> [<date>]"},
+		Description: "Add or subtract spent time.",
+		Details:     "Optionally, specify the date (`YYYY-MM-DD`) that time was spent on. Like on Gitlab, a day (`d`) lasts 8 hours, a week (`w`) 5 days and a month (`mo`) 4 weeks.",
+		Examples:    []string{"/spend 1h 30m", "/spend -30m 2026-10-01"},
+	}
+}
+
+func (qa SpendQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "spend").
+		Logger()
+
+	logger.Info().Msgf("handle `/spend` (args: %v)", command.Arguments)
+
+	date := qa.getEventDate(command.Payload)
+	if arg, exists := command.Values.Get("date"); exists {
+		date = arg.Time(date)
+	}
+
+	spent, err := durationMinutes(command.Values["duration"])
+	if err != nil {
+		return err
+	}
+
+	user := qa.getSender(command.Payload)
+	return qa.updateTimeTracking(ctx, command, func(data *timeTrackingData) error {
+		if data.totalSpent()+spent < 0 {
+			return fmt.Errorf("total time spent cannot be negative")
+		}
+
+		data.Spent = append(data.Spent, timeSpent{User: user, Minutes: spent, Date: date.Format("2006-01-02")})
+		return nil
+	})
+}
+
+func (qa RemoveTimeSpentQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionTriage}
+}
+
+func (qa RemoveTimeSpentQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/remove_time_spent"},
+		Description: "Remove time spent.",
+	}
+}
+
+func (qa RemoveTimeSpentQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "remove_time_spent").
+		Logger()
+
+	logger.Info().Msgf("handle `/remove_time_spent` (args: %v)", command.Arguments)
+
+	return qa.updateTimeTracking(ctx, command, func(data *timeTrackingData) error {
+		data.Spent = nil
+		return nil
+	})
+}
+
+func (timeTrackingHelper) TriggerOnEvents() []EventType {
+	// NOTE: time tracking should be triggered on comment
+	return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
+}
+
+// updateTimeTracking fetches the time tracking summary comment, applies the
+// given update and persists the result.
+// NOTE: the summary is fetched again just before being edited; if it has
+//		 been changed in the meantime (by another command handled at the
+//		 same time), the update is applied again on the new summary.
+func (qa timeTrackingHelper) updateTimeTracking(ctx *EventContext, command *EventCommand, update func(data *timeTrackingData) error) error {
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	comment, err := qa.findBotComment(ctx, client, command.Payload, metadataMarker(timeTrackingName))
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		data := &timeTrackingData{}
+		if comment != nil {
+			if _, err = decodeMetadata(comment.GetBody(), timeTrackingName, data); err != nil {
+				return err
+			}
+		}
+
+		if err = update(data); err != nil {
+			return err
+		}
+
+		body, err := data.renderWithin(timeTrackingMaxLength)
+		if err != nil {
+			return err
+		}
+
+		if comment != nil {
+			current, _, err := client.Issues.GetComment(ctx, command.Payload.RepositoryOwner(), command.Payload.RepositoryName(), comment.GetID())
+			if err != nil {
+				return err
+			}
+
+			if current.GetBody() != comment.GetBody() {
+				if attempt == timeTrackingMaxAttempts {
+					return fmt.Errorf("failed to update the time tracking summary: edited concurrently %d times", attempt)
+				}
+
+				zerolog.Ctx(ctx).Debug().Msgf("time tracking summary edited concurrently; retry (attempt %d)", attempt)
+				comment = current
+				continue
+			}
+		}
+
+		return qa.upsertBotComment(ctx, client, command.Payload, comment, body)
+	}
+}
+
+// renderWithin renders the time tracking summary comment; if it is longer
+// than the given length, the time entries are rolled over first.
+func (data *timeTrackingData) renderWithin(length int) (string, error) {
+	body, err := data.render()
+	if err != nil || len(body) <= length {
+		return body, err
+	}
+
+	data.rollOver()
+	body, err = data.render()
+	if err != nil || len(body) <= length {
+		return body, err
+	}
+	return "", fmt.Errorf("time tracking summary is too large (%d characters, expected at most %d)", len(body), length)
+}
+
+// rollOver merges all time entries of each user into a single entry, dated
+// with the latest date of the merged ones.
+func (data *timeTrackingData) rollOver() {
+	var spent []timeSpent
+	index := map[string]int{}
+	for _, entry := range data.Spent {
+		i, exists := index[entry.User]
+		if !exists {
+			index[entry.User] = len(spent)
+			spent = append(spent, entry)
+			continue
+		}
+
+		spent[i].Minutes += entry.Minutes
+		if entry.Date > spent[i].Date {
+			spent[i].Date = entry.Date
+		}
+	}
+	data.Spent = spent
+}
+
+func (data timeTrackingData) totalSpent() (total int) {
+	for _, spent := range data.Spent {
+		total += spent.Minutes
+	}
+	return total
+}
+
+// render generates the time tracking summary comment.
+func (data timeTrackingData) render() (string, error) {
+	metadata, err := encodeMetadata(timeTrackingName, data)
+	if err != nil {
+		return "", err
+	}
+
+	summary := &strings.Builder{}
+	summary.WriteString(metadataMarker(timeTrackingName) + "\n")
+	summary.WriteString("#### Time tracking\n\n")
+
+	estimate := "_none_"
+	if data.Estimate > 0 {
+		estimate = formatDuration(data.Estimate)
+	}
+	fmt.Fprintf(summary, "**Estimate:** %s<br>\n", estimate)
+	fmt.Fprintf(summary, "**Time spent:** %s\n\n", formatDuration(data.totalSpent()))
+
+	perUser := map[string]int{}
+	var users []string
+	for _, spent := range data.Spent {
+		if _, exists := perUser[spent.User]; !exists {
+			users = append(users, spent.User)
+		}
+		perUser[spent.User] += spent.Minutes
+	}
+	sort.Strings(users)
+
+	if len(users) > 0 {
+		summary.WriteString("| User | Time spent |\n")
+		summary.WriteString("| :--- | ---------: |\n")
+		for _, user := range users {
+			fmt.Fprintf(summary, "| @%s | %s |\n", user, formatDuration(perUser[user]))
+		}
+		summary.WriteString("\n")
+	}
+
+	summary.WriteString(metadata)
+	return summary.String(), nil
+}
+
+// durationMinutes returns the number of minutes of the given durations,
+// like `1h 30m`; like on Gitlab, the sign of the first duration applies to
+// all of them (`-1h 30m` subtracts 90 minutes).
+func durationMinutes(durations []Argument) (int, error) {
+	var total time.Duration
+	for i, duration := range durations {
+		switch {
+		case duration.Duration >= 0:
+			total += duration.Duration
+		case i == 0:
+			total -= duration.Duration
+		default:
+			return 0, fmt.Errorf("invalid duration '%s' (only the first duration can be negative)", duration.Raw)
+		}
+	}
+
+	if len(durations) > 0 && durations[0].Duration < 0 {
+		total = -total
+	}
+	return int(total / time.Minute), nil
+}
+
+// formatDuration formats the given number of minutes like a Gitlab duration.
+func formatDuration(minutes int) string {
+	return FormatDuration(time.Duration(minutes) * time.Minute)
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("estimate", &EstimateQuickAction{})
+	registerQuickAction("remove_estimate", &RemoveEstimateQuickAction{})
+	registerQuickAction("spend", &SpendQuickAction{})
+	registerQuickAction("remove_time_spent", &RemoveTimeSpentQuickAction{})
+}
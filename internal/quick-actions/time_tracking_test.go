@@ -0,0 +1,292 @@
+package quick_actions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	gqa_scenario_context "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx"
+	gqa_httptest "xnku.be/github-quick-actions/pkg/ghk_scenario_ctx/httptest"
+)
+
+func TestDurationMinutes(t *testing.T) {
+	ts := map[string]struct {
+		args    []string
+		minutes int
+		err     string
+	}{
+		"minutes":          {args: []string{"30m"}, minutes: 30},
+		"days":             {args: []string{"1d"}, minutes: 8 * 60},
+		"weeks":            {args: []string{"1w"}, minutes: 5 * 8 * 60},
+		"months":           {args: []string{"1mo"}, minutes: 4 * 5 * 8 * 60},
+		"mixed":            {args: []string{"1d2h30m"}, minutes: 8*60 + 2*60 + 30},
+		"several":          {args: []string{"1h", "30m"}, minutes: 90},
+		"negative":         {args: []string{"-1h", "30m"}, minutes: -90},
+		"invalid negative": {args: []string{"1h", "-30m"}, err: "invalid duration '-30m' (only the first duration can be negative)"},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			values, err := ParseArguments("estimate", EstimateQuickAction{}.Arguments(), tc.args, nil)
+			require.NoError(t, err)
+
+			minutes, err := durationMinutes(values["duration"])
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.minutes, minutes)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	ts := map[int]string{
+		0:                     "0m",
+		30:                    "30m",
+		90:                    "1h 30m",
+		8 * 60:                "1d",
+		5*8*60 + 60:           "1w 1h",
+		4*5*8*60 + 8*60 + 1:   "1mo 1d 1m",
+		-90:                   "-1h 30m",
+		2*4*5*8*60 + 3*5*8*60: "2mo 3w",
+	}
+
+	for minutes, duration := range ts {
+		t.Run(duration, func(t *testing.T) {
+			assert.Equal(t, duration, formatDuration(minutes))
+		})
+	}
+}
+
+func TestTimeTrackingData_render(t *testing.T) {
+	data := timeTrackingData{
+		Estimate: 600,
+		Spent: []timeSpent{
+			{User: "xunleii", Minutes: 30, Date: "2026-10-19"},
+			{User: "mojombo", Minutes: 90, Date: "2026-10-19"},
+			{User: "xunleii", Minutes: 60, Date: "2026-10-20"},
+		},
+	}
+
+	summary, err := data.render()
+	assert.NoError(t, err)
+	assert.Equal(t, `<!-- quick-actions:time_tracking -->
+#### Time tracking
+
+**Estimate:** 1d 2h<br>
+**Time spent:** 3h
+
+| User | Time spent |
+| :--- | ---------: |
+| @mojombo | 1h 30m |
+| @xunleii | 1h 30m |
+
+<!-- quick-actions:time_tracking:data {"estimate":600,"spent":[{"user":"xunleii","minutes":30,"date":"2026-10-19"},{"user":"mojombo","minutes":90,"date":"2026-10-19"},{"user":"xunleii","minutes":60,"date":"2026-10-20"}]} -->`, summary)
+
+	var decoded timeTrackingData
+	found, err := decodeMetadata(summary, timeTrackingName, &decoded)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, data, decoded)
+}
+
+func TestTimeTrackingData_renderWithin(t *testing.T) {
+	data := timeTrackingData{
+		Spent: []timeSpent{
+			{User: "xunleii", Minutes: 30, Date: "2026-10-20"},
+			{User: "mojombo", Minutes: 90, Date: "2026-10-19"},
+			{User: "xunleii", Minutes: 60, Date: "2026-10-19"},
+		},
+	}
+
+	summary, err := data.renderWithin(timeTrackingMaxLength)
+	require.NoError(t, err)
+	assert.Len(t, data.Spent, 3)
+
+	// NOTE: entries are rolled over only when the summary is too long
+	_, err = data.renderWithin(len(summary) - 1)
+	require.NoError(t, err)
+	assert.Equal(t, []timeSpent{
+		{User: "xunleii", Minutes: 90, Date: "2026-10-20"},
+		{User: "mojombo", Minutes: 90, Date: "2026-10-19"},
+	}, data.Spent)
+
+	_, err = data.renderWithin(100)
+	assert.Regexp(t, `^time tracking summary is too large \(\d+ characters, expected at most 100\)$`, err)
+}
+
+func TestTimeTrackingHelper_updateTimeTracking(t *testing.T) {
+	payload, err := PayloadFactory(EventTypeIssueComment, []byte(`{
+		"action": "created",
+		"comment": { "body": "/spend 1h", "created_at": "2026-10-19T10:00:00Z" },
+		"repository": { "owner": { "login": "xunleii" }, "name": "github-quick-actions" },
+		"issue": { "number": 1 },
+		"sender": { "login": "xunleii" },
+		"installation": { "id": 123456789 }
+	}`))
+	require.NoError(t, err)
+
+	listed := `<!-- quick-actions:time_tracking -->\n<!-- quick-actions:time_tracking:data {\"spent\":[{\"user\":\"mojombo\",\"minutes\":30,\"date\":\"2026-10-18\"}]} -->`
+	edited := `<!-- quick-actions:time_tracking -->\n<!-- quick-actions:time_tracking:data {\"spent\":[{\"user\":\"mojombo\",\"minutes\":30,\"date\":\"2026-10-18\"},{\"user\":\"mojombo\",\"minutes\":60,\"date\":\"2026-10-19\"}]} -->`
+
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"slug": "quick-actions"}`))
+	})
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": 43, "user": {"login": "quick-actions[bot]", "type": "Bot"}, "body": "` + listed + `"}]`))
+	})
+	mux.HandleFunc("/repos/xunleii/github-quick-actions/issues/comments/43", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// NOTE: the summary has been edited since it was listed
+			_, _ = w.Write([]byte(`{"id": 43, "body": "` + edited + `"}`))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, body))
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	srv := gqa_httptest.NewServer(mux)
+	ctx := &EventContext{Context: context.TODO(), ClientCreator: &gqa_scenario_context.ClientCreator{Client: srv.Client()}}
+	command := &EventCommand{Command: "spend", Payload: payload}
+
+	var attempts int
+	err = timeTrackingHelper{}.updateTimeTracking(ctx, command, func(data *timeTrackingData) error {
+		attempts++
+		data.Spent = append(data.Spent, timeSpent{User: "xunleii", Minutes: 60, Date: "2026-10-19"})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	require.Len(t, requests, 1)
+	assert.Contains(t, requests[0], `{\"user\":\"mojombo\",\"minutes\":60,\"date\":\"2026-10-19\"},{\"user\":\"xunleii\",\"minutes\":60,\"date\":\"2026-10-19\"}`)
+}
+
+func TestEstimate_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		EstimateQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestEstimateFeature(t *testing.T) {
+	events := EstimateQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"estimate": &EstimateQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("estimate && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestRemoveEstimate_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		RemoveEstimateQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestRemoveEstimateFeature(t *testing.T) {
+	events := RemoveEstimateQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"remove_estimate": &RemoveEstimateQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("remove_estimate && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestSpend_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		SpendQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestSpendFeature(t *testing.T) {
+	events := SpendQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"spend": &SpendQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("spend && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
+
+func TestRemoveTimeSpent_TriggerOnEvents(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]EventType{EventTypeIssueComment, EventTypePullRequestReviewComment},
+		RemoveTimeSpentQuickAction{}.TriggerOnEvents(),
+	)
+}
+
+func TestRemoveTimeSpentFeature(t *testing.T) {
+	events := RemoveTimeSpentQuickAction{}.TriggerOnEvents()
+
+	for _, event := range events {
+		t.Run(string(event), func(t *testing.T) {
+			suite := godog.TestSuite{
+				ScenarioInitializer: gqa_scenario_context.ScenarioInitializer(map[string]QuickAction{"remove_time_spent": &RemoveTimeSpentQuickAction{}}),
+				Options: &godog.Options{
+					Format:   "pretty",
+					Paths:    []string{"ghk::features"},
+					Tags:     fmt.Sprintf("remove_time_spent && %s", event),
+					TestingT: t,
+				},
+			}
+
+			if suite.Run() != 0 {
+				t.Fatal("non-zero status returned, failed to run feature tests")
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package quick_actions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+
+	. "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+type (
+	// UpdateBranchQuickAction implements QuickAction interface for /update_branch command.
+	// This quick action merges (or rebases with `--rebase`) the base branch
+	// into the pull request branch.
+	UpdateBranchQuickAction struct{ githubEventHelper }
+)
+
+func (qa UpdateBranchQuickAction) TriggerOnEvents() []EventType {
+	// NOTE: update branch is only available on comments (description cannot be
+	// 		 used because the pull request is just created)
+	return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
+}
+
+func (qa UpdateBranchQuickAction) Restriction() Restriction {
+	return Restriction{Permission: PermissionWrite, AllowAuthor: true}
+}
+
+func (qa UpdateBranchQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{"/update_branch [--rebase]"},
+		Description: "Update the pull request branch with the latest changes of the base branch.",
+		Details:     "Use `--rebase` to rebase the branch instead of merging the base branch.",
+	}
+}
+
+func (qa UpdateBranchQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", "update_branch").
+		Logger()
+
+	logger.Info().Msgf("handle `/update_branch` (args: %v)", command.Arguments)
+
+	if !qa.isPullRequest(command.Payload) {
+		logger.Debug().Msgf("/update_branch can only be used on pull requests; ignored")
+		return nil
+	}
+
+	rebase := false
+	for _, arg := range command.Arguments {
+		switch arg {
+		case "--rebase":
+			rebase = true
+		default:
+			return fmt.Errorf("invalid argument '%s' for /update_branch", arg)
+		}
+	}
+
+	client, err := qa.newInstallationClient(ctx, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	owner, repo := command.Payload.RepositoryOwner(), command.Payload.RepositoryName()
+	pr, err := qa.getPullRequest(ctx, client, command.Payload)
+	if err != nil {
+		return err
+	}
+
+	comparison, _, err := client.Repositories.CompareCommits(ctx, owner, repo, pr.GetBase().GetRef(), pr.GetHead().GetSHA(), nil)
+	if err != nil {
+		return err
+	}
+
+	if comparison.GetBehindBy() == 0 {
+		logger.Debug().Msgf("branch '%s' already up to date with '%s'", pr.GetHead().GetRef(), pr.GetBase().GetRef())
+		return qa.createComment(ctx, client, command.Payload,
+			fmt.Sprintf("Branch `%s` is already up to date with `%s`.", pr.GetHead().GetRef(), pr.GetBase().GetRef()),
+		)
+	}
+
+	if rebase {
+		err = qa.rebaseBranch(ctx, command.Payload, pr)
+	} else {
+		_, _, err = client.PullRequests.UpdateBranch(ctx, owner, repo, pr.GetNumber(),
+			&github.PullRequestBranchUpdateOptions{ExpectedHeadSHA: github.String(pr.GetHead().GetSHA())},
+		)
+
+		// NOTE: Github replies with 202 Accepted because the update is done asynchronously
+		var accepted *github.AcceptedError
+		if errors.As(err, &accepted) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	method := "merge"
+	if rebase {
+		method = "rebase"
+	}
+	return qa.createComment(ctx, client, command.Payload,
+		fmt.Sprintf("Branch `%s` was %d commit(s) behind `%s`; update by %s has been requested.",
+			pr.GetHead().GetRef(), comparison.GetBehindBy(), pr.GetBase().GetRef(), method),
+	)
+}
+
+// rebaseBranch rebases the pull request branch using the GraphQL API; the
+// REST API only supports updating the branch with a merge commit.
+func (qa UpdateBranchQuickAction) rebaseBranch(ctx *EventContext, payload EventPayload, pr *github.PullRequest) error {
+	client, err := ctx.NewGraphQLClient(payload)
+	if err != nil {
+		return err
+	}
+
+	var mutation struct {
+		UpdatePullRequestBranch struct {
+			PullRequest struct{ ID githubv4.ID }
+		} `graphql:"updatePullRequestBranch(input: $input)"`
+	}
+
+	// NOTE: githubv4.UpdatePullRequestBranchInput doesn't manage the update
+	//		 method yet; this local type keeps the same GraphQL type name
+	//		 (required by githubv4 to generate the mutation).
+	type UpdatePullRequestBranchInput struct {
+		githubv4.UpdatePullRequestBranchInput
+		UpdateMethod githubv4.String `json:"updateMethod"`
+	}
+
+	headOid := githubv4.GitObjectID(pr.GetHead().GetSHA())
+	input := UpdatePullRequestBranchInput{
+		UpdatePullRequestBranchInput: githubv4.UpdatePullRequestBranchInput{
+			PullRequestID:   githubv4.ID(pr.GetNodeID()),
+			ExpectedHeadOid: &headOid,
+		},
+		UpdateMethod: "REBASE",
+	}
+	return client.Mutate(ctx, &mutation, input, nil)
+}
+
+func init() {
+	// NOTE: register quick actions
+	registerQuickAction("update_branch", &UpdateBranchQuickAction{})
+}
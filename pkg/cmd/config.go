@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/palantir/go-githubapp/githubapp"
@@ -25,6 +27,20 @@ const (
 	EnvVarListenPath = "GQA_LISTEN_PATH"
 	EnvVarUserAgent  = "GQA_USER_AGENT"
 
+	EnvVarStorePath     = "GQA_STORE_PATH"
+	EnvVarTickInterval  = "GQA_TICK_INTERVAL"
+	EnvVarSweepInterval = "GQA_SWEEP_INTERVAL"
+
+	EnvVarLabelPolicy = "GQA_LABEL_POLICY"
+
+	EnvVarFeedbackReactions = "GQA_FEEDBACK_REACTIONS"
+	EnvVarFeedbackReport    = "GQA_FEEDBACK_REPORT"
+
+	EnvVarRepositoryConfig = "GQA_REPOSITORY_CONFIG"
+
+	EnvVarPluginsEndpoints = "GQA_PLUGINS_ENDPOINTS"
+	EnvVarPluginsTimeout   = "GQA_PLUGINS_TIMEOUT"
+
 	EnvVarLogLevel = "GQA_LOG_LEVEL"
 )
 
@@ -49,6 +65,20 @@ type CLIConfig struct {
 	ListenPath string `name:"listen.path" help:"Webhook listening path" env:"GQA_LISTEN_PATH" default:"/api/v1/webhook"`
 	LogLevel   string `name:"log.level" help:"Log level verbosity" env:"GQA_LOG_LEVEL" default:"info" enum:"trace,debug,info,warn,error,fatal,panic"`
 
+	StorePath     string        `name:"store.path" help:"Path of the embedded store containing scheduled jobs (like reminders); scheduled jobs are disabled if empty" env:"GQA_STORE_PATH" default:"quick-actions.db"`
+	TickInterval  time.Duration `name:"store.tick_interval" help:"Interval between two runs of the scheduled jobs" env:"GQA_TICK_INTERVAL" default:"1m"`
+	SweepInterval time.Duration `name:"sweep.interval" help:"Minimum interval between two runs of the periodic tasks (like due date reminders)" env:"GQA_SWEEP_INTERVAL" default:"1h"`
+
+	LabelPolicy string `name:"label.policy" help:"How /label handles labels missing from the repository: add them anyway (allow), create them with the given color (create) or refuse them (reject)" env:"GQA_LABEL_POLICY" default:"allow" enum:"allow,create,reject"`
+
+	FeedbackReactions bool `name:"feedback.reactions" help:"Add reactions on comments containing quick actions (👀 on receipt, then 👍 or 😕)" env:"GQA_FEEDBACK_REACTIONS" default:"true" negatable:""`
+	FeedbackReport    bool `name:"feedback.report" help:"Reply to comments containing quick actions with the result of each command" env:"GQA_FEEDBACK_REPORT" default:"false"`
+
+	RepositoryConfig bool `name:"repository.config" help:"Read the configuration of each repository from .github/quick-actions.yml, inheriting from the organization .github repository" env:"GQA_REPOSITORY_CONFIG" default:"true" negatable:""`
+
+	PluginsEndpoints map[string]string `name:"plugins.endpoints" help:"URL prefixes plugins can be declared with, and the secret used to sign the requests sent to each of them, like 'https://plugins.example.com/=secret;...' (plugins disabled if empty)" env:"GQA_PLUGINS_ENDPOINTS"`
+	PluginsTimeout   time.Duration     `name:"plugins.timeout" help:"Timeout of each plugin request" env:"GQA_PLUGINS_TIMEOUT" default:"10s"`
+
 	Version kong.VersionFlag
 }
 
@@ -133,6 +163,63 @@ var envVarsDefinitions = map[string]struct {
 		defaults: func(config *CLIConfig) (err error) { config.ListenPath = "/api/v1/webhook"; return },
 		set:      func(config *CLIConfig, s string) (err error) { config.ListenPath = s; return },
 	},
+	"GQA_STORE_PATH": {
+		defaults: func(config *CLIConfig) (err error) { config.StorePath = "quick-actions.db"; return },
+		set:      func(config *CLIConfig, s string) (err error) { config.StorePath = s; return },
+	},
+	"GQA_TICK_INTERVAL": {
+		defaults: func(config *CLIConfig) (err error) { config.TickInterval = time.Minute; return },
+		set: func(config *CLIConfig, s string) (err error) {
+			config.TickInterval, err = time.ParseDuration(s)
+			return
+		},
+	},
+	"GQA_SWEEP_INTERVAL": {
+		defaults: func(config *CLIConfig) (err error) { config.SweepInterval = time.Hour; return },
+		set: func(config *CLIConfig, s string) (err error) {
+			config.SweepInterval, err = time.ParseDuration(s)
+			return
+		},
+	},
+	"GQA_LABEL_POLICY": {
+		defaults: func(config *CLIConfig) (err error) { config.LabelPolicy = "allow"; return },
+		set:      func(config *CLIConfig, s string) (err error) { config.LabelPolicy = s; return },
+	},
+	"GQA_FEEDBACK_REACTIONS": {
+		defaults: func(config *CLIConfig) (err error) { config.FeedbackReactions = true; return },
+		set: func(config *CLIConfig, s string) (err error) {
+			config.FeedbackReactions, err = strconv.ParseBool(s)
+			return
+		},
+	},
+	"GQA_FEEDBACK_REPORT": {
+		defaults: func(config *CLIConfig) (err error) { return },
+		set: func(config *CLIConfig, s string) (err error) {
+			config.FeedbackReport, err = strconv.ParseBool(s)
+			return
+		},
+	},
+	"GQA_REPOSITORY_CONFIG": {
+		defaults: func(config *CLIConfig) (err error) { config.RepositoryConfig = true; return },
+		set: func(config *CLIConfig, s string) (err error) {
+			config.RepositoryConfig, err = strconv.ParseBool(s)
+			return
+		},
+	},
+	"GQA_PLUGINS_ENDPOINTS": {
+		defaults: func(config *CLIConfig) (err error) { return },
+		set: func(config *CLIConfig, s string) (err error) {
+			config.PluginsEndpoints, err = ParsePluginsEndpoints(s)
+			return
+		},
+	},
+	"GQA_PLUGINS_TIMEOUT": {
+		defaults: func(config *CLIConfig) (err error) { config.PluginsTimeout = 10 * time.Second; return },
+		set: func(config *CLIConfig, s string) (err error) {
+			config.PluginsTimeout, err = time.ParseDuration(s)
+			return
+		},
+	},
 
 	"GQA_GITHUB_API_VERSION": {
 		defaults: func(config *CLIConfig) (err error) { config.Github.APIVersion = "v3"; return },
@@ -169,3 +256,20 @@ var envVarsDefinitions = map[string]struct {
 		set:      func(config *CLIConfig, s string) (err error) { config.Github.Application.Pkey = s; return },
 	},
 }
+
+// ParsePluginsEndpoints parses the plugins endpoints, written like Kong maps
+// (`<url prefix>=<secret>;...`).
+func ParsePluginsEndpoints(s string) (map[string]string, error) {
+	endpoints := map[string]string{}
+	for _, entry := range strings.Split(s, ";") {
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid plugin endpoint '%s' (expected '<url prefix>=<secret>')", strings.SplitN(entry, "=", 2)[0])
+		}
+		endpoints[parts[0]] = parts[1]
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+
+	gh_quick_actions "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+// docsIncludeMarker is the line of the README template replaced by the
+// quick actions tables.
+const docsIncludeMarker = "<!-- ::include quick_actions_table -->\n"
+
+type (
+	// GenDocsCommand defines all fields used to generate the documentation.
+	GenDocsCommand struct {
+		Template string `name:"template" help:"README template path" default:"docs/README.tpl.md" type:"existingfile"`
+		List     string `name:"list" help:"Path of the list of quick actions to be developed or rejected" default:"docs/quick_actions.list.toml" type:"existingfile"`
+		Output   string `name:"output" short:"o" help:"Generated README path (stdout if empty)"`
+	}
+
+	// quickActionsList contains the quick actions not available yet,
+	// maintained by hand in the list file.
+	quickActionsList struct {
+		QuickActions struct {
+			NextReleases []struct {
+				QuickAction []string `toml:"quick_action"`
+				OnEvents    []string `toml:"on_events"`
+				Description string   `toml:"description"`
+			} `toml:"next_releases"`
+			Rejected []struct {
+				QuickAction []string `toml:"quick_action"`
+				Description string   `toml:"description"`
+				Reasons     []string `toml:"reasons"`
+			} `toml:"rejected"`
+		} `toml:"quick_actions"`
+	}
+)
+
+var blankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+
+// Run generates the README using the quick actions registered on the given
+// GithubQuickActions instance.
+func (c GenDocsCommand) Run(actions *gh_quick_actions.GithubQuickActions) error {
+	w := io.Writer(os.Stdout)
+	if c.Output != "" {
+		file, err := os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", c.Output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	return c.GenerateREADME(w, actions)
+}
+
+// GenerateREADME writes the README, built from the template, the registered
+// quick actions and the list of quick actions not available yet.
+func (c GenDocsCommand) GenerateREADME(w io.Writer, actions *gh_quick_actions.GithubQuickActions) error {
+	template, err := os.ReadFile(c.Template)
+	if err != nil {
+		return fmt.Errorf("failed to read README template: %w", err)
+	}
+	idx := strings.Index(string(template), docsIncludeMarker)
+	if idx < 0 {
+		return fmt.Errorf("no `%s` found in %s", strings.TrimSpace(docsIncludeMarker), c.Template)
+	}
+
+	var list quickActionsList
+	if _, err := toml.DecodeFile(c.List, &list); err != nil {
+		return fmt.Errorf("failed to read quick actions list: %w", err)
+	}
+
+	var tables []string
+
+	var rows [][]string
+	for _, info := range actions.QuickActions() {
+		description := info.Description
+		if info.Details != "" {
+			description += "\n_" + info.Details + "_"
+		}
+
+		var usages []string
+		for _, usage := range info.Usage {
+			usages = append(usages, "`"+usage+"`")
+		}
+		for _, alias := range info.Aliases {
+			usages = append(usages, "`/"+alias+"`")
+		}
+		var events []string
+		for _, event := range info.Events {
+			events = append(events, fmt.Sprintf("**&#10003;** `%s`", event))
+		}
+		rows = append(rows, []string{strings.Join(usages, "\n"), strings.Join(events, "\n"), description})
+	}
+	tables = append(tables, "## Available quick actions\n\n"+
+		"The following quick actions are already released and available on the Github application.\n\n"+
+		markdownTable([]string{"Command", "Applicable on", "Description"}, "clc", rows))
+
+	rows = nil
+	for _, entry := range list.QuickActions.NextReleases {
+		var events []string
+		for _, event := range entry.OnEvents {
+			events = append(events, fmt.Sprintf("**&#9676;** `%s`", event))
+		}
+		rows = append(rows, []string{codeLines(entry.QuickAction), strings.Join(events, "\n"), entry.Description})
+	}
+	tables = append(tables, "## Quick actions to be developed\n\n"+
+		"The following quick actions will be available in the future (must need times to develop them).\n\n"+
+		markdownTable([]string{"Command", "Applicable on", "Description"}, "clc", rows))
+
+	rows = nil
+	for _, entry := range list.QuickActions.Rejected {
+		rows = append(rows, []string{codeLines(entry.QuickAction), entry.Description, strings.Join(entry.Reasons, "\n")})
+	}
+	tables = append(tables, "## Quick actions that will not be developed\n\n"+
+		"The following quick actions will not be developed for specific reasons.\n\n"+
+		markdownTable([]string{"Command", "Description", "Reasons"}, "clc", rows))
+
+	readme := string(template[:idx]) + strings.Join(tables, "\n") + "\n" + string(template[idx+len(docsIncludeMarker):])
+	_, err = io.WriteString(w, blankLinesRegexp.ReplaceAllString(readme, "\n\n"))
+	return err
+}
+
+// codeLines formats all given items as inline code, one per line.
+func codeLines(items []string) string {
+	var codes []string
+	for _, item := range items {
+		codes = append(codes, "`"+item+"`")
+	}
+	return strings.Join(codes, "\n")
+}
+
+// markdownTable generates an aligned Markdown table; aligns contains the
+// alignment of each column (`c` for center, `l` for left).
+func markdownTable(header []string, aligns string, rows [][]string) string {
+	cells := make([][]string, len(rows))
+	widths := make([]int, len(header))
+	for i, title := range header {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for r, row := range rows {
+		for i, cell := range row {
+			cell = strings.TrimSpace(cell)
+			cell = strings.NewReplacer("|", "\\|", "\n", "<br>").Replace(cell)
+			cells[r] = append(cells[r], cell)
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	pad := func(text string, i int) string {
+		missing := widths[i] - utf8.RuneCountInString(text)
+		if aligns[i] == 'c' {
+			return strings.Repeat(" ", missing/2) + text + strings.Repeat(" ", missing-missing/2)
+		}
+		return text + strings.Repeat(" ", missing)
+	}
+	line := func(row []string) string {
+		var padded []string
+		for i, cell := range row {
+			padded = append(padded, pad(cell, i))
+		}
+		return "| " + strings.Join(padded, " | ") + " |\n"
+	}
+
+	var table strings.Builder
+	table.WriteString(line(header))
+	var separators []string
+	for i, width := range widths {
+		if aligns[i] == 'c' {
+			separators = append(separators, ":"+strings.Repeat("-", width-2)+":")
+		} else {
+			separators = append(separators, ":"+strings.Repeat("-", width-1))
+		}
+	}
+	table.WriteString("| " + strings.Join(separators, " | ") + " |\n")
+	for _, row := range cells {
+		table.WriteString(line(row))
+	}
+	return table.String()
+}
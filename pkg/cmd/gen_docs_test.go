@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gh_quick_actions "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+)
+
+func TestMarkdownTable(t *testing.T) {
+	table := markdownTable(
+		[]string{"Command", "Description"},
+		"cl",
+		[][]string{{"`/a|b`", "First line\nsecond line"}, {"`/c`", "Some text "}},
+	)
+
+	assert.Equal(t, ""+
+		"| Command | Description               |\n"+
+		"| :-----: | :------------------------ |\n"+
+		"| `/a\\|b` | First line<br>second line |\n"+
+		"|  `/c`   | Some text                 |\n",
+		table,
+	)
+}
+
+func TestGenDocsCommand_GenerateREADME(t *testing.T) {
+	dir := t.TempDir()
+	template := filepath.Join(dir, "README.tpl.md")
+	list := filepath.Join(dir, "quick_actions.list.toml")
+
+	require.NoError(t, os.WriteFile(template, []byte("# Title\n\n<!-- ::include quick_actions_table -->\n\n\n## Footer\n"), 0o600))
+	require.NoError(t, os.WriteFile(list, []byte(`
+[[quick_actions.next_releases]]
+quick_action = ["/close"]
+on_events = ["issue_comment"]
+description = "Close."
+
+[[quick_actions.rejected]]
+quick_action = ["/approve"]
+description = "Approve."
+reasons = ["Not possible."]
+`), 0o600))
+
+	actions := gh_quick_actions.NewGithubQuickActions(nil)
+	actions.AddQuickAction("cmd", describedQuickAction{})
+
+	var readme strings.Builder
+	require.NoError(t, GenDocsCommand{Template: template, List: list}.GenerateREADME(&readme, actions))
+	assert.Equal(t, "# Title\n\n"+
+		"## Available quick actions\n\n"+
+		"The following quick actions are already released and available on the Github application.\n\n"+
+		"|         Command          | Applicable on                |             Description              |\n"+
+		"| :----------------------: | :--------------------------- | :----------------------------------: |\n"+
+		"| `/cmd <arg>`<br>`/alias` | **&#10003;** `issue_comment` | Some description.<br>_Some details._ |\n\n"+
+		"## Quick actions to be developed\n\n"+
+		"The following quick actions will be available in the future (must need times to develop them).\n\n"+
+		"| Command  | Applicable on               | Description |\n"+
+		"| :------: | :-------------------------- | :---------: |\n"+
+		"| `/close` | **&#9676;** `issue_comment` |   Close.    |\n\n"+
+		"## Quick actions that will not be developed\n\n"+
+		"The following quick actions will not be developed for specific reasons.\n\n"+
+		"|  Command   | Description |    Reasons    |\n"+
+		"| :--------: | :---------- | :-----------: |\n"+
+		"| `/approve` | Approve.    | Not possible. |\n\n"+
+		"## Footer\n",
+		readme.String(),
+	)
+}
+
+// describedQuickAction implements a simple QuickAction with metadata
+type describedQuickAction struct{}
+
+func (describedQuickAction) TriggerOnEvents() []gh_quick_actions.EventType {
+	return []gh_quick_actions.EventType{gh_quick_actions.EventTypeIssueComment}
+}
+func (describedQuickAction) HandleCommand(*gh_quick_actions.EventContext, *gh_quick_actions.EventCommand) error {
+	return nil
+}
+func (describedQuickAction) Metadata() gh_quick_actions.Metadata {
+	return gh_quick_actions.Metadata{
+		Usage:       []string{"/cmd <arg>"},
+		Description: "Some description.",
+		Details:     "Some details.",
+		Aliases:     []string{"alias"},
+	}
+}
@@ -0,0 +1,555 @@
+package gh_quick_actions
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+)
+
+// ArgumentKind enumerates all kinds of arguments that can be declared by a
+// quick action.
+type ArgumentKind string
+
+const (
+	// ArgumentKindUser matches users, like `@octocat` (or `me`).
+	ArgumentKindUser ArgumentKind = "user"
+	// ArgumentKindTeam matches teams, like `@org/team`.
+	ArgumentKindTeam ArgumentKind = "team"
+	// ArgumentKindLabel matches labels, like `~bug` (the tilde is
+	// optional).
+	ArgumentKindLabel ArgumentKind = "label"
+	// ArgumentKindMilestone matches milestones, like `%v1.0` (the percent
+	// sign is optional); milestones must exist on the repository (see
+	// ResolveArguments).
+	ArgumentKindMilestone ArgumentKind = "milestone"
+	// ArgumentKindIssueRef matches issue references, like `#12`,
+	// `owner/repo#12` or an issue URL.
+	ArgumentKindIssueRef ArgumentKind = "issue-ref"
+	// ArgumentKindNumber matches positive numbers, like `2`.
+	ArgumentKindNumber ArgumentKind = "number"
+	// ArgumentKindDuration matches time tracking durations, like `30m`,
+	// `1d12h` or `-1h`; like on Gitlab, a day (`d`) lasts 8 hours, a
+	// week (`w`) 5 days and a month (`mo`) 4 weeks.
+	ArgumentKindDuration ArgumentKind = "duration"
+	// ArgumentKindDate matches dates, like `2026-11-01` (`on 2026-11-01`),
+	// `today`, `tomorrow` or delays like `in 2 weeks` (`2w`); unlike
+	// durations, delays use calendar days, weeks, months (`mo`) and years.
+	ArgumentKindDate ArgumentKind = "date"
+	// ArgumentKindText matches all remaining arguments, joined by spaces.
+	ArgumentKindText ArgumentKind = "text"
+)
+
+type (
+	// ArgumentsSchema is an optional interface implemented by quick actions
+	// in order to declare their arguments. Arguments are then validated and
+	// converted before HandleCommand is called (see EventCommand.Values).
+	ArgumentsSchema interface {
+		Arguments() []ArgumentSpec
+	}
+
+	// ArgumentSpec declares a quick action argument. Arguments are matched
+	// in order; optional arguments are skipped if the next argument doesn't
+	// match their kind.
+	ArgumentSpec struct {
+		Name     string
+		Kind     ArgumentKind
+		Required bool
+		// Variadic allows several consecutive values for this argument.
+		Variadic bool
+	}
+
+	// Argument is a validated and converted argument.
+	Argument struct {
+		// Raw is the argument as written by the user.
+		Raw string
+		// Value is the converted argument: the user login (or `me`), the
+		// team `org/team`, the label name, the milestone title or the text.
+		Value string
+
+		// Owner, Repo and Number identify the referenced issue (only for
+		// issue references); Number is also the converted number (only for
+		// numbers) and the milestone number (only for milestones, once
+		// resolved).
+		Owner  string
+		Repo   string
+		Number int
+
+		// Duration is the converted duration (only for durations).
+		Duration time.Duration
+
+		// Date is the converted date, in UTC (only for absolute dates);
+		// use Time to convert all dates.
+		Date time.Time
+		// delay is the converted delay (only for relative dates).
+		delay *dateDelay
+	}
+
+	// dateDelay is a delay from the event date, like `tomorrow` or `in 2
+	// weeks`.
+	dateDelay struct {
+		years, months, days int
+		duration            time.Duration
+		// midnight starts the delay from the beginning of the day.
+		midnight bool
+	}
+
+	// Arguments contains all validated arguments of a command, by name.
+	Arguments map[string][]Argument
+
+	// UsageError is returned when the arguments of a command don't match
+	// its schema.
+	UsageError struct {
+		Command string
+		Reason  string
+		Schema  []ArgumentSpec
+	}
+)
+
+var (
+	argumentUserRegexp         = regexp.MustCompile(`^@([A-Za-z\d](?:[A-Za-z\d]|-[A-Za-z\d])*)$`)
+	argumentTeamRegexp         = regexp.MustCompile(`^@([A-Za-z\d][\w-]*/[\w.-]+)$`)
+	argumentIssueRefRegexp     = regexp.MustCompile(`^(?:([\w.-]+)/([\w.-]+))?#(\d+)$`)
+	argumentIssueURLRegexp     = regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/(?:issues|pull)/(\d+)$`)
+	argumentNumberRegexp       = regexp.MustCompile(`^[1-9]\d*$`)
+	argumentDurationRegexp     = regexp.MustCompile(`^-?(\d+(mo|w|d|h|m))+$`)
+	argumentDurationPartRegexp = regexp.MustCompile(`(\d+)(mo|w|d|h|m)`)
+	argumentDelayRegexp        = regexp.MustCompile(`^(\d+)(mo|[mhdwy])$`)
+	argumentLongDelayRegexp    = regexp.MustCompile(`^in (\d+) (minute|hour|day|week|month|year)s?$`)
+)
+
+// durationUnits contains the time tracking units, from the largest to the
+// smallest.
+var durationUnits = []struct {
+	unit     string
+	duration time.Duration
+}{
+	{"mo", 4 * 5 * 8 * time.Hour},
+	{"w", 5 * 8 * time.Hour},
+	{"d", 8 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+}
+
+// dateUnits converts the units of relative dates to their delay.
+var dateUnits = map[string]func(n int) dateDelay{
+	"minute": func(n int) dateDelay { return dateDelay{duration: time.Duration(n) * time.Minute} },
+	"hour":   func(n int) dateDelay { return dateDelay{duration: time.Duration(n) * time.Hour} },
+	"day":    func(n int) dateDelay { return dateDelay{days: n} },
+	"week":   func(n int) dateDelay { return dateDelay{days: 7 * n} },
+	"month":  func(n int) dateDelay { return dateDelay{months: n} },
+	"year":   func(n int) dateDelay { return dateDelay{years: n} },
+}
+
+// dateShortUnits contains the short forms of the units of relative dates.
+var dateShortUnits = map[string]string{"m": "minute", "h": "hour", "d": "day", "w": "week", "mo": "month", "y": "year"}
+
+// maxDateArguments is the maximum number of arguments a date can be written
+// with (like `in 2 weeks`).
+const maxDateArguments = 3
+
+// Get returns the first value of the given argument.
+func (args Arguments) Get(name string) (Argument, bool) {
+	if len(args[name]) == 0 {
+		return Argument{}, false
+	}
+	return args[name][0], true
+}
+
+// Time returns the converted date; relative dates start from the given
+// date.
+func (arg Argument) Time(from time.Time) time.Time {
+	if arg.delay == nil {
+		return arg.Date
+	}
+
+	from = from.UTC()
+	if arg.delay.midnight {
+		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return from.AddDate(arg.delay.years, arg.delay.months, arg.delay.days).Add(arg.delay.duration)
+}
+
+// Values returns the converted values of the given argument.
+func (args Arguments) Values(name string) []string {
+	var values []string
+	for _, arg := range args[name] {
+		values = append(values, arg.Value)
+	}
+	return values
+}
+
+func (err *UsageError) Error() string {
+	return fmt.Sprintf("%s; usage: `%s`", err.Reason, Usage(err.Command, err.Schema))
+}
+
+// Usage generates the usage of the given command from its arguments schema,
+// like `/assign @user [@user...]`.
+func Usage(command string, schema []ArgumentSpec) string {
+	usage := []string{"/" + command}
+	for _, spec := range schema {
+		placeholder := spec.placeholder()
+		if spec.Variadic {
+			placeholder = fmt.Sprintf("%s [%s...]", placeholder, placeholder)
+		}
+		if !spec.Required {
+			placeholder = "[" + placeholder + "]"
+		}
+		usage = append(usage, placeholder)
+	}
+	return strings.Join(usage, " ")
+}
+
+// ValidateArguments validates and converts the command arguments if the
+// given quick action implements ArgumentsSchema; converted arguments are
+// stored in EventCommand.Values.
+func ValidateArguments(action QuickAction, command *EventCommand) error {
+	schema, valid := action.(ArgumentsSchema)
+	if !valid {
+		return nil
+	}
+
+	values, err := ParseArguments(command.Command, schema.Arguments(), command.Arguments, command.Payload)
+	if err != nil {
+		return err
+	}
+	command.Values = values
+	return nil
+}
+
+// ResolveArguments checks the validated arguments of the command against the
+// repository where the event comes from, if the given quick action
+// implements ArgumentsSchema: milestones must exist on the repository (open
+// or closed) and their number is set on the arguments.
+func ResolveArguments(ctx *EventContext, action QuickAction, command *EventCommand) error {
+	schema, valid := action.(ArgumentsSchema)
+	if !valid {
+		return nil
+	}
+
+	var milestones map[string]int
+	for _, spec := range schema.Arguments() {
+		if spec.Kind != ArgumentKindMilestone || len(command.Values[spec.Name]) == 0 {
+			continue
+		}
+
+		if milestones == nil {
+			var err error
+			if milestones, err = listMilestones(ctx, command.Payload); err != nil {
+				return err
+			}
+		}
+		for i, arg := range command.Values[spec.Name] {
+			number, exists := milestones[arg.Value]
+			if !exists {
+				return &UsageError{Command: command.Command, Reason: fmt.Sprintf("unknown milestone '%s'", arg.Value), Schema: schema.Arguments()}
+			}
+			command.Values[spec.Name][i].Number = number
+		}
+	}
+	return nil
+}
+
+// listMilestones returns the number of all milestones of the repository
+// where the event comes from, by title.
+func listMilestones(ctx *EventContext, payload EventPayload) (map[string]int, error) {
+	client, err := ctx.NewClient(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	milestones := map[string]int{}
+	opts := &github.MilestoneListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := client.Issues.ListMilestones(ctx, payload.RepositoryOwner(), payload.RepositoryName(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the milestones: %w", err)
+		}
+		for _, milestone := range page {
+			milestones[milestone.GetTitle()] = milestone.GetNumber()
+		}
+
+		if resp.NextPage == 0 {
+			return milestones, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ParseArguments validates and converts the given arguments using the given
+// schema; issue references without repository target the repository of the
+// given payload.
+func ParseArguments(command string, schema []ArgumentSpec, args []string, payload EventPayload) (Arguments, error) {
+	usageError := func(format string, a ...interface{}) error {
+		return &UsageError{Command: command, Reason: fmt.Sprintf(format, a...), Schema: schema}
+	}
+
+	values := Arguments{}
+	// NOTE: mismatch contains the first argument which didn't match the
+	//		 next one, in order to explain why it has been rejected
+	var mismatch *ArgumentSpec
+	for i, spec := range schema {
+		if spec.Kind == ArgumentKindText {
+			// NOTE: text followed by other arguments stops before the
+			//		 longest trailing arguments matching them, in order to
+			//		 allow unquoted text (like `/remind me check the release
+			//		 in 2 days`)
+			text, others := args, Arguments(nil)
+			for j := 1; j < len(args) && i < len(schema)-1; j++ {
+				if next, err := ParseArguments(command, schema[i+1:], args[j:], payload); err == nil {
+					text, others = args[:j], next
+					break
+				}
+			}
+
+			if len(text) > 0 {
+				values[spec.Name] = []Argument{{Raw: strings.Join(text, " "), Value: strings.Join(text, " ")}}
+				args = args[len(text):]
+			} else if spec.Required {
+				return nil, usageError("missing argument `%s`", spec.placeholder())
+			}
+			if others != nil {
+				for name, value := range others {
+					values[name] = value
+				}
+				return values, nil
+			}
+			continue
+		}
+
+		for len(args) > 0 {
+			arg, n, valid := matchArgument(spec.Kind, args, payload)
+			if !valid {
+				if mismatch == nil {
+					mismatch = &schema[i]
+				}
+				break
+			}
+			values[spec.Name] = append(values[spec.Name], arg)
+			args, mismatch = args[n:], nil
+
+			if !spec.Variadic {
+				break
+			}
+		}
+
+		if len(values[spec.Name]) == 0 && spec.Required {
+			if len(args) == 0 {
+				return nil, usageError("missing argument `%s`", spec.placeholder())
+			}
+			return nil, usageError("invalid %s '%s'", spec.Kind, args[0])
+		}
+	}
+
+	switch {
+	case len(args) > 0 && mismatch != nil:
+		return nil, usageError("invalid %s '%s'", mismatch.Kind, args[0])
+	case len(args) > 0:
+		return nil, usageError("unexpected argument '%s'", args[0])
+	}
+	return values, nil
+}
+
+// matchArgument converts the first arguments if they match the given kind
+// and returns how many of them are used; only dates can be written with
+// several arguments (like `in 2 weeks`).
+func matchArgument(kind ArgumentKind, args []string, payload EventPayload) (Argument, int, bool) {
+	if kind == ArgumentKindDate {
+		n := maxDateArguments
+		if len(args) < n {
+			n = len(args)
+		}
+		for ; n > 1; n-- {
+			if arg, valid := parseArgument(kind, strings.Join(args[:n], " "), payload); valid {
+				return arg, n, true
+			}
+		}
+	}
+
+	arg, valid := parseArgument(kind, args[0], payload)
+	return arg, 1, valid
+}
+
+// parseArgument converts the given argument if it matches the given kind.
+func parseArgument(kind ArgumentKind, raw string, payload EventPayload) (Argument, bool) {
+	arg := Argument{Raw: raw}
+
+	switch kind {
+	case ArgumentKindUser:
+		if raw == "me" {
+			arg.Value = raw
+			return arg, true
+		}
+		match := argumentUserRegexp.FindStringSubmatch(raw)
+		if match == nil {
+			return arg, false
+		}
+		arg.Value = match[1]
+	case ArgumentKindTeam:
+		match := argumentTeamRegexp.FindStringSubmatch(raw)
+		if match == nil {
+			return arg, false
+		}
+		arg.Value = match[1]
+	case ArgumentKindLabel:
+		value := strings.TrimPrefix(raw, "~")
+		if value == "" || strings.ContainsAny(value[:1], "@#%") {
+			return arg, false
+		}
+		arg.Value = value
+	case ArgumentKindMilestone:
+		value := strings.TrimPrefix(raw, "%")
+		if value == "" || strings.ContainsAny(value[:1], "@#~") {
+			return arg, false
+		}
+		arg.Value = value
+	case ArgumentKindIssueRef:
+		var owner, repo string
+		if payload != nil {
+			owner, repo = payload.RepositoryOwner(), payload.RepositoryName()
+		}
+		return ParseIssueReference(raw, owner, repo)
+	case ArgumentKindNumber:
+		if !argumentNumberRegexp.MatchString(raw) {
+			return arg, false
+		}
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return arg, false
+		}
+		arg.Value, arg.Number = raw, number
+	case ArgumentKindDuration:
+		if !argumentDurationRegexp.MatchString(raw) {
+			return arg, false
+		}
+		for _, part := range argumentDurationPartRegexp.FindAllStringSubmatch(raw, -1) {
+			n, _ := strconv.Atoi(part[1])
+			for _, unit := range durationUnits {
+				if unit.unit == part[2] {
+					arg.Duration += time.Duration(n) * unit.duration
+				}
+			}
+		}
+		if strings.HasPrefix(raw, "-") {
+			arg.Duration = -arg.Duration
+		}
+		arg.Value = raw
+	case ArgumentKindDate:
+		return ParseDate(raw)
+	case ArgumentKindText:
+		arg.Value = raw
+	default:
+		return arg, false
+	}
+	return arg, true
+}
+
+// ParseDate converts dates, like `2026-11-01`, `tomorrow` or `in 2 weeks`
+// (see ArgumentKindDate); use Argument.Time to get the date.
+func ParseDate(raw string) (Argument, bool) {
+	arg := Argument{Raw: raw, Value: raw}
+
+	date, delay, valid := parseDate(strings.ToLower(strings.TrimSpace(raw)))
+	if !valid {
+		return arg, false
+	}
+	arg.Date, arg.delay = date, delay
+	return arg, true
+}
+
+// parseDate converts the given absolute date, or returns the delay of the
+// given relative one.
+func parseDate(value string) (time.Time, *dateDelay, bool) {
+	switch value {
+	case "today":
+		return time.Time{}, &dateDelay{midnight: true}, true
+	case "tomorrow":
+		return time.Time{}, &dateDelay{days: 1, midnight: true}, true
+	}
+	if date, err := time.Parse("2006-01-02", strings.TrimPrefix(value, "on ")); err == nil {
+		return date, nil, true
+	}
+
+	match := argumentLongDelayRegexp.FindStringSubmatch(value)
+	if short := argumentDelayRegexp.FindStringSubmatch(value); short != nil {
+		match = []string{short[0], short[1], dateShortUnits[short[2]]}
+	}
+	if match == nil {
+		return time.Time{}, nil, false
+	}
+
+	n, err := strconv.Atoi(match[1])
+	if err != nil || n <= 0 {
+		return time.Time{}, nil, false
+	}
+	delay := dateUnits[match[2]](n)
+	return time.Time{}, &delay, true
+}
+
+// ParseIssueReference converts issue references, like `#12`, `owner/repo#12`
+// or an issue URL; references without repository belong to the given one.
+func ParseIssueReference(raw string, owner, repo string) (Argument, bool) {
+	arg := Argument{Raw: raw}
+
+	match := argumentIssueRefRegexp.FindStringSubmatch(raw)
+	if match == nil {
+		match = argumentIssueURLRegexp.FindStringSubmatch(raw)
+	}
+	if match == nil {
+		return arg, false
+	}
+
+	number, err := strconv.Atoi(match[3])
+	if err != nil {
+		return arg, false
+	}
+	arg.Owner, arg.Repo, arg.Number = match[1], match[2], number
+	if arg.Owner == "" {
+		arg.Owner, arg.Repo = owner, repo
+	}
+	arg.Value = fmt.Sprintf("%s/%s#%d", arg.Owner, arg.Repo, arg.Number)
+	return arg, true
+}
+
+// FormatDuration formats the given duration like a time tracking duration
+// (see ArgumentKindDuration), like `1d 4h`.
+func FormatDuration(duration time.Duration) string {
+	if duration < time.Minute && duration > -time.Minute {
+		return "0m"
+	}
+
+	sign := ""
+	if duration < 0 {
+		sign, duration = "-", -duration
+	}
+
+	var parts []string
+	for _, unit := range durationUnits {
+		if duration >= unit.duration {
+			parts = append(parts, fmt.Sprintf("%d%s", duration/unit.duration, unit.unit))
+			duration %= unit.duration
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// placeholder returns the placeholder used in usages for this argument.
+func (spec ArgumentSpec) placeholder() string {
+	switch spec.Kind {
+	case ArgumentKindUser:
+		return "@" + spec.Name
+	case ArgumentKindTeam:
+		return "@org/" + spec.Name
+	case ArgumentKindLabel:
+		return "~" + spec.Name
+	case ArgumentKindMilestone:
+		return "%" + spec.Name
+	case ArgumentKindIssueRef:
+		return "#" + spec.Name
+	default:
+		return "<" + spec.Name + ">"
+	}
+}
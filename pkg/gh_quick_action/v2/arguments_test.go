@@ -0,0 +1,244 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsage(t *testing.T) {
+	schema := []ArgumentSpec{
+		{Name: "user", Kind: ArgumentKindUser, Required: true, Variadic: true},
+		{Name: "team", Kind: ArgumentKindTeam},
+		{Name: "label", Kind: ArgumentKindLabel, Variadic: true},
+		{Name: "milestone", Kind: ArgumentKindMilestone},
+		{Name: "issue", Kind: ArgumentKindIssueRef},
+		{Name: "n", Kind: ArgumentKindNumber},
+		{Name: "duration", Kind: ArgumentKindDuration, Required: true},
+		{Name: "reason", Kind: ArgumentKindText},
+	}
+
+	assert.Equal(t,
+		"/cmd @user [@user...] [@org/team] [~label [~label...]] [%milestone] [#issue] [<n>] <duration> [<reason>]",
+		Usage("cmd", schema),
+	)
+}
+
+func TestParseArguments(t *testing.T) {
+	payload := mockEventPayload{repoOwner: "xunleii", repoName: "github-quick-actions"}
+	schema := []ArgumentSpec{
+		{Name: "user", Kind: ArgumentKindUser, Required: true, Variadic: true},
+		{Name: "label", Kind: ArgumentKindLabel, Variadic: true},
+		{Name: "issue", Kind: ArgumentKindIssueRef},
+		{Name: "n", Kind: ArgumentKindNumber},
+		{Name: "duration", Kind: ArgumentKindDuration},
+		{Name: "reason", Kind: ArgumentKindText},
+	}
+	usage := "; usage: `/cmd @user [@user...] [~label [~label...]] [#issue] [<n>] [<duration>] [<reason>]`"
+
+	ts := map[string]struct {
+		args   []string
+		values Arguments
+		err    string
+	}{
+		"required only": {
+			args:   []string{"@octocat", "me"},
+			values: Arguments{"user": {{Raw: "@octocat", Value: "octocat"}, {Raw: "me", Value: "me"}}},
+		},
+		"all arguments": {
+			args: []string{"@octocat", "~bug", "feature", "#12", "2", "-1mo2w", "waiting", "for", "upstream"},
+			values: Arguments{
+				"user":     {{Raw: "@octocat", Value: "octocat"}},
+				"label":    {{Raw: "~bug", Value: "bug"}, {Raw: "feature", Value: "feature"}},
+				"issue":    {{Raw: "#12", Value: "xunleii/github-quick-actions#12", Owner: "xunleii", Repo: "github-quick-actions", Number: 12}},
+				"n":        {{Raw: "2", Value: "2", Number: 2}},
+				"duration": {{Raw: "-1mo2w", Value: "-1mo2w", Duration: -240 * time.Hour}},
+				"reason":   {{Raw: "waiting for upstream", Value: "waiting for upstream"}},
+			},
+		},
+		"skipped optional arguments": {
+			args: []string{"@octocat", "#3"},
+			values: Arguments{
+				"user":  {{Raw: "@octocat", Value: "octocat"}},
+				"issue": {{Raw: "#3", Value: "xunleii/github-quick-actions#3", Owner: "xunleii", Repo: "github-quick-actions", Number: 3}},
+			},
+		},
+		"missing required argument": {
+			args: nil,
+			err:  "missing argument `@user`" + usage,
+		},
+		"invalid required argument": {
+			args: []string{"octocat"},
+			err:  "invalid user 'octocat'" + usage,
+		},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			values, err := ParseArguments("cmd", schema, tc.args, payload)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.IsType(t, &UsageError{}, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.values, values)
+		})
+	}
+}
+
+func TestParseArguments_unexpected(t *testing.T) {
+	schema := []ArgumentSpec{{Name: "issue", Kind: ArgumentKindIssueRef, Required: true}}
+
+	values, err := ParseArguments("cmd", schema, []string{"https://github.com/octocat/hello-world/pull/3"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, Arguments{"issue": {{Raw: "https://github.com/octocat/hello-world/pull/3", Value: "octocat/hello-world#3", Owner: "octocat", Repo: "hello-world", Number: 3}}}, values)
+
+	_, err = ParseArguments("cmd", schema, []string{"#1", "#2"}, nil)
+	assert.EqualError(t, err, "unexpected argument '#2'; usage: `/cmd #issue`")
+
+	schema = []ArgumentSpec{{Name: "label", Kind: ArgumentKindLabel, Variadic: true}}
+	_, err = ParseArguments("cmd", schema, []string{"~bug", "@octocat"}, nil)
+	assert.EqualError(t, err, "invalid label '@octocat'; usage: `/cmd [~label [~label...]]`")
+}
+
+func TestParseArguments_date(t *testing.T) {
+	from := time.Date(2026, 10, 19, 15, 4, 5, 0, time.UTC)
+	schema := []ArgumentSpec{
+		{Name: "user", Kind: ArgumentKindUser, Required: true},
+		{Name: "message", Kind: ArgumentKindText, Required: true},
+		{Name: "date", Kind: ArgumentKindDate, Required: true},
+	}
+
+	ts := map[string]struct {
+		args    []string
+		message string
+		date    time.Time
+		err     string
+	}{
+		"absolute date":  {args: []string{"me", "check", "on", "2026-11-01"}, message: "check", date: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		"relative date":  {args: []string{"me", "check the release", "in", "2", "weeks"}, message: "check the release", date: from.AddDate(0, 0, 14)},
+		"short delay":    {args: []string{"me", "check", "1mo"}, message: "check", date: from.AddDate(0, 1, 0)},
+		"tomorrow":       {args: []string{"me", "check", "tomorrow"}, message: "check", date: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		"unquoted text":  {args: []string{"me", "check", "in", "2", "days", "in", "3", "hours"}, message: "check in 2 days", date: from.Add(3 * time.Hour)},
+		"missing date":   {args: []string{"me", "check", "in", "3", "dayz"}, err: "missing argument `<date>`; usage: `/cmd @user <message> <date>`"},
+		"missing text":   {args: []string{"me", "tomorrow"}, err: "missing argument `<date>`; usage: `/cmd @user <message> <date>`"},
+		"negative delay": {args: []string{"me", "check", "in", "0", "days"}, err: "missing argument `<date>`; usage: `/cmd @user <message> <date>`"},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			values, err := ParseArguments("cmd", schema, tc.args, nil)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			message, _ := values.Get("message")
+			date, _ := values.Get("date")
+			assert.Equal(t, tc.message, message.Value)
+			assert.Equal(t, tc.date, date.Time(from))
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0m", FormatDuration(0))
+	assert.Equal(t, "1d 4h", FormatDuration(12*time.Hour))
+	assert.Equal(t, "1mo 1w 1m", FormatDuration(200*time.Hour+time.Minute))
+	assert.Equal(t, "-1h 30m", FormatDuration(-90*time.Minute))
+}
+
+func TestParseArgument(t *testing.T) {
+	ts := map[ArgumentKind]map[string]bool{
+		ArgumentKindUser:      {"@octocat": true, "@octo-cat": true, "me": true, "octocat": false, "@-octocat": false, "@org/team": false, "@": false},
+		ArgumentKindTeam:      {"@org/team": true, "@org/team-a.b": true, "@octocat": false, "org/team": false},
+		ArgumentKindLabel:     {"~bug": true, "bug": true, "~bug:critical": true, "~": false, "@bug": false, "#bug": false, "%bug": false},
+		ArgumentKindMilestone: {"%v1.0": true, "v1.0": true, "%": false, "~v1.0": false, "@v1.0": false, "#1": false},
+		ArgumentKindIssueRef:  {"#1": true, "octocat/hello-world#1": true, "https://github.com/octocat/hello-world/issues/1": true, "1": false, "#issue": false},
+		ArgumentKindNumber:    {"1": true, "12": true, "0": false, "-1": false, "01": false, "a": false},
+		ArgumentKindDuration:  {"30m": true, "1w2d3h4m": true, "2mo": true, "-1h": true, "1.5h": false, "h": false, "1y": false},
+		ArgumentKindDate:      {"2026-11-01": true, "on 2026-11-01": true, "today": true, "Tomorrow": true, "in 2 weeks": true, "in 1 month": true, "12h": true, "1mo": true, "2026-13-01": false, "in 2": false, "0d": false},
+		ArgumentKindText:      {"anything": true},
+	}
+
+	for kind, args := range ts {
+		for arg, valid := range args {
+			kind, arg, valid := kind, arg, valid
+			t.Run(string(kind)+"/"+arg, func(t *testing.T) {
+				_, ok := parseArgument(kind, arg, nil)
+				assert.Equal(t, valid, ok)
+			})
+		}
+	}
+}
+
+func TestValidateArguments(t *testing.T) {
+	command := &EventCommand{Command: "cmd", Arguments: []string{"@octocat"}}
+
+	require.NoError(t, ValidateArguments(mockQuickAction{}, command))
+	assert.Nil(t, command.Values)
+
+	action := mockQuickActionWithArguments{schema: []ArgumentSpec{{Name: "user", Kind: ArgumentKindUser, Required: true}}}
+	require.NoError(t, ValidateArguments(action, command))
+	assert.Equal(t, Arguments{"user": {{Raw: "@octocat", Value: "octocat"}}}, command.Values)
+
+	command.Arguments = []string{"octocat"}
+	assert.EqualError(t, ValidateArguments(action, command), "invalid user 'octocat'; usage: `/cmd @user`")
+}
+
+func TestResolveArguments(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.String())
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, "http://"+r.Host, r.URL.Path))
+			_, _ = w.Write([]byte(`[{"number": 1, "title": "v1.0"}]`))
+		default:
+			_, _ = w.Write([]byte(`[{"number": 3, "title": "v2.0"}]`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	payload, err := PayloadFactory(EventTypeIssueComment, permissionPayload("octocat", "mojombo", "/cmd"))
+	require.NoError(t, err)
+	ctx := &EventContext{Context: context.TODO(), ClientCreator: mockAppClientCreator{srv: srv}}
+	action := mockQuickActionWithArguments{schema: []ArgumentSpec{{Name: "milestone", Kind: ArgumentKindMilestone, Required: true}}}
+
+	command := &EventCommand{Command: "cmd", Arguments: []string{"%v2.0"}, Payload: payload}
+	require.NoError(t, ValidateArguments(action, command))
+	require.NoError(t, ResolveArguments(ctx, action, command))
+	assert.Equal(t, Arguments{"milestone": {{Raw: "%v2.0", Value: "v2.0", Number: 3}}}, command.Values)
+	assert.Equal(t, []string{
+		"GET /repos/xunleii/github-quick-actions/milestones?per_page=100&state=all",
+		"GET /repos/xunleii/github-quick-actions/milestones?page=2&per_page=100&state=all",
+	}, requests)
+
+	command = &EventCommand{Command: "cmd", Arguments: []string{"v3.0"}, Payload: payload}
+	require.NoError(t, ValidateArguments(action, command))
+	assert.EqualError(t, ResolveArguments(ctx, action, command), "unknown milestone 'v3.0'; usage: `/cmd %milestone`")
+
+	// NOTE: commands without milestone don't call the Github API
+	requests = nil
+	require.NoError(t, ResolveArguments(ctx, mockQuickAction{}, command))
+	assert.Empty(t, requests)
+}
+
+// mockQuickActionWithArguments implements a simple QuickAction with an
+// arguments schema
+type mockQuickActionWithArguments struct {
+	mockQuickAction
+	schema []ArgumentSpec
+}
+
+func (m mockQuickActionWithArguments) Arguments() []ArgumentSpec { return m.schema }
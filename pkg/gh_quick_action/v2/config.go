@@ -0,0 +1,541 @@
+package gh_quick_actions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"github.com/thoas/go-funk"
+	"gopkg.in/yaml.v3"
+)
+
+// RepositoryConfigPath is the path of the configuration file inside
+// repositories.
+const RepositoryConfigPath = ".github/quick-actions.yml"
+
+// OrganizationConfigRepository and OrganizationConfigPath locate the default
+// configuration of all repositories of an organization (or a user).
+const (
+	OrganizationConfigRepository = ".github"
+	OrganizationConfigPath       = "quick-actions.yml"
+)
+
+// defaultPrefix is the prefix of commands if none is configured.
+const defaultPrefix = "/"
+
+// maxCachedConfigs is the maximum number of parsed configurations kept in
+// memory; the cache is flushed once reached.
+const maxCachedConfigs = 1024
+
+type (
+	// RepositoryConfig defines how quick actions behave on a repository;
+	// it is read from the RepositoryConfigPath file of the default branch,
+	// merged with the organization configuration (see
+	// OrganizationConfigPath) unless `inherit: false` is set.
+	// A nil RepositoryConfig means that the default behaviour is used.
+	RepositoryConfig struct {
+		// Inherit defines if the organization configuration is used as
+		// default (true if not set).
+		Inherit *bool `yaml:"inherit,omitempty"`
+		// Prefix is the prefix of all commands (`/` by default).
+		Prefix string `yaml:"prefix,omitempty"`
+		// Enabled lists the commands enabled even if they are listed in
+		// Disabled.
+		Enabled []string `yaml:"enabled,omitempty"`
+		// Disabled lists the commands which cannot be run on the
+		// repository; `*` disables all commands not listed in Enabled.
+		Disabled []string `yaml:"disabled,omitempty"`
+		// Aliases defines other names for existing commands, like
+		// `dup: duplicate`.
+		Aliases map[string]string `yaml:"aliases,omitempty"`
+		// Permissions overrides the minimum permission required to run
+		// commands (see Restricted).
+		Permissions map[string]Permission `yaml:"permissions,omitempty"`
+		// Options contains the options of each command (see
+		// Configurable).
+		Options map[string]map[string]interface{} `yaml:"options,omitempty"`
+		// Macros defines commands composed of other commands, like
+		// `triage-bug: [/label ~bug, /assign $author]`; steps can use the
+		// macro arguments with `$1`, `$2`, ... or `$@` and the author of
+		// the issue or pull request with `$author`.
+		Macros map[string][]string `yaml:"macros,omitempty"`
+		// Plugins defines commands run by external HTTP services (see
+		// Plugin).
+		Plugins map[string]*Plugin `yaml:"plugins,omitempty"`
+
+		// Sources lists the files the configuration comes from.
+		Sources []string `yaml:"-"`
+	}
+
+	// Configurable is an optional interface implemented by quick actions
+	// accepting per-repository options.
+	Configurable interface {
+		// Options returns a pointer to a new options structure, filled with
+		// the default values; the repository options are decoded into it
+		// and given to the quick action through EventCommand.Options.
+		Options() interface{}
+	}
+
+	// configCache contains the parsed configurations, indexed by the SHA
+	// of the configuration files.
+	configCache struct {
+		sync.Mutex
+		entries map[string]configCacheEntry
+	}
+	configCacheEntry struct {
+		config *RepositoryConfig
+		err    error
+	}
+
+	// configFile is a configuration file fetched from a repository.
+	configFile struct {
+		// name identifies the file in errors, like `.github/quick-actions.yml`.
+		name    string
+		sha     string
+		content []byte
+	}
+)
+
+// EnableRepositoryConfig enables the per-repository configuration files
+// (see RepositoryConfig).
+func (a *GithubQuickActions) EnableRepositoryConfig() {
+	a.configs = &configCache{entries: map[string]configCacheEntry{}}
+}
+
+// repositoryConfig fetches and validates the configuration of the repository
+// of the given event, merged with the organization one if the repository
+// inherits from it. It returns nil if there is no configuration file or if
+// repository configurations are disabled.
+func (a GithubQuickActions) repositoryConfig(ctx *EventContext, payload EventPayload) (*RepositoryConfig, error) {
+	if a.configs == nil {
+		return nil, nil
+	}
+
+	client, err := ctx.NewClient(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: configuration files are fetched on every event, but the
+	//		 client creator caches HTTP responses (see httpcache), turning
+	//		 these calls into conditional requests; the configuration
+	//		 itself is only parsed once per files SHA
+	owner := payload.RepositoryOwner()
+	repoFile, err := fetchConfigFile(ctx, client, owner, payload.RepositoryName(), RepositoryConfigPath, RepositoryConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*configFile
+	if repoFile == nil || repoFile.inherits() {
+		name := fmt.Sprintf("%s/%s:%s", owner, OrganizationConfigRepository, OrganizationConfigPath)
+		orgFile, err := fetchConfigFile(ctx, client, owner, OrganizationConfigRepository, OrganizationConfigPath, name)
+		if err != nil {
+			return nil, err
+		}
+		if orgFile != nil {
+			files = append(files, orgFile)
+		}
+	}
+	if repoFile != nil {
+		files = append(files, repoFile)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	var shas []string
+	for _, file := range files {
+		shas = append(shas, file.sha)
+	}
+	key := strings.Join(shas, "+")
+
+	a.configs.Lock()
+	entry, cached := a.configs.entries[key]
+	a.configs.Unlock()
+	if cached {
+		zerolog.Ctx(ctx).Trace().Msgf("use cached configuration (sha: %s)", key)
+		return entry.config, entry.err
+	}
+
+	entry.config, entry.err = a.parseConfigFiles(files...)
+
+	a.configs.Lock()
+	if len(a.configs.entries) >= maxCachedConfigs {
+		a.configs.entries = map[string]configCacheEntry{}
+	}
+	a.configs.entries[key] = entry
+	a.configs.Unlock()
+
+	return entry.config, entry.err
+}
+
+// fetchConfigFile fetches the given configuration file from the default
+// branch of the repository; it returns nil if the file doesn't exist or
+// can't be read by the application.
+func fetchConfigFile(ctx *EventContext, client *github.Client, owner, repo, path, name string) (*configFile, error) {
+	file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, nil)
+	// NOTE: installations without the `contents: read` permission (or
+	//		 without access to the organization .github repository) get
+	//		 a 403; they just use the default configuration
+	if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", name, err)
+	} else if file == nil {
+		return nil, fmt.Errorf("failed to fetch %s: not a file", name)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return &configFile{name: name, sha: file.GetSHA(), content: []byte(content)}, nil
+}
+
+// inherits returns true if the configuration file doesn't opt out from the
+// organization configuration.
+func (file configFile) inherits() bool {
+	var config struct {
+		Inherit *bool `yaml:"inherit"`
+	}
+	// NOTE: invalid files are reported when parsed
+	_ = yaml.Unmarshal(file.content, &config)
+	return config.Inherit == nil || *config.Inherit
+}
+
+// ParseRepositoryConfig parses the given configuration file and validates
+// it against the registered quick actions.
+func (a GithubQuickActions) ParseRepositoryConfig(content []byte) (*RepositoryConfig, error) {
+	return a.parseConfigFiles(&configFile{name: RepositoryConfigPath, content: content})
+}
+
+// parseConfigFiles parses and validates each given configuration file, then
+// merges them in order: lists are appended and maps are deeply merged, other
+// values (and macros or plugins) being overridden.
+func (a GithubQuickActions) parseConfigFiles(files ...*configFile) (*RepositoryConfig, error) {
+	var names []string
+	merged := map[string]interface{}{}
+	// NOTE: files can refer to the macros and plugins of the previous ones
+	inherited := &RepositoryConfig{Macros: map[string][]string{}, Plugins: map[string]*Plugin{}}
+	for _, file := range files {
+		names = append(names, file.name)
+
+		config := &RepositoryConfig{}
+		decoder := yaml.NewDecoder(bytes.NewReader(file.content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid %s: %s", file.name, strings.TrimPrefix(err.Error(), "yaml: "))
+		}
+		if err := a.validateRepositoryConfig(config, inherited); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", file.name, err)
+		}
+		for name, steps := range config.Macros {
+			inherited.Macros[name] = steps
+		}
+		for name, plugin := range config.Plugins {
+			inherited.Plugins[name] = plugin
+		}
+
+		var raw map[string]interface{}
+		_ = yaml.Unmarshal(file.content, &raw)
+		delete(raw, "inherit")
+		merged = mergeConfig(merged, overrideDefinitions(merged, raw, "macros", "plugins"))
+	}
+
+	content, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	config := &RepositoryConfig{Sources: names}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", strings.Join(names, " + "), strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+	if err := a.validateRepositoryConfig(config, nil); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", strings.Join(names, " + "), err)
+	}
+	if err := a.validateMacros(config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", strings.Join(names, " + "), err)
+	}
+	return config, nil
+}
+
+// mergeConfig merges the override configuration into the base one: lists are
+// appended, maps are deeply merged and other values are overridden.
+func mergeConfig(base, override map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range override {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if previous, valid := merged[key].(map[string]interface{}); valid {
+				merged[key] = mergeConfig(previous, value)
+				continue
+			}
+		case []interface{}:
+			if previous, valid := merged[key].([]interface{}); valid {
+				merged[key] = append(append([]interface{}{}, previous...), value...)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// overrideDefinitions removes from the base configuration the definitions
+// (like macros) redefined by the override one, in order to replace them
+// instead of merging them.
+func overrideDefinitions(base, override map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		definitions, valid := override[key].(map[string]interface{})
+		inherited, inherits := base[key].(map[string]interface{})
+		if !valid || !inherits {
+			continue
+		}
+
+		kept := map[string]interface{}{}
+		for name, definition := range inherited {
+			if _, redefined := definitions[name]; !redefined {
+				kept[name] = definition
+			}
+		}
+		base[key] = kept
+	}
+	return override
+}
+
+// YAML returns the configuration in YAML.
+func (c *RepositoryConfig) YAML() (string, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	config := c
+	if config == nil {
+		config = &RepositoryConfig{}
+	}
+	if err := encoder.Encode(config); err != nil {
+		return "", err
+	}
+	return buffer.String(), encoder.Close()
+}
+
+// validateRepositoryConfig checks that the given configuration only refers
+// to registered quick actions, or to macros and plugins defined by itself or
+// by the inherited configuration (if any), with valid values.
+func (a GithubQuickActions) validateRepositoryConfig(config, inherited *RepositoryConfig) error {
+	errs := &multierror.Error{}
+	errs.ErrorFormat = configErrorFormat
+
+	if config.Prefix != "" && strings.IndexFunc(config.Prefix, unicode.IsSpace) >= 0 {
+		errs = multierror.Append(errs, fmt.Errorf("prefix: '%s' must not contain spaces", config.Prefix))
+	}
+
+	for _, command := range config.Enabled {
+		if !a.isCommand(command, config, inherited) {
+			errs = multierror.Append(errs, fmt.Errorf("enabled: unknown command '%s'", command))
+		}
+	}
+	for _, command := range config.Disabled {
+		if !a.isCommand(command, config, inherited) && command != "*" {
+			errs = multierror.Append(errs, fmt.Errorf("disabled: unknown command '%s'", command))
+		}
+	}
+
+	for _, alias := range sortedKeys(config.Aliases) {
+		command := config.Aliases[alias]
+		if _, exists := a.quickAction(alias); exists {
+			errs = multierror.Append(errs, fmt.Errorf("aliases.%s: command '%s' already exists", alias, alias))
+		} else if strings.IndexFunc(alias, unicode.IsSpace) >= 0 || alias == "" {
+			errs = multierror.Append(errs, fmt.Errorf("aliases.%s: invalid alias name", alias))
+		}
+		if !a.isCommand(command, config, inherited) {
+			errs = multierror.Append(errs, fmt.Errorf("aliases.%s: unknown command '%s'", alias, command))
+		}
+	}
+
+	for _, command := range sortedKeys(config.Permissions) {
+		if _, exists := a.quickAction(command); !exists {
+			errs = multierror.Append(errs, fmt.Errorf("permissions.%s: unknown command '%s'", command, command))
+		}
+		if _, valid := permissionLevels[config.Permissions[command]]; !valid {
+			errs = multierror.Append(errs, fmt.Errorf("permissions.%s: invalid permission '%s' (expected none, read, triage, write, maintain or admin)", command, config.Permissions[command]))
+		}
+	}
+
+	for _, command := range sortedKeys(config.Options) {
+		action, exists := a.quickAction(command)
+		if !exists {
+			errs = multierror.Append(errs, fmt.Errorf("options.%s: unknown command '%s'", command, command))
+			continue
+		}
+		if _, err := config.options(command, action); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("options.%s: %w", command, err))
+		}
+	}
+
+	if err := a.validatePlugins(config); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	return errs.ErrorOrNil()
+}
+
+// isCommand returns true if the given command is a registered quick action,
+// or a macro or a plugin of one of the given configurations.
+func (a GithubQuickActions) isCommand(command string, configs ...*RepositoryConfig) bool {
+	if _, exists := a.quickAction(command); exists {
+		return true
+	}
+	for _, config := range configs {
+		if _, isMacro := config.macro(command); isMacro {
+			return true
+		}
+		if config != nil && config.Plugins[command] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// quickAction returns the quick action registered for the given command,
+// whatever the event type.
+func (a GithubQuickActions) quickAction(command string) (QuickAction, bool) {
+	for _, actions := range a.registry {
+		if action, exists := actions[command]; exists {
+			return action, true
+		}
+	}
+	return nil, false
+}
+
+// CommandPrefix returns the prefix of all commands.
+func (c *RepositoryConfig) CommandPrefix() string {
+	if c == nil || c.Prefix == "" {
+		return defaultPrefix
+	}
+	return c.Prefix
+}
+
+// IsEnabled returns true if the given command can be run on the repository.
+func (c *RepositoryConfig) IsEnabled(command string) bool {
+	if c == nil {
+		return true
+	}
+
+	for _, enabled := range c.Enabled {
+		if enabled == command {
+			return true
+		}
+	}
+	for _, disabled := range c.Disabled {
+		if disabled == command || disabled == "*" {
+			return false
+		}
+	}
+	return true
+}
+
+// isOptedIn returns true if the given command is explicitly enabled on the
+// repository.
+func (c *RepositoryConfig) isOptedIn(command string) bool {
+	if c == nil {
+		return false
+	}
+
+	for _, enabled := range c.Enabled {
+		if enabled == command {
+			return true
+		}
+	}
+	return false
+}
+
+// Alias returns the command of the given repository alias, if defined.
+func (c *RepositoryConfig) Alias(name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	command, exists := c.Aliases[name]
+	return command, exists
+}
+
+// restriction returns the restriction of the given command, overridden by
+// the configured permission if any.
+func (c *RepositoryConfig) restriction(command string, restriction Restriction) Restriction {
+	if c == nil {
+		return restriction
+	}
+	if permission, exists := c.Permissions[command]; exists {
+		restriction.Permission = permission
+	}
+	return restriction
+}
+
+// options returns the options of the given quick action, decoded from the
+// configuration; it returns nil if the quick action doesn't implement
+// Configurable.
+func (c *RepositoryConfig) options(command string, action QuickAction) (interface{}, error) {
+	configurable, valid := action.(Configurable)
+	var raw map[string]interface{}
+	if c != nil {
+		raw = c.Options[command]
+	}
+
+	switch {
+	case !valid && raw != nil:
+		return nil, fmt.Errorf("command '%s' has no options", command)
+	case !valid:
+		return nil, nil
+	}
+
+	options := configurable.Options()
+	if raw == nil {
+		return options, nil
+	}
+
+	content, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(options); err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+
+	if validator, valid := options.(interface{ Validate() error }); valid {
+		if err := validator.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return options, nil
+}
+
+// configErrorFormat formats configuration errors as a Markdown list.
+func configErrorFormat(errs []error) string {
+	var lines []string
+	for _, err := range errs {
+		lines = append(lines, "- "+err.Error())
+	}
+	return fmt.Sprintf("%d error(s) found\n%s", len(errs), strings.Join(lines, "\n"))
+}
+
+// sortedKeys returns the keys of the given map, sorted.
+func sortedKeys(m interface{}) []string {
+	keys, _ := funk.Keys(m).([]string)
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,353 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConfigTestQuickActions() *GithubQuickActions {
+	qa := NewGithubQuickActions(nil)
+	qa.AddQuickAction("label", &mockConfigurableQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}})
+	qa.AddQuickAction("unlabel", &mockDescribedQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}, metadata: Metadata{Aliases: []string{"remove_label"}}})
+	return qa
+}
+
+func TestParseRepositoryConfig(t *testing.T) {
+	qa := newConfigTestQuickActions()
+
+	config, err := qa.ParseRepositoryConfig([]byte(`
+prefix: "!"
+disabled: ["*"]
+enabled: [label]
+aliases:
+  tag: label
+permissions:
+  label: write
+options:
+  label:
+    name: bug
+`))
+	require.NoError(t, err)
+	assert.Equal(t, &RepositoryConfig{
+		Prefix:      "!",
+		Enabled:     []string{"label"},
+		Disabled:    []string{"*"},
+		Aliases:     map[string]string{"tag": "label"},
+		Permissions: map[string]Permission{"label": PermissionWrite},
+		Options:     map[string]map[string]interface{}{"label": {"name": "bug"}},
+		Sources:     []string{".github/quick-actions.yml"},
+	}, config)
+
+	config, err = qa.ParseRepositoryConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, &RepositoryConfig{Sources: []string{".github/quick-actions.yml"}}, config)
+}
+
+func TestParseRepositoryConfig_invalid(t *testing.T) {
+	qa := newConfigTestQuickActions()
+
+	ts := map[string]struct {
+		content string
+		err     string
+	}{
+		"invalid YAML": {
+			content: "prefix: [",
+			err:     "invalid .github/quick-actions.yml: line 1: did not find expected node content",
+		},
+		"unknown field": {
+			content: "prefix: \"!\"\nunknown: true",
+			err:     "invalid .github/quick-actions.yml: unmarshal errors:\n  line 2: field unknown not found in type gh_quick_actions.RepositoryConfig",
+		},
+		"invalid values": {
+			content: `
+prefix: "! "
+enabled: [unknown]
+disabled: ["*", assign]
+aliases: {remove_label: label, tag: untag}
+permissions: {label: owner}
+options: {unlabel: {name: bug}, label: {color: red}}
+`,
+			err: "invalid .github/quick-actions.yml: 8 error(s) found\n" +
+				"- prefix: '! ' must not contain spaces\n" +
+				"- enabled: unknown command 'unknown'\n" +
+				"- disabled: unknown command 'assign'\n" +
+				"- aliases.remove_label: command 'remove_label' already exists\n" +
+				"- aliases.tag: unknown command 'untag'\n" +
+				"- permissions.label: invalid permission 'owner' (expected none, read, triage, write, maintain or admin)\n" +
+				"- options.label: unmarshal errors:\n  line 1: field color not found in type gh_quick_actions.mockOptions\n" +
+				"- options.unlabel: command 'unlabel' has no options",
+		},
+		"invalid option value": {
+			content: "options: {label: {name: invalid}}",
+			err:     "invalid .github/quick-actions.yml: 1 error(s) found\n- options.label: invalid name 'invalid'",
+		},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := qa.ParseRepositoryConfig([]byte(tc.content))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRepositoryConfig_nil(t *testing.T) {
+	var config *RepositoryConfig
+
+	assert.Equal(t, "/", config.CommandPrefix())
+	assert.True(t, config.IsEnabled("label"))
+	_, isAlias := config.Alias("tag")
+	assert.False(t, isAlias)
+	assert.Equal(t, Restriction{Permission: PermissionTriage}, config.restriction("label", Restriction{Permission: PermissionTriage}))
+
+	options, err := config.options("label", &mockConfigurableQuickAction{})
+	require.NoError(t, err)
+	assert.Equal(t, &mockOptions{Name: "default"}, options)
+
+	info := QuickActionInfo{Command: "label", Metadata: Metadata{Usage: []string{"/label ~label"}, Aliases: []string{"tag"}}}
+	assert.Equal(t, info, config.Describe(info))
+}
+
+func TestRepositoryConfig_Describe(t *testing.T) {
+	config := &RepositoryConfig{
+		Prefix:      "!",
+		Aliases:     map[string]string{"tag": "label", "untag": "unlabel", "add_label": "label"},
+		Permissions: map[string]Permission{"label": PermissionWrite},
+	}
+
+	info := config.Describe(QuickActionInfo{
+		Command:     "label",
+		Restriction: Restriction{Permission: PermissionTriage, AllowAuthor: true},
+		Metadata:    Metadata{Usage: []string{"/label ~label"}, Examples: []string{"/label ~bug"}, Aliases: []string{"labels"}},
+	})
+	assert.Equal(t, QuickActionInfo{
+		Command:     "label",
+		Restriction: Restriction{Permission: PermissionWrite, AllowAuthor: true},
+		Metadata:    Metadata{Usage: []string{"!label ~label"}, Examples: []string{"!label ~bug"}, Aliases: []string{"labels", "add_label", "tag"}},
+	}, info)
+}
+
+func TestRepositoryConfig_IsEnabled(t *testing.T) {
+	config := &RepositoryConfig{Disabled: []string{"label"}}
+	assert.False(t, config.IsEnabled("label"))
+	assert.True(t, config.IsEnabled("unlabel"))
+
+	config = &RepositoryConfig{Disabled: []string{"*"}, Enabled: []string{"unlabel"}}
+	assert.False(t, config.IsEnabled("label"))
+	assert.True(t, config.IsEnabled("unlabel"))
+}
+
+func TestMergeConfig(t *testing.T) {
+	base := map[string]interface{}{
+		"prefix":   "/",
+		"disabled": []interface{}{"poll"},
+		"aliases":  map[string]interface{}{"dup": "duplicate"},
+		"options":  map[string]interface{}{"label": map[string]interface{}{"policy": "reject"}, "duplicate": map[string]interface{}{"label": "duplicate"}},
+	}
+	override := map[string]interface{}{
+		"prefix":   "!",
+		"disabled": []interface{}{"lgtm"},
+		"aliases":  map[string]interface{}{"tag": "label"},
+		"options":  map[string]interface{}{"label": map[string]interface{}{"policy": "create"}},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"prefix":   "!",
+		"disabled": []interface{}{"poll", "lgtm"},
+		"aliases":  map[string]interface{}{"dup": "duplicate", "tag": "label"},
+		"options":  map[string]interface{}{"label": map[string]interface{}{"policy": "create"}, "duplicate": map[string]interface{}{"label": "duplicate"}},
+	}, mergeConfig(base, override))
+	assert.Equal(t, []interface{}{"poll"}, base["disabled"])
+}
+
+func TestRepositoryConfig_YAML(t *testing.T) {
+	config := &RepositoryConfig{
+		Disabled: []string{"poll"},
+		Options:  map[string]map[string]interface{}{"label": {"policy": "reject"}},
+		Sources:  []string{".github/quick-actions.yml"},
+	}
+
+	content, err := config.YAML()
+	require.NoError(t, err)
+	assert.Equal(t, "disabled:\n  - poll\noptions:\n  label:\n    policy: reject\n", content)
+
+	content, err = (*RepositoryConfig)(nil).YAML()
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", content)
+}
+
+// newConfigTestServer returns a server answering the contents API with the
+// given configuration files, indexed by `repository:path`, and counting
+// calls; the SHA of each file is its content.
+func newConfigTestServer(t *testing.T, files map[string]string, calls *int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+
+		path := strings.Replace(strings.TrimPrefix(r.URL.Path, "/repos/xunleii/"), "/contents/", ":", 1)
+		content, exists := files[path]
+		if !exists {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "sha": %q, "content": %q}`, content, base64.StdEncoding.EncodeToString([]byte(content)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+const repoConfig = "github-quick-actions:.github/quick-actions.yml"
+const orgConfig = ".github:quick-actions.yml"
+
+func TestRepositoryConfig_handle(t *testing.T) {
+	var calls int
+	files := map[string]string{}
+	srv := newConfigTestServer(t, files, &calls)
+
+	action := &mockConfigurableQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}}
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+	qa.EnableRepositoryConfig()
+	qa.AddQuickAction("label", action)
+
+	// NOTE: without configuration file, the default behaviour is used
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/label")))
+	assert.Equal(t, []*mockOptions{{Name: "default"}}, action.options)
+	assert.Equal(t, 2, calls)
+
+	files[repoConfig] = "prefix: \"!\"\naliases: {tag: label}\noptions: {label: {name: bug}}"
+	action.options = nil
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/label\n!label\n!tag")))
+	assert.Equal(t, []*mockOptions{{Name: "bug"}, {Name: "bug"}}, action.options)
+	assert.Len(t, qa.configs.entries, 1)
+
+	// NOTE: an updated configuration file is parsed again
+	files[repoConfig] = "disabled: [label]"
+	action.options = nil
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/label")))
+	assert.Empty(t, action.options)
+	assert.Len(t, qa.configs.entries, 2)
+
+	// NOTE: commands are not run with an invalid configuration
+	files[repoConfig] = "disabled: [unknown]"
+	err := qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/label"))
+	assert.EqualError(t, err, "1 error occurred:\n\t* invalid .github/quick-actions.yml: 1 error(s) found\n- disabled: unknown command 'unknown'\n\n")
+	assert.Empty(t, action.options)
+
+	// NOTE: configuration errors are only reported to commands
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "Some comment")))
+}
+
+func TestRepositoryConfig_forbidden(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Resource not accessible by integration"}`, http.StatusForbidden)
+	}))
+	t.Cleanup(srv.Close)
+
+	action := &mockConfigurableQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}}
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+	qa.EnableRepositoryConfig()
+	qa.AddQuickAction("label", action)
+
+	// NOTE: installations without the contents permission use the default
+	//		 configuration
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/label")))
+	assert.Equal(t, []*mockOptions{{Name: "default"}}, action.options)
+}
+
+func TestRepositoryConfig_inherit(t *testing.T) {
+	var calls int
+	files := map[string]string{
+		orgConfig:  "disabled: [label]\naliases: {tag: label}\noptions: {label: {name: org}}",
+		repoConfig: "enabled: [label]\naliases: {lbl: label}",
+	}
+	srv := newConfigTestServer(t, files, &calls)
+
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+	qa.EnableRepositoryConfig()
+	qa.AddQuickAction("label", &mockConfigurableQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}})
+
+	payload, err := PayloadFactory(EventTypeIssueComment, feedbackPayload("created", "/label"))
+	require.NoError(t, err)
+	ctx := &EventContext{Context: context.TODO(), ClientCreator: mockAppClientCreator{srv: srv}}
+
+	config, err := qa.repositoryConfig(ctx, payload)
+	require.NoError(t, err)
+	assert.Equal(t, &RepositoryConfig{
+		Enabled:  []string{"label"},
+		Disabled: []string{"label"},
+		Aliases:  map[string]string{"tag": "label", "lbl": "label"},
+		Options:  map[string]map[string]interface{}{"label": {"name": "org"}},
+		Sources:  []string{"xunleii/.github:quick-actions.yml", ".github/quick-actions.yml"},
+	}, config)
+	assert.True(t, config.IsEnabled("label"))
+
+	// NOTE: the organization configuration is not fetched if not inherited
+	calls = 0
+	files[repoConfig] = "inherit: false\naliases: {lbl: label}"
+	config, err = qa.repositoryConfig(ctx, payload)
+	require.NoError(t, err)
+	assert.Equal(t, &RepositoryConfig{Aliases: map[string]string{"lbl": "label"}, Sources: []string{".github/quick-actions.yml"}}, config)
+	assert.Equal(t, 1, calls)
+
+	// NOTE: the organization configuration is used alone if the repository
+	//		 has no configuration
+	delete(files, repoConfig)
+	config, err = qa.repositoryConfig(ctx, payload)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"xunleii/.github:quick-actions.yml"}, config.Sources)
+
+	// NOTE: errors are reported with the file they come from
+	files[orgConfig] = "disabled: [unknown]"
+	_, err = qa.repositoryConfig(ctx, payload)
+	assert.EqualError(t, err, "invalid xunleii/.github:quick-actions.yml: 1 error(s) found\n- disabled: unknown command 'unknown'")
+}
+
+func TestRepositoryConfig_permissions(t *testing.T) {
+	var calls int
+	srv := newConfigTestServer(t, map[string]string{repoConfig: "permissions: {label: admin}"}, &calls)
+
+	action := &mockConfigurableQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}}
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+	qa.EnableRepositoryConfig()
+	qa.AddQuickAction("label", action)
+
+	// NOTE: the permission API isn't served by the test server, so the
+	//		 sender has no permission
+	err := qa.Handle(context.TODO(), "issue_comment", "", permissionPayload("octocat", "mojombo", "/label"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "@octocat is not allowed to run `/label`: `admin` permission required on the repository, but has `none` permission")
+	assert.Empty(t, action.options)
+}
+
+// mockOptions contains the options of mockConfigurableQuickAction
+type mockOptions struct {
+	Name string `yaml:"name"`
+}
+
+func (m mockOptions) Validate() error {
+	if strings.HasPrefix(m.Name, "invalid") {
+		return fmt.Errorf("invalid name '%s'", m.Name)
+	}
+	return nil
+}
+
+// mockConfigurableQuickAction implements a simple QuickAction with options,
+// recording the options of each run
+type mockConfigurableQuickAction struct {
+	mockQuickAction
+	options []*mockOptions
+}
+
+func (m *mockConfigurableQuickAction) Options() interface{} { return &mockOptions{Name: "default"} }
+func (m *mockConfigurableQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	m.options = append(m.options, command.Options.(*mockOptions))
+	return m.retErr
+}
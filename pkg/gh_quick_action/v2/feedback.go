@@ -0,0 +1,251 @@
+package gh_quick_actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// reactionReceived is added on comments containing commands as soon as
+	// they are received.
+	reactionReceived = "eyes"
+	// reactionSucceeded is added once all commands succeeded.
+	reactionSucceeded = "+1"
+	// reactionFailed is added once at least one command failed.
+	reactionFailed = "confused"
+
+	// reportMarker identifies the report of a specific comment.
+	reportMarker = "<!-- quick-actions:report:%d -->"
+)
+
+type (
+	// Feedback defines how users are notified of the result of their
+	// commands, on issue comments and review comments.
+	Feedback struct {
+		// Reactions adds a 👀 reaction on the comment containing the
+		// commands on receipt, then 👍 if all commands succeeded or 😕
+		// otherwise.
+		Reactions bool
+		// Report replies with a collapsible report containing the result
+		// of each command; the report is updated when the comment is
+		// edited.
+		Report bool
+	}
+
+	// commandResult contains the result of a command.
+	commandResult struct {
+		command *EventCommand
+		err     error
+	}
+
+	// feedbackNotifier notifies the author of a specific comment.
+	feedbackNotifier struct {
+		Feedback
+
+		client    *github.Client
+		payload   EventPayload
+		commentID int64
+	}
+)
+
+// SetFeedback defines how users are notified of the result of their commands.
+func (a *GithubQuickActions) SetFeedback(feedback Feedback) { a.feedback = feedback }
+
+// newFeedbackNotifier returns a notifier for the comment of the given event;
+// it returns nil if the feedback is disabled or if the event is not a
+// comment.
+func (a GithubQuickActions) newFeedbackNotifier(ctx *EventContext, payload EventPayload) *feedbackNotifier {
+	if !a.feedback.Reactions && !a.feedback.Report {
+		return nil
+	}
+
+	var commentID int64
+	switch event := payload.Raw().(type) {
+	case *github.IssueCommentEvent:
+		commentID = event.GetComment().GetID()
+	case *github.PullRequestReviewCommentEvent:
+		commentID = event.GetComment().GetID()
+	default:
+		return nil
+	}
+
+	client, err := ctx.NewClient(payload)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msgf("failed to create Github client, feedback disabled: %s", err)
+		return nil
+	}
+	return &feedbackNotifier{Feedback: a.feedback, client: client, payload: payload, commentID: commentID}
+}
+
+// received notifies the user that the commands has been received.
+func (n *feedbackNotifier) received(ctx *EventContext) {
+	if n == nil || !n.Reactions {
+		return
+	}
+
+	if err := n.react(ctx, reactionReceived); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msgf("failed to add reaction '%s': %s", reactionReceived, err)
+	}
+}
+
+// done notifies the user of the result of all commands.
+func (n *feedbackNotifier) done(ctx *EventContext, results []commandResult) {
+	if n == nil {
+		return
+	}
+	logger := zerolog.Ctx(ctx)
+
+	if n.Reactions {
+		reaction := reactionSucceeded
+		for _, result := range results {
+			if result.err != nil {
+				reaction = reactionFailed
+				break
+			}
+		}
+
+		if err := n.react(ctx, reaction); err != nil {
+			logger.Warn().Err(err).Msgf("failed to add reaction '%s': %s", reaction, err)
+		}
+	}
+
+	if n.Report {
+		if err := n.report(ctx, results); err != nil {
+			logger.Warn().Err(err).Msgf("failed to post quick actions report: %s", err)
+		}
+	}
+}
+
+// react adds the given reaction on the comment.
+func (n *feedbackNotifier) react(ctx *EventContext, reaction string) error {
+	owner, repo := n.payload.RepositoryOwner(), n.payload.RepositoryName()
+
+	var err error
+	switch n.payload.Type() {
+	case EventTypePullRequestReviewComment:
+		_, _, err = n.client.Reactions.CreatePullRequestCommentReaction(ctx, owner, repo, n.commentID, reaction)
+	default:
+		_, _, err = n.client.Reactions.CreateIssueCommentReaction(ctx, owner, repo, n.commentID, reaction)
+	}
+	return err
+}
+
+// report posts the report of the given results, or updates the existing one
+// if the comment has been edited.
+func (n *feedbackNotifier) report(ctx *EventContext, results []commandResult) error {
+	owner, repo, number := n.payload.RepositoryOwner(), n.payload.RepositoryName(), n.payload.IssueNumber()
+	body := reportBody(n.commentID, results)
+
+	var reportID int64
+	if n.payload.Action() == EventActionEdited {
+		var err error
+		if reportID, err = n.findReport(ctx); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	review := n.payload.Type() == EventTypePullRequestReviewComment
+	switch {
+	case reportID != 0 && review:
+		_, _, err = n.client.PullRequests.EditComment(ctx, owner, repo, reportID, &github.PullRequestComment{Body: github.String(body)})
+	case reportID != 0:
+		_, _, err = n.client.Issues.EditComment(ctx, owner, repo, reportID, &github.IssueComment{Body: github.String(body)})
+	case review:
+		_, _, err = n.client.PullRequests.CreateCommentInReplyTo(ctx, owner, repo, number, body, n.commentID)
+	default:
+		_, _, err = n.client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: github.String(body)})
+	}
+	return err
+}
+
+// findReport returns the ID of the existing report of the comment, or 0 if
+// no report has been posted yet; only the comments of the application itself
+// are considered, to avoid taking over forged reports.
+func (n *feedbackNotifier) findReport(ctx *EventContext) (int64, error) {
+	owner, repo, number := n.payload.RepositoryOwner(), n.payload.RepositoryName(), n.payload.IssueNumber()
+	marker := fmt.Sprintf(reportMarker, n.commentID)
+
+	opts := github.ListOptions{PerPage: 100}
+	for {
+		var ids []int64
+		var bodies []string
+		var users []*github.User
+		var resp *github.Response
+		var err error
+
+		switch n.payload.Type() {
+		case EventTypePullRequestReviewComment:
+			var comments []*github.PullRequestComment
+			comments, resp, err = n.client.PullRequests.ListComments(ctx, owner, repo, number, &github.PullRequestListCommentsOptions{ListOptions: opts})
+			for _, comment := range comments {
+				ids, bodies, users = append(ids, comment.GetID()), append(bodies, comment.GetBody()), append(users, comment.GetUser())
+			}
+		default:
+			var comments []*github.IssueComment
+			comments, resp, err = n.client.Issues.ListComments(ctx, owner, repo, number, &github.IssueListCommentsOptions{ListOptions: opts})
+			for _, comment := range comments {
+				ids, bodies, users = append(ids, comment.GetID()), append(bodies, comment.GetBody()), append(users, comment.GetUser())
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		for i, body := range bodies {
+			if users[i].GetType() != "Bot" || !strings.Contains(body, marker) {
+				continue
+			}
+
+			login, err := ctx.AppLogin()
+			if err != nil {
+				return 0, err
+			}
+			if users[i].GetLogin() == login {
+				return ids[i], nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return 0, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// reportBody generates the collapsible report of the given results.
+func reportBody(commentID int64, results []commandResult) string {
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+		}
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "<details>\n<summary>Quick actions report: %d succeeded, %d failed</summary>\n\n", len(results)-failed, failed)
+	report.WriteString("| Command | Result |\n")
+	report.WriteString("| :------ | :----- |\n")
+	for _, result := range results {
+		line := "`" + reportCell(strings.Join(append([]string{"/" + result.command.Command}, result.command.Arguments...), " ")) + "`"
+		if result.command.Macro != "" {
+			line += fmt.Sprintf(" (from `/%s`)", reportCell(result.command.Macro))
+		}
+		status := "✅"
+		if result.err != nil {
+			status = "❌ " + result.err.Error()
+		}
+		fmt.Fprintf(&report, "| %s | %s |\n", line, reportCell(status))
+	}
+	report.WriteString("\n</details>\n")
+	fmt.Fprintf(&report, reportMarker, commentID)
+	return report.String()
+}
+
+// reportCell escapes the given text to be used inside a Markdown table cell.
+func reportCell(text string) string {
+	text = strings.TrimSpace(text)
+	return strings.NewReplacer("|", "\\|", "\n", "<br>", "\t", " ").Replace(text)
+}
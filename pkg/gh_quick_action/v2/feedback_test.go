@@ -0,0 +1,142 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feedbackTestServer records all requests sent to Github.
+type feedbackTestServer struct {
+	sync.Mutex
+	*httptest.Server
+
+	requests []string
+	comments string
+}
+
+func newFeedbackTestServer(t *testing.T, comments string) *feedbackTestServer {
+	srv := &feedbackTestServer{comments: comments}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app" {
+			_, _ = w.Write([]byte(`{"slug": "quick-actions"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+
+		srv.Lock()
+		srv.requests = append(srv.requests, strings.TrimSpace(fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body)))
+		srv.Unlock()
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(srv.comments))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func feedbackPayload(action, body string) []byte {
+	return []byte(fmt.Sprintf(`{
+		"action": %q,
+		"comment": {"id": 42, "body": %q},
+		"changes": {"body": {"from": ""}},
+		"issue": {"number": 1},
+		"repository": {"name": "github-quick-actions", "owner": {"login": "xunleii"}},
+		"installation": {"id": 1}
+	}`, action, body))
+}
+
+func TestFeedback_reactions(t *testing.T) {
+	srv := newFeedbackTestServer(t, "[]")
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv.Server})
+	qa.SetFeedback(Feedback{Reactions: true})
+	qa.AddQuickAction("ok", mockQuickAction{onEvents: []EventType{EventTypeIssueComment}})
+	qa.AddQuickAction("ko", mockQuickAction{onEvents: []EventType{EventTypeIssueComment}, retErr: fmt.Errorf("failure")})
+
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/ok")))
+	assert.Equal(t, []string{
+		`POST /repos/xunleii/github-quick-actions/issues/comments/42/reactions {"content":"eyes"}`,
+		`POST /repos/xunleii/github-quick-actions/issues/comments/42/reactions {"content":"+1"}`,
+	}, srv.requests)
+
+	srv.requests = nil
+	require.Error(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/ok\n/ko")))
+	assert.Equal(t, []string{
+		`POST /repos/xunleii/github-quick-actions/issues/comments/42/reactions {"content":"eyes"}`,
+		`POST /repos/xunleii/github-quick-actions/issues/comments/42/reactions {"content":"confused"}`,
+	}, srv.requests)
+}
+
+func TestFeedback_report(t *testing.T) {
+	srv := newFeedbackTestServer(t, `[{"id": 7, "body": "unrelated"}]`)
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv.Server})
+	qa.SetFeedback(Feedback{Report: true})
+	qa.AddQuickAction("ok", mockQuickAction{onEvents: []EventType{EventTypeIssueComment}})
+	qa.AddQuickAction("ko", mockQuickAction{onEvents: []EventType{EventTypeIssueComment}, retErr: fmt.Errorf("failure | reason")})
+
+	require.Error(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/ok a\n/ko")))
+	require.Len(t, srv.requests, 1)
+	assert.True(t, strings.HasPrefix(srv.requests[0], "POST /repos/xunleii/github-quick-actions/issues/1/comments "), srv.requests[0])
+
+	// NOTE: the report must be created if no report exists yet ...
+	srv.requests = nil
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("edited", "/ok")))
+	require.Len(t, srv.requests, 2)
+	assert.Equal(t, "GET /repos/xunleii/github-quick-actions/issues/1/comments", srv.requests[0])
+	assert.True(t, strings.HasPrefix(srv.requests[1], "POST /repos/xunleii/github-quick-actions/issues/1/comments "), srv.requests[1])
+
+	// ... and updated otherwise
+	srv.comments = `[{"id": 7, "body": "unrelated"}, {"id": 8, "body": "report\n<!-- quick-actions:report:42 -->", "user": {"login": "quick-actions[bot]", "type": "Bot"}}]`
+	srv.requests = nil
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("edited", "/ok")))
+	require.Len(t, srv.requests, 2)
+	assert.True(t, strings.HasPrefix(srv.requests[1], "PATCH /repos/xunleii/github-quick-actions/issues/comments/8 "), srv.requests[1])
+
+	// NOTE: reports forged by users or by other bots are ignored
+	srv.comments = `[
+		{"id": 7, "body": "<!-- quick-actions:report:42 -->", "user": {"login": "mallory", "type": "User"}},
+		{"id": 8, "body": "<!-- quick-actions:report:42 -->", "user": {"login": "other-app[bot]", "type": "Bot"}}
+	]`
+	srv.requests = nil
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("edited", "/ok")))
+	require.Len(t, srv.requests, 2)
+	assert.True(t, strings.HasPrefix(srv.requests[1], "POST /repos/xunleii/github-quick-actions/issues/1/comments "), srv.requests[1])
+}
+
+func TestFeedback_disabled(t *testing.T) {
+	srv := newFeedbackTestServer(t, "[]")
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv.Server})
+	qa.AddQuickAction("ok", mockQuickAction{onEvents: []EventType{EventTypeIssueComment}})
+
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", feedbackPayload("created", "/ok")))
+	assert.Empty(t, srv.requests)
+}
+
+func TestReportBody(t *testing.T) {
+	results := []commandResult{
+		{command: &EventCommand{Command: "label", Arguments: []string{"~bug", "~feature"}}},
+		{command: &EventCommand{Command: "assign", Arguments: []string{"@octocat"}}, err: fmt.Errorf("user 'octocat' | not found\nin repository")},
+	}
+
+	assert.Equal(t,
+		"<details>\n<summary>Quick actions report: 1 succeeded, 1 failed</summary>\n\n"+
+			"| Command | Result |\n"+
+			"| :------ | :----- |\n"+
+			"| `/label ~bug ~feature` | ✅ |\n"+
+			"| `/assign @octocat` | ❌ user 'octocat' \\| not found<br>in repository |\n"+
+			"\n</details>\n"+
+			"<!-- quick-actions:report:42 -->",
+		reportBody(42, results),
+	)
+}
@@ -4,7 +4,7 @@ package gh_quick_actions
 type EventType string
 
 const (
-	EventTypeIssue                    EventType = "issue"
+	EventTypeIssue                    EventType = "issues"
 	EventTypeIssueComment             EventType = "issue_comment"
 	EventTypePullRequest              EventType = "pull_request"
 	EventTypePullRequestReviewComment EventType = "pull_request_review_comment"
@@ -17,4 +17,10 @@ const (
 	EventActionCreated EventAction = "created"
 	EventActionEdited  EventAction = "edited"
 	EventActionDeleted EventAction = "deleted"
+
+	EventActionOpened   EventAction = "opened"
+	EventActionClosed   EventAction = "closed"
+	EventActionReopened EventAction = "reopened"
+
+	EventActionSynchronize EventAction = "synchronize"
 )
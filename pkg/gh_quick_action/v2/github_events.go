@@ -23,6 +23,7 @@ func (i *IssueEvent) RepositoryName() string  { return i.GetRepo().GetName() }
 func (i *IssueEvent) RepositoryOwner() string { return i.GetRepo().GetOwner().GetLogin() }
 func (i *IssueEvent) IssueNumber() int        { return i.GetIssue().GetNumber() }
 func (i *IssueEvent) Body() string            { return i.GetIssue().GetBody() }
+func (i *IssueEvent) PreviousBody() string    { return previousBody(i.GetChanges(), i.Body()) }
 func (i *IssueEvent) Raw() interface{}        { return i.IssuesEvent }
 
 // IssueCommentEvent wraps *github.com/google/go-github/v39/github.IssueCommentEvent
@@ -41,6 +42,7 @@ func (i *IssueCommentEvent) RepositoryName() string  { return i.GetRepo().GetNam
 func (i *IssueCommentEvent) RepositoryOwner() string { return i.GetRepo().GetOwner().GetLogin() }
 func (i *IssueCommentEvent) IssueNumber() int        { return i.GetIssue().GetNumber() }
 func (i *IssueCommentEvent) Body() string            { return i.GetComment().GetBody() }
+func (i *IssueCommentEvent) PreviousBody() string    { return previousBody(i.GetChanges(), i.Body()) }
 func (i *IssueCommentEvent) Raw() interface{}        { return i.IssueCommentEvent }
 
 // PullRequestEvent wraps *github.com/google/go-github/v39/github.PullRequestEvent
@@ -59,6 +61,7 @@ func (i *PullRequestEvent) RepositoryName() string  { return i.GetRepo().GetName
 func (i *PullRequestEvent) RepositoryOwner() string { return i.GetRepo().GetOwner().GetLogin() }
 func (i *PullRequestEvent) IssueNumber() int        { return i.GetPullRequest().GetNumber() }
 func (i *PullRequestEvent) Body() string            { return i.GetPullRequest().GetBody() }
+func (i *PullRequestEvent) PreviousBody() string    { return previousBody(i.GetChanges(), i.Body()) }
 func (i *PullRequestEvent) Raw() interface{}        { return i.PullRequestEvent }
 
 // PullRequestReviewCommentEvent wraps *github.com/google/go-github/v39/github.PullRequestReviewCommentEvent
@@ -77,8 +80,18 @@ func (i *PullRequestReviewCommentEvent) RepositoryName() string  { return i.GetR
 func (i *PullRequestReviewCommentEvent) RepositoryOwner() string { return i.GetRepo().GetOwner().GetLogin() }
 func (i *PullRequestReviewCommentEvent) IssueNumber() int        { return i.GetPullRequest().GetNumber() }
 func (i *PullRequestReviewCommentEvent) Body() string            { return i.GetComment().GetBody() }
+func (i *PullRequestReviewCommentEvent) PreviousBody() string    { return previousBody(i.GetChanges(), i.Body()) }
 func (i *PullRequestReviewCommentEvent) Raw() interface{}        { return i.PullRequestReviewCommentEvent }
 
+// previousBody returns the body before the edition described by the given
+// changes, or the current body if it hasn't been edited.
+func previousBody(changes *github.EditChange, body string) string {
+	if changes.GetBody() == nil {
+		return body
+	}
+	return changes.GetBody().GetFrom()
+}
+
 // payloadFactory is only used internally to generate EventPayload from raw JSON.
 var payloadFactory = map[EventType]func([]byte) (EventPayload, error){
 	EventTypeIssue:                    newIssueEvent,
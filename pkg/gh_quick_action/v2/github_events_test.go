@@ -60,20 +60,20 @@ func TestPayloadFactory(t *testing.T) {
 			name:            "github.IssueEvent",
 			eventType:       EventTypeIssue,
 			eventJSON:       issueEventFixtureJSON,
-			expectedPayload: mockEventPayload{EventTypeIssue, EventActionCreated, "github-quick-actions", "xunleii", 0, "..."},
+			expectedPayload: mockEventPayload{EventTypeIssue, EventActionCreated, "github-quick-actions", "xunleii", 0, "...", "..."},
 		},
 		{
 			name:      "github.IssueEvent@invalid",
 			eventType: EventTypeIssue,
 			eventJSON: []byte("...invalid..."),
-			err:       fmt.Errorf("failed to extract data from JSON for event 'issue': invalid character '.' looking for beginning of value"),
+			err:       fmt.Errorf("failed to extract data from JSON for event 'issues': invalid character '.' looking for beginning of value"),
 		},
 
 		{
 			name:            "github.IssueCommentEvent",
 			eventType:       EventTypeIssueComment,
 			eventJSON:       issueCommentEventFixtureJSON,
-			expectedPayload: mockEventPayload{EventTypeIssueComment, EventActionCreated, "github-quick-actions", "xunleii", 0, "..."},
+			expectedPayload: mockEventPayload{EventTypeIssueComment, EventActionCreated, "github-quick-actions", "xunleii", 0, "...", "..."},
 		},
 		{
 			name:      "github.IssueCommentEvent@invalid",
@@ -86,7 +86,7 @@ func TestPayloadFactory(t *testing.T) {
 			name:            "github.PullRequestEvent",
 			eventType:       EventTypePullRequest,
 			eventJSON:       pullRequestEventFixtureJSON,
-			expectedPayload: mockEventPayload{EventTypePullRequest, EventActionCreated, "github-quick-actions", "xunleii", 0, "..."},
+			expectedPayload: mockEventPayload{EventTypePullRequest, EventActionCreated, "github-quick-actions", "xunleii", 0, "...", "..."},
 		},
 		{
 			name:      "github.PullRequestEvent@invalid",
@@ -99,7 +99,7 @@ func TestPayloadFactory(t *testing.T) {
 			name:            "github.PullRequestReviewCommentEvent",
 			eventType:       EventTypePullRequestReviewComment,
 			eventJSON:       pullRequestReviewCommentEventFixtureJSON,
-			expectedPayload: mockEventPayload{EventTypePullRequestReviewComment, EventActionCreated, "github-quick-actions", "xunleii", 0, "..."},
+			expectedPayload: mockEventPayload{EventTypePullRequestReviewComment, EventActionCreated, "github-quick-actions", "xunleii", 0, "...", "..."},
 		},
 		{
 			name:      "github.PullRequestReviewCommentEvent@invalid",
@@ -126,6 +126,7 @@ func TestPayloadFactory(t *testing.T) {
 				assert.Equal(t, tt.expectedPayload.RepositoryOwner(), payload.RepositoryOwner())
 				assert.Equal(t, tt.expectedPayload.IssueNumber(), payload.IssueNumber())
 				assert.Equal(t, tt.expectedPayload.Body(), payload.Body())
+				assert.Equal(t, tt.expectedPayload.PreviousBody(), payload.PreviousBody())
 				assert.NotNil(t, payload.Raw())
 			case tt.err != nil:
 				assert.EqualError(t, err, tt.err.Error())
@@ -137,6 +138,30 @@ func TestPayloadFactory(t *testing.T) {
 	}
 }
 
+func TestPreviousBody(t *testing.T) {
+	tts := map[string]struct {
+		eventType EventType
+		eventJSON string
+		expected  string
+	}{
+		"issue edited":                       {EventTypeIssue, `{"action": "edited", "issue": {"body": "new"}, "changes": {"body": {"from": "old"}}}`, "old"},
+		"issue title edited":                 {EventTypeIssue, `{"action": "edited", "issue": {"body": "new"}, "changes": {"title": {"from": "old"}}}`, "new"},
+		"issue comment edited":               {EventTypeIssueComment, `{"action": "edited", "comment": {"body": "new"}, "changes": {"body": {"from": "old"}}}`, "old"},
+		"issue comment created":              {EventTypeIssueComment, `{"action": "created", "comment": {"body": "new"}}`, "new"},
+		"pull request edited":                {EventTypePullRequest, `{"action": "edited", "pull_request": {"body": "new"}, "changes": {"body": {"from": ""}}}`, ""},
+		"pull request review comment edited": {EventTypePullRequestReviewComment, `{"action": "edited", "comment": {"body": "new"}, "changes": {"body": {"from": "old"}}}`, "old"},
+	}
+
+	for name, tt := range tts {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			payload, err := PayloadFactory(tt.eventType, []byte(tt.eventJSON))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, payload.PreviousBody())
+		})
+	}
+}
+
 type mockEventPayload struct {
 	eventType   EventType
 	action      EventAction
@@ -144,6 +169,7 @@ type mockEventPayload struct {
 	repoOwner   string
 	issueNumber int
 	body        string
+	prevBody    string
 }
 
 func (m mockEventPayload) Type() EventType         { return m.eventType }
@@ -152,6 +178,7 @@ func (m mockEventPayload) RepositoryName() string  { return m.repoName }
 func (m mockEventPayload) RepositoryOwner() string { return m.repoOwner }
 func (m mockEventPayload) IssueNumber() int        { return m.issueNumber }
 func (m mockEventPayload) Body() string            { return m.body }
+func (m mockEventPayload) PreviousBody() string    { return m.prevBody }
 func (m mockEventPayload) Raw() interface{}        { panic("not implemented") }
 
 //@format=off
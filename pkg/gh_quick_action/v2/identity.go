@@ -0,0 +1,68 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/palantir/go-githubapp/githubapp"
+)
+
+// appIdentity resolves, once, the login of the Github Application bot user.
+type appIdentity struct {
+	mx    sync.Mutex
+	login string
+}
+
+// resolve returns the login of the Github Application bot user, like
+// `quick-actions[bot]`.
+func (i *appIdentity) resolve(ctx context.Context, cc githubapp.ClientCreator) (string, error) {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	if i.login != "" {
+		return i.login, nil
+	}
+
+	client, err := cc.NewAppClient()
+	if err != nil {
+		return "", err
+	}
+	app, _, err := client.Apps.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the Github Application: %w", err)
+	}
+	if app.GetSlug() == "" {
+		return "", fmt.Errorf("failed to fetch the Github Application: no slug found")
+	}
+
+	i.login = app.GetSlug() + "[bot]"
+	return i.login, nil
+}
+
+// AppLogin returns the login of the Github Application bot user, which
+// authors all comments posted by quick actions.
+func (ctx *EventContext) AppLogin() (string, error) {
+	if ctx.identity == nil {
+		ctx.identity = &appIdentity{}
+	}
+	return ctx.identity.resolve(ctx, ctx.ClientCreator)
+}
+
+// sentByItself returns true if the given event has been triggered by the
+// Github Application itself, like the comments posted by plugins; if the
+// application login can't be resolved, events sent by bots are considered
+// sent by itself.
+func sentByItself(ctx *EventContext, payload EventPayload) (bool, error) {
+	event, valid := payload.Raw().(interface{ GetSender() *github.User })
+	if !valid || event.GetSender().GetType() != "Bot" {
+		return false, nil
+	}
+
+	login, err := ctx.AppLogin()
+	if err != nil {
+		return true, err
+	}
+	return event.GetSender().GetLogin() == login, nil
+}
@@ -0,0 +1,216 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+)
+
+// maxMacroCommands is the maximum number of commands a macro can expand to,
+// including the ones of nested macros.
+const maxMacroCommands = 32
+
+// macroParameter matches the parameters of macro steps: `$1`, `$2`, ... for
+// positional arguments, `$@` for all arguments and `$author` for the author
+// of the issue or pull request.
+var macroParameter = regexp.MustCompile(`\$(@|author|[1-9][0-9]*)`)
+
+// macro returns the steps of the given macro, if defined.
+func (c *RepositoryConfig) macro(name string) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	steps, exists := c.Macros[name]
+	return steps, exists
+}
+
+// expandMacro expands the given macro into the commands of its steps,
+// replacing their parameters by the given arguments; steps calling other
+// macros are expanded recursively. Like commands written by users, disabled
+// macros and unknown or disabled steps are ignored.
+func (a GithubQuickActions) expandMacro(ctx context.Context, event EventPayload, config *RepositoryConfig, macro string, args []string, stack []string) []*EventCommand {
+	logger := zerolog.Ctx(ctx).With().Str("macro", macro).Logger()
+	steps, _ := config.macro(macro)
+	if !config.IsEnabled(macro) {
+		logger.Info().Msgf("macro '/%s' is disabled on this repository, ignored", macro)
+		return nil
+	}
+
+	// NOTE: cycles are rejected when the configuration is validated; this
+	//		 only avoids an infinite recursion on unvalidated ones
+	for _, name := range stack {
+		if name == macro {
+			logger.Error().Msgf("recursive macro '/%s' (%s), ignored", macro, strings.Join(append(stack, macro), " -> "))
+			return nil
+		}
+	}
+	stack = append(stack, macro)
+	logger.Trace().Msgf("expand macro '/%s' (args: %v)", macro, args)
+
+	_, author := payloadUsers(event)
+	var commands []*EventCommand
+	for _, step := range steps {
+		items, err := parseCommandLine(step)
+		if err != nil || len(items) == 0 {
+			logger.Error().Err(err).Msgf("failed to parse step '%s', ignored", step)
+			continue
+		}
+		items = expandMacroParameters(items, args, author)
+
+		command := strings.TrimPrefix(items[0], defaultPrefix)
+		if canonical, isAlias := config.Alias(command); isAlias {
+			command = canonical
+		}
+		if _, isMacro := config.macro(command); isMacro {
+			commands = append(commands, a.expandMacro(ctx, event, config, command, items[1:], stack)...)
+			continue
+		}
+
+		command, valid := a.resolveCommand(ctx, event, config, command)
+		if !valid {
+			continue
+		}
+		commands = append(commands, &EventCommand{
+			Command:   command,
+			Arguments: items[1:],
+			Macro:     stack[0],
+			Payload:   event,
+		})
+	}
+	return commands
+}
+
+// expandMacroParameters replaces the parameters of the given step items by
+// the macro arguments; `$@` items are replaced by all arguments and items
+// left empty are removed.
+func expandMacroParameters(items []string, args []string, author string) []string {
+	var expanded []string
+	for _, item := range items {
+		if item == "$@" {
+			expanded = append(expanded, args...)
+			continue
+		}
+
+		item = macroParameter.ReplaceAllStringFunc(item, func(parameter string) string {
+			switch parameter = parameter[1:]; parameter {
+			case "@":
+				return strings.Join(args, " ")
+			case "author":
+				if author == "" {
+					return ""
+				}
+				return "@" + author
+			}
+
+			n, _ := strconv.Atoi(parameter)
+			if n > len(args) {
+				return ""
+			}
+			return args[n-1]
+		})
+		if item != "" {
+			expanded = append(expanded, item)
+		}
+	}
+	return expanded
+}
+
+// validateMacros checks that the steps of all macros refer to existing
+// commands, aliases, macros or plugins, without cycles. Unlike the other
+// settings, macros are only validated once all configuration files are
+// merged, because they can use macros defined in the organization
+// configuration.
+func (a GithubQuickActions) validateMacros(config *RepositoryConfig) error {
+	errs := &multierror.Error{}
+	errs.ErrorFormat = configErrorFormat
+
+	for _, name := range sortedKeys(config.Macros) {
+		if _, exists := a.quickAction(name); exists {
+			errs = multierror.Append(errs, fmt.Errorf("macros.%s: command '%s' already exists", name, name))
+		} else if _, exists := config.Alias(name); exists {
+			errs = multierror.Append(errs, fmt.Errorf("macros.%s: alias '%s' already exists", name, name))
+		} else if _, exists := config.Plugins[name]; exists {
+			errs = multierror.Append(errs, fmt.Errorf("macros.%s: plugin '%s' already exists", name, name))
+		} else if strings.IndexFunc(name, unicode.IsSpace) >= 0 || name == "" {
+			errs = multierror.Append(errs, fmt.Errorf("macros.%s: invalid macro name", name))
+		}
+		if len(config.Macros[name]) == 0 {
+			errs = multierror.Append(errs, fmt.Errorf("macros.%s: no step defined", name))
+		}
+
+		for i, step := range config.Macros[name] {
+			items, err := parseCommandLine(step)
+			switch {
+			case err != nil:
+				errs = multierror.Append(errs, fmt.Errorf("macros.%s[%d]: invalid step '%s': %s", name, i, step, err))
+				continue
+			case len(items) == 0 || !strings.HasPrefix(items[0], defaultPrefix):
+				errs = multierror.Append(errs, fmt.Errorf("macros.%s[%d]: step '%s' must start with '%s'", name, i, step, defaultPrefix))
+				continue
+			}
+
+			command := strings.TrimPrefix(items[0], defaultPrefix)
+			_, isCommand := a.quickAction(command)
+			_, isAlias := config.Alias(command)
+			_, isMacro := config.macro(command)
+			_, isPlugin := config.Plugins[command]
+			if !isCommand && !isAlias && !isMacro && !isPlugin {
+				errs = multierror.Append(errs, fmt.Errorf("macros.%s[%d]: unknown command '%s'", name, i, command))
+			}
+		}
+	}
+	if errs.ErrorOrNil() != nil {
+		return errs
+	}
+
+	for _, name := range sortedKeys(config.Macros) {
+		if size, err := macroSize(config, name, nil); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("macros.%s: %w", name, err))
+		} else if size > maxMacroCommands {
+			errs = multierror.Append(errs, fmt.Errorf("macros.%s: expands to more than %d commands", name, maxMacroCommands))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// macroSize returns the number of commands the given macro expands to,
+// whatever the event type; it fails if the macro calls itself.
+func macroSize(config *RepositoryConfig, macro string, stack []string) (int, error) {
+	for _, name := range stack {
+		if name == macro {
+			return 0, fmt.Errorf("recursive macro (%s)", strings.Join(append(stack, macro), " -> "))
+		}
+	}
+	stack = append(stack, macro)
+
+	size := 0
+	for _, step := range config.Macros[macro] {
+		items, _ := parseCommandLine(step)
+		command := strings.TrimPrefix(items[0], defaultPrefix)
+		if canonical, isAlias := config.Alias(command); isAlias {
+			command = canonical
+		}
+
+		if _, isMacro := config.macro(command); !isMacro {
+			size++
+			continue
+		}
+		n, err := macroSize(config, command, stack)
+		if err != nil {
+			return 0, err
+		}
+		size += n
+		// NOTE: stop as soon as the limit is reached, to avoid walking
+		//		 through exponentially nested macros
+		if size > maxMacroCommands {
+			return size, nil
+		}
+	}
+	return size, nil
+}
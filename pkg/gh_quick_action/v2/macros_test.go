@@ -0,0 +1,198 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandMacroParameters(t *testing.T) {
+	ts := map[string]struct {
+		items    []string
+		args     []string
+		author   string
+		expected []string
+	}{
+		"no parameter":       {items: []string{"/label", "~bug"}, args: []string{"~other"}, expected: []string{"/label", "~bug"}},
+		"positional":         {items: []string{"/label", "~$1", "~$2"}, args: []string{"bug", "feature"}, expected: []string{"/label", "~bug", "~feature"}},
+		"missing positional": {items: []string{"/label", "$1", "$2"}, args: []string{"~bug"}, expected: []string{"/label", "~bug"}},
+		"all arguments":      {items: []string{"/label", "~bug", "$@"}, args: []string{"~a b", "~c"}, expected: []string{"/label", "~bug", "~a b", "~c"}},
+		"joined arguments":   {items: []string{"/remind", "me", "in", "$@"}, args: []string{"2", "days"}, expected: []string{"/remind", "me", "in", "2", "days"}},
+		"inlined arguments":  {items: []string{"/poll", "Release $@?", "yes", "no"}, args: []string{"v1", "now"}, expected: []string{"/poll", "Release v1 now?", "yes", "no"}},
+		"author":             {items: []string{"/assign", "$author"}, author: "octocat", expected: []string{"/assign", "@octocat"}},
+		"unknown author":     {items: []string{"/assign", "$author"}, expected: []string{"/assign"}},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, expandMacroParameters(tc.items, tc.args, tc.author))
+		})
+	}
+}
+
+func TestParseRepositoryConfig_macros(t *testing.T) {
+	qa := newConfigTestQuickActions()
+
+	config, err := qa.ParseRepositoryConfig([]byte(`
+aliases:
+  tag: label
+macros:
+  triage-bug: [/label ~bug ~needs-repro, /tag $@]
+  triage: [/triage-bug $@, /unlabel ~needs-triage]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"triage-bug": {"/label ~bug ~needs-repro", "/tag $@"},
+		"triage":     {"/triage-bug $@", "/unlabel ~needs-triage"},
+	}, config.Macros)
+
+	ts := map[string]struct {
+		content string
+		err     string
+	}{
+		"existing command": {content: "macros: {label: [/unlabel]}", err: "- macros.label: command 'label' already exists"},
+		"existing alias":   {content: "aliases: {tag: label}\nmacros: {tag: [/unlabel]}", err: "- macros.tag: alias 'tag' already exists"},
+		"invalid name":     {content: "macros: {\"a b\": [/unlabel]}", err: "- macros.a b: invalid macro name"},
+		"no step":          {content: "macros: {empty: []}", err: "- macros.empty: no step defined"},
+		"no prefix":        {content: "macros: {triage: [label ~bug]}", err: "- macros.triage[0]: step 'label ~bug' must start with '/'"},
+		"unknown command":  {content: "macros: {triage: [/label, /unknown]}", err: "- macros.triage[1]: unknown command 'unknown'"},
+		"recursive macro":  {content: "macros: {a: [/b], b: [/label, /c], c: [/a]}", err: "- macros.a: recursive macro (a -> b -> c -> a)"},
+		"too many commands": {
+			content: "macros: {a: [/label, /label, /label, /label], b: [/a, /a, /a, /a], c: [/b, /b, /b]}",
+			err:     "- macros.c: expands to more than 32 commands",
+		},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := qa.ParseRepositoryConfig([]byte(tc.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestParseConfigFiles_macros(t *testing.T) {
+	qa := newConfigTestQuickActions()
+
+	// NOTE: macros can use the ones of the organization, and redefined
+	//		 macros replace the inherited ones
+	config, err := qa.parseConfigFiles(
+		&configFile{name: "org", content: []byte("macros: {bug: [/label ~bug], triage: [/label ~triage]}")},
+		&configFile{name: "repo", content: []byte("macros: {triage: [/bug, /unlabel ~needs-triage]}")},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"bug":    {"/label ~bug"},
+		"triage": {"/bug", "/unlabel ~needs-triage"},
+	}, config.Macros)
+
+	// NOTE: macros of the organization can also be enabled, disabled and
+	//		 aliased by the repository
+	config, err = qa.parseConfigFiles(
+		&configFile{name: "org", content: []byte("macros: {bug: [/label ~bug]}\ndisabled: [bug]")},
+		&configFile{name: "repo", content: []byte("enabled: [bug]\naliases: {b: bug}")},
+	)
+	require.NoError(t, err)
+	assert.True(t, config.IsEnabled("bug"))
+	assert.Equal(t, map[string]string{"b": "bug"}, config.Aliases)
+
+	_, err = qa.parseConfigFiles(
+		&configFile{name: "org", content: []byte("disabled: [bug]")},
+		&configFile{name: "repo", content: []byte("macros: {bug: [/label ~bug]}")},
+	)
+	assert.EqualError(t, err, "invalid org: 1 error(s) found\n- disabled: unknown command 'bug'")
+}
+
+func TestPayloadToCommands_macros(t *testing.T) {
+	qa := newConfigTestQuickActions()
+	config := &RepositoryConfig{
+		Prefix:   "!",
+		Disabled: []string{"unlabel"},
+		Aliases:  map[string]string{"tag": "label"},
+		Macros: map[string][]string{
+			"triage-bug": {"/label ~bug $@", "/unlabel ~needs-triage", "/tag $author"},
+			"triage":     {"/triage-bug ~$1", "/remove_label $2"},
+			"a":          {"/b"},
+			"b":          {"/label", "/a"},
+		},
+	}
+
+	payload, err := PayloadFactory(EventTypeIssueComment, permissionPayload("octocat", "mojombo", "!triage-bug ~p1 \"~needs info\"\n!triage regression ~wontfix\n!a"))
+	require.NoError(t, err)
+
+	noLog := zerolog.Nop()
+	ctx := noLog.WithContext(context.Background())
+
+	// NOTE: `/unlabel` and its alias are disabled on the repository
+	commands := qa.payloadToCommands(ctx, payload, config)
+	require.Len(t, commands, 5)
+	assert.Equal(t, EventCommand{Command: "label", Arguments: []string{"~bug", "~p1", "~needs info"}, Macro: "triage-bug", Payload: payload}, *commands[0])
+	assert.Equal(t, EventCommand{Command: "label", Arguments: []string{"@mojombo"}, Macro: "triage-bug", Payload: payload}, *commands[1])
+	assert.Equal(t, EventCommand{Command: "label", Arguments: []string{"~bug", "~regression"}, Macro: "triage", Payload: payload}, *commands[2])
+	assert.Equal(t, EventCommand{Command: "label", Arguments: []string{"@mojombo"}, Macro: "triage", Payload: payload}, *commands[3])
+	// NOTE: recursive macros are stopped (they are rejected by the
+	//		 configuration validation anyway)
+	assert.Equal(t, EventCommand{Command: "label", Arguments: []string{}, Macro: "a", Payload: payload}, *commands[4])
+
+	// NOTE: disabled macros are ignored, even when called by other ones
+	config.Disabled = []string{"triage-bug"}
+	commands = qa.payloadToCommands(ctx, payload, config)
+	require.Len(t, commands, 2)
+	assert.Equal(t, EventCommand{Command: "unlabel", Arguments: []string{"~wontfix"}, Macro: "triage", Payload: payload}, *commands[0])
+	assert.Equal(t, EventCommand{Command: "label", Arguments: []string{}, Macro: "a", Payload: payload}, *commands[1])
+}
+
+func TestMacros_handle(t *testing.T) {
+	config := "macros: {triage-bug: [/label ~bug, /lgtm, /label ~needs-repro]}"
+
+	var report string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/issues/1/comments") {
+			body, _ := io.ReadAll(r.Body)
+			report = string(body)
+		}
+
+		switch {
+		case r.URL.Path == "/repos/xunleii/github-quick-actions/contents/.github/quick-actions.yml":
+			_, _ = fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "sha": "sha", "content": %q}`, base64.StdEncoding.EncodeToString([]byte(config)))
+		case strings.HasSuffix(r.URL.Path, "/collaborators/octocat/permission"):
+			_, _ = w.Write([]byte(`{"permission": "read", "role_name": "triage"}`))
+		case r.Method == http.MethodGet:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	label := &mockRestrictedQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}, restriction: Restriction{Permission: PermissionTriage}}
+	lgtm := &mockRestrictedQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}, restriction: Restriction{Permission: PermissionWrite}}
+
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+	qa.EnableRepositoryConfig()
+	qa.SetFeedback(Feedback{Report: true})
+	qa.AddQuickAction("label", label)
+	qa.AddQuickAction("lgtm", lgtm)
+
+	// NOTE: each step is authorized and reported on its own
+	err := qa.Handle(context.TODO(), "issue_comment", "", permissionPayload("octocat", "mojombo", "/triage-bug"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "@octocat is not allowed to run `/lgtm`: `write` permission required on the repository, but has `triage` permission")
+	assert.Equal(t, 2, label.handled)
+	assert.Zero(t, lgtm.handled)
+	assert.Contains(t, report, "| `/label ~bug` (from `/triage-bug`) | ✅ |")
+	assert.Contains(t, report, "| `/lgtm` (from `/triage-bug`) | ❌ @octocat is not allowed")
+	assert.Contains(t, report, "| `/label ~needs-repro` (from `/triage-bug`) | ✅ |")
+}
@@ -0,0 +1,174 @@
+package gh_quick_actions
+
+import (
+	"strings"
+)
+
+type (
+	// commandLine is a Markdown line which can contain a command, with its
+	// line number (starting at 0).
+	commandLine struct {
+		number int
+		text   string
+	}
+
+	// codeFence represents the opening fence of a fenced code block (like
+	// ``` or ~~~~).
+	codeFence struct {
+		char   byte
+		length int
+	}
+)
+
+// commandLines returns all lines of the given Markdown body which can
+// contain a command: lines starting with the given prefix (like `/`) in
+// normal paragraphs. Lines
+// inside fenced code blocks, indented code blocks, block quotes (including
+// their lazy continuation lines) and HTML comments are ignored; inline HTML
+// comments are removed from the returned lines.
+func commandLines(body, prefix string) []commandLine {
+	// NOTE: some Github clients send CRLF line endings
+	body = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(body)
+
+	var lines []commandLine
+	var fence *codeFence
+	var inComment, inQuote bool
+
+	for n, line := range strings.Split(body, "\n") {
+		switch {
+		case fence != nil:
+			if fence.closedBy(line) {
+				fence = nil
+			}
+			continue
+		case inComment:
+			idx := strings.Index(line, "-->")
+			if idx < 0 {
+				continue
+			}
+			// NOTE: the rest of the line doesn't start at the beginning
+			//		 of the line and so, cannot be a command
+			_, inComment = stripHTMLComments(line[idx+3:])
+			continue
+		case strings.TrimSpace(line) == "":
+			inQuote = false
+			continue
+		}
+
+		unindented, indent := trimIndentation(line)
+		if indent < 4 {
+			if fence = openingCodeFence(unindented); fence != nil {
+				inQuote = false
+				continue
+			}
+		}
+
+		text, opened := stripHTMLComments(line)
+		inComment = opened
+
+		switch {
+		case indent < 4 && strings.HasPrefix(unindented, ">"):
+			inQuote = true
+		case inQuote:
+			// NOTE: lines following a block quote are part of it until
+			//		 the next blank line (lazy continuation)
+		case strings.HasPrefix(text, prefix):
+			// NOTE: indented lines (like indented code) never start
+			//		 with the prefix
+			lines = append(lines, commandLine{number: n, text: text})
+		}
+	}
+	return lines
+}
+
+// trimIndentation removes the leading spaces of the given line and returns
+// its indentation width (tabulations count as 4 spaces).
+func trimIndentation(line string) (string, int) {
+	indent := 0
+	for i, c := range line {
+		switch c {
+		case ' ':
+			indent++
+		case '\t':
+			indent += 4 - indent%4
+		default:
+			return line[i:], indent
+		}
+	}
+	return "", indent
+}
+
+// openingCodeFence returns the code fence opened by the given unindented
+// line, or nil if the line doesn't open a fenced code block.
+func openingCodeFence(line string) *codeFence {
+	if line == "" || (line[0] != '`' && line[0] != '~') {
+		return nil
+	}
+
+	fence := &codeFence{char: line[0]}
+	for fence.length < len(line) && line[fence.length] == fence.char {
+		fence.length++
+	}
+	if fence.length < 3 {
+		return nil
+	}
+
+	// NOTE: backtick fences cannot have backticks in their info string
+	if fence.char == '`' && strings.Contains(line[fence.length:], "`") {
+		return nil
+	}
+	return fence
+}
+
+// closedBy returns true if the given line closes the fenced code block.
+func (fence codeFence) closedBy(line string) bool {
+	unindented, indent := trimIndentation(line)
+	if indent >= 4 {
+		return false
+	}
+
+	closing := strings.TrimRight(unindented, " \t")
+	return len(closing) >= fence.length && strings.Trim(closing, string(fence.char)) == ""
+}
+
+// stripHTMLComments removes all HTML comments from the given line and
+// returns true if the last comment is not closed on this line.
+func stripHTMLComments(line string) (string, bool) {
+	var stripped strings.Builder
+	for {
+		start := strings.Index(line, "<!--")
+		if start < 0 {
+			stripped.WriteString(line)
+			return stripped.String(), false
+		}
+		stripped.WriteString(line[:start])
+
+		end := strings.Index(line[start+4:], "-->")
+		if end < 0 {
+			return stripped.String(), true
+		}
+		line = line[start+4+end+3:]
+	}
+}
+
+// addedCommandLines returns the lines of next which are not in previous;
+// lines are compared regardless of their whitespaces and duplicated lines
+// are counted (a command added twice is returned once if it was already in
+// previous).
+func addedCommandLines(previous, next []commandLine) []commandLine {
+	existing := map[string]int{}
+	for _, line := range previous {
+		existing[strings.Join(strings.Fields(line.text), " ")]++
+	}
+
+	var added []commandLine
+	for _, line := range next {
+		key := strings.Join(strings.Fields(line.text), " ")
+		if existing[key] > 0 {
+			existing[key]--
+			continue
+		}
+		added = append(added, line)
+	}
+	return added
+}
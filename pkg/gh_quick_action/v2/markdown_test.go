@@ -0,0 +1,175 @@
+package gh_quick_actions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandLines(t *testing.T) {
+	ts := map[string]struct {
+		body  string
+		lines []commandLine
+	}{
+		"simple commands": {
+			body:  "/label ~bug\nsome text\n/assign @me",
+			lines: []commandLine{{0, "/label ~bug"}, {2, "/assign @me"}},
+		},
+		"CRLF line endings": {
+			body:  "/label ~bug\r\nsome text\r\n/assign @me\r\n",
+			lines: []commandLine{{0, "/label ~bug"}, {2, "/assign @me"}},
+		},
+		"CR line endings": {
+			body:  "/label ~bug\r/assign @me",
+			lines: []commandLine{{0, "/label ~bug"}, {1, "/assign @me"}},
+		},
+		"paragraph lines": {
+			body:  "Some description\n/label ~bug\nand more",
+			lines: []commandLine{{1, "/label ~bug"}},
+		},
+		"indented lines": {
+			body:  "  /label ~bug\n    /label ~bug\n\t/label ~bug",
+			lines: nil,
+		},
+		"backtick fenced code block": {
+			body:  "```\n/label ~bug\n```\n/assign @me",
+			lines: []commandLine{{3, "/assign @me"}},
+		},
+		"fenced code block with info string": {
+			body:  "```shell\n/label ~bug\n```\n/assign @me",
+			lines: []commandLine{{3, "/assign @me"}},
+		},
+		"tilde fenced code block": {
+			body:  "~~~~\n/label ~bug\n~~~\n/label ~feature\n~~~~~\n/assign @me",
+			lines: []commandLine{{5, "/assign @me"}},
+		},
+		"indented fenced code block": {
+			body:  "   ```\n/label ~bug\n  ```\n/assign @me",
+			lines: []commandLine{{3, "/assign @me"}},
+		},
+		"mismatched code fences": {
+			body:  "```\n/label ~bug\n~~~\n/label ~feature\n````\n/assign @me",
+			lines: []commandLine{{5, "/assign @me"}},
+		},
+		"unclosed fenced code block": {
+			body:  "/label ~bug\n```\n/assign @me",
+			lines: []commandLine{{0, "/label ~bug"}},
+		},
+		"inline code": {
+			body:  "``/label ~bug``\n/assign @me",
+			lines: []commandLine{{1, "/assign @me"}},
+		},
+		"invalid backtick fence": {
+			body:  "``` `invalid` ```\n/assign @me",
+			lines: []commandLine{{1, "/assign @me"}},
+		},
+		"block quote": {
+			body:  "> /label ~bug\n>/label ~bug\n   > /label ~bug\n\n/assign @me",
+			lines: []commandLine{{4, "/assign @me"}},
+		},
+		"block quote lazy continuation": {
+			body:  "> Some quote\n/label ~bug\n\n/assign @me",
+			lines: []commandLine{{3, "/assign @me"}},
+		},
+		"multi-line HTML comment": {
+			body:  "<!--\n/label ~bug\n-->\n/assign @me",
+			lines: []commandLine{{3, "/assign @me"}},
+		},
+		"HTML comment template": {
+			body:  "<!-- Use /label ~bug -->\n<!--\n/label ~bug\n--> /label ~feature\n/assign @me",
+			lines: []commandLine{{4, "/assign @me"}},
+		},
+		"inline HTML comments": {
+			body:  "/label ~bug <!-- ~feature --> ~documentation <!-- ~question\n/label ~feature\n-->\n/assign @me",
+			lines: []commandLine{{0, "/label ~bug  ~documentation "}, {3, "/assign @me"}},
+		},
+		"HTML comment inside code block": {
+			body:  "```\n<!--\n```\n/assign @me",
+			lines: []commandLine{{3, "/assign @me"}},
+		},
+		"code fence inside HTML comment": {
+			body:  "<!--\n```\n-->\n/assign @me",
+			lines: []commandLine{{3, "/assign @me"}},
+		},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.lines, commandLines(tc.body, "/"))
+		})
+	}
+}
+
+func TestAddedCommandLines(t *testing.T) {
+	ts := map[string]struct {
+		previous, next string
+		lines          []commandLine
+	}{
+		"typo fixed": {
+			previous: "/lable ~bug",
+			next:     "/label ~bug",
+			lines:    []commandLine{{0, "/label ~bug"}},
+		},
+		"command added": {
+			previous: "/label ~bug\nSome text",
+			next:     "/label ~bug\nSome text\n/assign @me",
+			lines:    []commandLine{{2, "/assign @me"}},
+		},
+		"command moved": {
+			previous: "Some text\n/label ~bug",
+			next:     "/label   ~bug\nSome text",
+			lines:    nil,
+		},
+		"command duplicated": {
+			previous: "/label ~bug",
+			next:     "/label ~bug\n/label ~bug",
+			lines:    []commandLine{{1, "/label ~bug"}},
+		},
+		"command uncommented": {
+			previous: "<!-- /label ~bug -->\n```\n/assign @me\n```",
+			next:     "/label ~bug\n\n/assign @me",
+			lines:    []commandLine{{0, "/label ~bug"}, {2, "/assign @me"}},
+		},
+		"command removed": {
+			previous: "/label ~bug\n/assign @me",
+			next:     "/assign @me",
+			lines:    nil,
+		},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.lines, addedCommandLines(commandLines(tc.previous, "/"), commandLines(tc.next, "/")))
+		})
+	}
+}
+
+func FuzzCommandLines(f *testing.F) {
+	f.Add("/label ~bug\nsome text\n/assign @me")
+	f.Add("/label ~bug\r\n```\r\n/label ~feature\r\n```\r\n")
+	f.Add("> /label ~bug\n/label ~feature\n\n/assign @me")
+	f.Add("<!-- /label ~bug -->\n<!--\n/label ~feature\n-->")
+	f.Add("~~~\n/label ~bug\n~~~~\n    /label ~feature\n\t/label ~feature")
+
+	f.Fuzz(func(t *testing.T, body string) {
+		lines := commandLines(body, "/")
+
+		count := len(strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(body), "\n"))
+		previous := -1
+		for _, line := range lines {
+			if !strings.HasPrefix(line.text, "/") {
+				t.Errorf("line n°%d doesn't start with '/': %q", line.number, line.text)
+			}
+			if strings.ContainsAny(line.text, "\r\n") || strings.Contains(line.text, "<!--") {
+				t.Errorf("line n°%d contains a line ending or an HTML comment: %q", line.number, line.text)
+			}
+			if line.number <= previous || line.number >= count {
+				t.Errorf("invalid line number %d (previous: %d, lines: %d)", line.number, previous, count)
+			}
+			previous = line.number
+		}
+	})
+}
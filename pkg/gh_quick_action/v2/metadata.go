@@ -0,0 +1,206 @@
+package gh_quick_actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	// Describer is an optional interface implemented by quick actions in
+	// order to describe themselves; it is used by /help and to generate
+	// the documentation.
+	Describer interface {
+		Metadata() Metadata
+	}
+
+	// Metadata describes a quick action.
+	Metadata struct {
+		// Usage lists the syntaxes of the command, like
+		// `/assign @user [@user...]`. If empty, it is generated from the
+		// arguments schema (see ArgumentsSchema).
+		Usage []string
+		// Description is a one-line description of the quick action.
+		Description string
+		// Details completes the description with notes, like the
+		// required configuration.
+		Details string
+		// Aliases lists other command names that trigger the quick
+		// action, like `remove_label` for `unlabel`.
+		Aliases []string
+		// Examples lists some commands using this quick action.
+		Examples []string
+	}
+
+	// QuickActionInfo describes a registered quick action.
+	QuickActionInfo struct {
+		Command string
+		Events  []EventType
+		// Restriction defines who is allowed to run the quick action (see
+		// Restricted).
+		Restriction Restriction
+		Metadata
+	}
+)
+
+// DescribeQuickAction returns the metadata of the given quick action; the
+// usage is generated from its arguments schema if not defined.
+func DescribeQuickAction(command string, action QuickAction) Metadata {
+	var metadata Metadata
+	if describer, valid := action.(Describer); valid {
+		metadata = describer.Metadata()
+	}
+
+	if len(metadata.Usage) == 0 {
+		var schema []ArgumentSpec
+		if action, valid := action.(ArgumentsSchema); valid {
+			schema = action.Arguments()
+		}
+		metadata.Usage = []string{Usage(command, schema)}
+	}
+	return metadata
+}
+
+// QuickActions lists all registered quick actions (without their aliases)
+// sorted by command name. If some event types are given, only quick actions
+// triggered by at least one of them are returned.
+func (a GithubQuickActions) QuickActions(eventTypes ...EventType) []QuickActionInfo {
+	infos := map[string]*QuickActionInfo{}
+	for eventType, actions := range a.registry {
+		for command, action := range actions {
+			if _, isAlias := a.aliases[command]; isAlias {
+				continue
+			}
+
+			if infos[command] == nil {
+				infos[command] = &QuickActionInfo{Command: command, Restriction: restrictionOf(action), Metadata: DescribeQuickAction(command, action)}
+			}
+			infos[command].Events = append(infos[command].Events, eventType)
+		}
+	}
+
+	var list []QuickActionInfo
+	for _, info := range infos {
+		if len(eventTypes) > 0 && !triggeredBy(info.Events, eventTypes) {
+			continue
+		}
+
+		sort.Slice(info.Events, func(i, j int) bool { return info.Events[i] < info.Events[j] })
+		list = append(list, *info)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Command < list[j].Command })
+	return list
+}
+
+// QuickAction returns the quick action registered for the given command (or
+// one of its aliases) and event type.
+func (a GithubQuickActions) QuickAction(eventType EventType, command string) (QuickActionInfo, bool) {
+	if canonical, isAlias := a.aliases[command]; isAlias {
+		command = canonical
+	}
+
+	action, exists := a.registry[eventType][command]
+	if !exists {
+		return QuickActionInfo{}, false
+	}
+
+	info := QuickActionInfo{Command: command, Restriction: restrictionOf(action), Metadata: DescribeQuickAction(command, action)}
+	for eventType, actions := range a.registry {
+		if _, exists := actions[command]; exists {
+			info.Events = append(info.Events, eventType)
+		}
+	}
+	sort.Slice(info.Events, func(i, j int) bool { return info.Events[i] < info.Events[j] })
+	return info, true
+}
+
+// RepositoryCommands lists the macros and the plugins of the repository
+// available on the given event type, sorted by name.
+func (c *RepositoryConfig) RepositoryCommands(eventType EventType) []QuickActionInfo {
+	if c == nil {
+		return nil
+	}
+
+	var infos []QuickActionInfo
+	for _, name := range sortedKeys(c.Macros) {
+		infos = append(infos, QuickActionInfo{
+			Command: name,
+			Events:  repositoryCommandEvents,
+			Metadata: Metadata{
+				Usage:       []string{fmt.Sprintf("%s%s [<argument>...]", defaultPrefix, name)},
+				Description: fmt.Sprintf("Macro running `%s`.", strings.Join(c.Macros[name], "`, `")),
+			},
+		})
+	}
+	for _, name := range sortedKeys(c.Plugins) {
+		plugin, available := c.plugin(name, eventType)
+		if !available {
+			continue
+		}
+
+		action := &pluginQuickAction{name: name, plugin: plugin}
+		infos = append(infos, QuickActionInfo{Command: name, Events: plugin.events(), Restriction: restrictionOf(action), Metadata: DescribeQuickAction(name, action)})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Command < infos[j].Command })
+	return infos
+}
+
+// Describe returns the given quick action description as seen on the
+// repository: usages and examples use the repository prefix, the
+// restriction is overridden by the configured permission and the repository
+// aliases are appended to the quick action ones.
+func (c *RepositoryConfig) Describe(info QuickActionInfo) QuickActionInfo {
+	info.Restriction = c.restriction(info.Command, info.Restriction)
+
+	prefix := c.CommandPrefix()
+	info.Usage = withPrefix(info.Usage, prefix)
+	info.Examples = withPrefix(info.Examples, prefix)
+
+	if c == nil {
+		return info
+	}
+	// NOTE: the capacity is limited in order to never append to the
+	//		 aliases shared with the quick action metadata
+	info.Aliases = info.Aliases[:len(info.Aliases):len(info.Aliases)]
+	for _, alias := range sortedKeys(c.Aliases) {
+		if c.Aliases[alias] == info.Command {
+			info.Aliases = append(info.Aliases, alias)
+		}
+	}
+	return info
+}
+
+// withPrefix replaces the default prefix of the given commands by the
+// given one.
+func withPrefix(commands []string, prefix string) []string {
+	if prefix == defaultPrefix {
+		return commands
+	}
+
+	prefixed := make([]string, len(commands))
+	for i, command := range commands {
+		prefixed[i] = prefix + strings.TrimPrefix(command, defaultPrefix)
+	}
+	return prefixed
+}
+
+// restrictionOf returns the restriction of the given quick action, if any.
+func restrictionOf(action QuickAction) Restriction {
+	if restricted, valid := action.(Restricted); valid {
+		return restricted.Restriction()
+	}
+	return Restriction{}
+}
+
+// triggeredBy returns true if at least one of the given event types is in
+// the list of events.
+func triggeredBy(events []EventType, eventTypes []EventType) bool {
+	for _, eventType := range eventTypes {
+		for _, event := range events {
+			if event == eventType {
+				return true
+			}
+		}
+	}
+	return false
+}
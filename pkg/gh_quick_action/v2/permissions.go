@@ -0,0 +1,164 @@
+package gh_quick_actions
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+)
+
+// Permission is the permission level of a user on a repository.
+type Permission string
+
+const (
+	PermissionNone     Permission = "none"
+	PermissionRead     Permission = "read"
+	PermissionTriage   Permission = "triage"
+	PermissionWrite    Permission = "write"
+	PermissionMaintain Permission = "maintain"
+	PermissionAdmin    Permission = "admin"
+)
+
+type (
+	// Restricted is an optional interface implemented by quick actions
+	// which can only be run by users with a minimum permission on the
+	// repository; quick actions not implementing it can be run by anyone
+	// able to comment.
+	Restricted interface {
+		Restriction() Restriction
+	}
+
+	// Restriction defines who is allowed to run a quick action.
+	Restriction struct {
+		// Permission is the minimum permission required on the repository.
+		Permission Permission
+		// AllowAuthor allows the author of the issue or pull request to
+		// run the quick action on it, whatever their permission.
+		AllowAuthor bool
+	}
+
+	// PermissionError is returned when a user is not allowed to run a
+	// command.
+	PermissionError struct {
+		User        string
+		Command     string
+		Restriction Restriction
+		Permission  Permission
+	}
+
+	// permissionResolver fetches the permission of the event sender once per
+	// event, only if a restricted quick action is triggered.
+	permissionResolver struct {
+		payload    EventPayload
+		permission Permission
+	}
+)
+
+// permissionLevels orders permissions from the lowest to the highest.
+var permissionLevels = map[Permission]int{
+	PermissionNone:     0,
+	PermissionRead:     1,
+	PermissionTriage:   2,
+	PermissionWrite:    3,
+	PermissionMaintain: 4,
+	PermissionAdmin:    5,
+}
+
+// Includes returns true if the permission grants at least the given one.
+func (p Permission) Includes(required Permission) bool {
+	return permissionLevels[p] >= permissionLevels[required]
+}
+
+func (err *PermissionError) Error() string {
+	required := fmt.Sprintf("`%s` permission", err.Restriction.Permission)
+	if err.Restriction.AllowAuthor {
+		required += " (or being the author of the issue or pull request)"
+	}
+	return fmt.Sprintf("@%s is not allowed to run `/%s`: %s required on the repository, but has `%s` permission", err.User, err.Command, required, err.Permission)
+}
+
+// authorize checks that the sender of the event is allowed to run the given
+// command, using the restriction of the quick action overridden by the
+// repository configuration.
+func (r *permissionResolver) authorize(ctx *EventContext, action QuickAction, command *EventCommand) error {
+	restriction := ctx.Config.restriction(command.Command, restrictionOf(action))
+	if restriction.Permission == "" || PermissionNone.Includes(restriction.Permission) {
+		return nil
+	}
+
+	sender, author := payloadUsers(r.payload)
+	if restriction.AllowAuthor && sender != "" && strings.EqualFold(sender, author) {
+		return nil
+	}
+
+	if r.permission == "" {
+		permission, err := userPermission(ctx, r.payload, sender)
+		if err != nil {
+			return fmt.Errorf("failed to get permission of @%s: %w", sender, err)
+		}
+		r.permission = permission
+	}
+
+	if !r.permission.Includes(restriction.Permission) {
+		return &PermissionError{User: sender, Command: command.Command, Restriction: restriction, Permission: r.permission}
+	}
+	return nil
+}
+
+// userPermission fetches the permission of the given user on the repository
+// of the event, using the collaborator permission API.
+func userPermission(ctx *EventContext, payload EventPayload, user string) (Permission, error) {
+	if user == "" {
+		return PermissionNone, nil
+	}
+
+	client, err := ctx.NewClient(payload)
+	if err != nil {
+		return "", err
+	}
+
+	// NOTE: github.RepositoryPermissionLevel only contains the legacy
+	//		 permission (admin, write, read or none); `role_name` is
+	//		 required to distinguish triage and maintain roles
+	u := fmt.Sprintf("repos/%v/%v/collaborators/%v/permission", payload.RepositoryOwner(), payload.RepositoryName(), user)
+	req, err := client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var level struct {
+		Permission string `json:"permission"`
+		RoleName   string `json:"role_name"`
+	}
+	resp, err := client.Do(ctx, req, &level)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return PermissionNone, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if _, known := permissionLevels[Permission(level.RoleName)]; known {
+		return Permission(level.RoleName), nil
+	}
+	if _, known := permissionLevels[Permission(level.Permission)]; known {
+		return Permission(level.Permission), nil
+	}
+	return PermissionNone, nil
+}
+
+// payloadUsers returns the login of the user who sent the event and the one
+// of the author of the issue or pull request.
+func payloadUsers(payload EventPayload) (sender, author string) {
+	switch event := payload.Raw().(type) {
+	case *github.IssuesEvent:
+		return event.GetSender().GetLogin(), event.GetIssue().GetUser().GetLogin()
+	case *github.IssueCommentEvent:
+		return event.GetSender().GetLogin(), event.GetIssue().GetUser().GetLogin()
+	case *github.PullRequestEvent:
+		return event.GetSender().GetLogin(), event.GetPullRequest().GetUser().GetLogin()
+	case *github.PullRequestReviewCommentEvent:
+		return event.GetSender().GetLogin(), event.GetPullRequest().GetUser().GetLogin()
+	}
+	return "", ""
+}
@@ -0,0 +1,125 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermission_Includes(t *testing.T) {
+	assert.True(t, PermissionAdmin.Includes(PermissionTriage))
+	assert.True(t, PermissionTriage.Includes(PermissionTriage))
+	assert.True(t, PermissionRead.Includes(PermissionNone))
+	assert.False(t, PermissionRead.Includes(PermissionTriage))
+	assert.False(t, PermissionNone.Includes(PermissionRead))
+	assert.False(t, Permission("unknown").Includes(PermissionRead))
+}
+
+func TestPermissionError(t *testing.T) {
+	err := &PermissionError{User: "octocat", Command: "label", Restriction: Restriction{Permission: PermissionTriage}, Permission: PermissionRead}
+	assert.EqualError(t, err, "@octocat is not allowed to run `/label`: `triage` permission required on the repository, but has `read` permission")
+
+	err.Restriction.AllowAuthor = true
+	assert.EqualError(t, err, "@octocat is not allowed to run `/label`: `triage` permission (or being the author of the issue or pull request) required on the repository, but has `read` permission")
+}
+
+// newPermissionTestServer returns a server answering the collaborator
+// permission API with the given permissions by user, and counting calls.
+func newPermissionTestServer(t *testing.T, permissions map[string]string, calls *int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+
+		user := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/xunleii/github-quick-actions/collaborators/"), "/permission")
+		level, exists := permissions[user]
+		if !exists {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(level))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func permissionPayload(sender, author, body string) []byte {
+	return []byte(fmt.Sprintf(`{
+		"action": "created",
+		"comment": {"id": 42, "body": %q},
+		"issue": {"number": 1, "user": {"login": %q}},
+		"sender": {"login": %q},
+		"repository": {"name": "github-quick-actions", "owner": {"login": "xunleii"}},
+		"installation": {"id": 1}
+	}`, body, author, sender))
+}
+
+func TestAuthorize(t *testing.T) {
+	permissions := map[string]string{
+		"reader":     `{"permission": "read", "role_name": "read"}`,
+		"triager":    `{"permission": "read", "role_name": "triage"}`,
+		"maintainer": `{"permission": "write", "role_name": "maintain"}`,
+		"custom":     `{"permission": "write", "role_name": "custom-role"}`,
+	}
+
+	ts := map[string]struct {
+		sender, author string
+		restriction    Restriction
+		calls          int
+		err            string
+	}{
+		"unrestricted":       {sender: "stranger", author: "octocat", restriction: Restriction{}},
+		"enough permission":  {sender: "triager", author: "octocat", restriction: Restriction{Permission: PermissionTriage}, calls: 1},
+		"higher permission":  {sender: "maintainer", author: "octocat", restriction: Restriction{Permission: PermissionWrite}, calls: 1},
+		"custom role":        {sender: "custom", author: "octocat", restriction: Restriction{Permission: PermissionWrite}, calls: 1},
+		"author allowed":     {sender: "octocat", author: "octocat", restriction: Restriction{Permission: PermissionTriage, AllowAuthor: true}},
+		"author not allowed": {sender: "reader", author: "reader", restriction: Restriction{Permission: PermissionTriage}, calls: 1, err: "@reader is not allowed to run `/cmd`: `triage` permission required on the repository, but has `read` permission"},
+		"missing permission": {sender: "reader", author: "octocat", restriction: Restriction{Permission: PermissionTriage, AllowAuthor: true}, calls: 1, err: "@reader is not allowed to run `/cmd`: `triage` permission (or being the author of the issue or pull request) required on the repository, but has `read` permission"},
+		"not a collaborator": {sender: "stranger", author: "octocat", restriction: Restriction{Permission: PermissionRead}, calls: 1, err: "@stranger is not allowed to run `/cmd`: `read` permission required on the repository, but has `none` permission"},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			srv := newPermissionTestServer(t, permissions, &calls)
+			qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+
+			action := &mockRestrictedQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}, restriction: tc.restriction}
+			qa.AddQuickAction("cmd", action)
+
+			err := qa.Handle(context.TODO(), "issue_comment", "", permissionPayload(tc.sender, tc.author, "/cmd\n/cmd"))
+			assert.Equal(t, tc.calls, calls)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				assert.Zero(t, action.handled)
+
+				var permissionErr *PermissionError
+				assert.True(t, errors.As(err, &permissionErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, action.handled)
+		})
+	}
+}
+
+// mockRestrictedQuickAction implements a simple QuickAction with a
+// restriction, counting how many times it has been run
+type mockRestrictedQuickAction struct {
+	mockQuickAction
+	restriction Restriction
+	handled     int
+}
+
+func (m *mockRestrictedQuickAction) Restriction() Restriction { return m.restriction }
+func (m *mockRestrictedQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	m.handled++
+	return m.retErr
+}
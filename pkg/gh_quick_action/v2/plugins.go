@@ -0,0 +1,502 @@
+package gh_quick_actions
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"github.com/thoas/go-funk"
+)
+
+// PluginSignatureHeader is the header containing the HMAC-SHA256 signature of
+// the plugin requests body, like `sha256=<hex digest>`.
+const PluginSignatureHeader = "X-Quick-Actions-Signature-256"
+
+// PluginProtocolVersion is the version of the plugin request and response
+// formats.
+const PluginProtocolVersion = 1
+
+const (
+	// defaultPluginTimeout is the timeout of plugin requests if none is
+	// configured.
+	defaultPluginTimeout = 10 * time.Second
+	// maxPluginResponseSize is the maximum size of plugin responses.
+	maxPluginResponseSize = 1 << 20
+	// maxPluginMutations is the maximum number of mutations a plugin can
+	// request per command.
+	maxPluginMutations = 20
+)
+
+type (
+	// Plugin is a command declared in the repository configuration and run
+	// by an external HTTP service: the command is sent as a PluginRequest
+	// and the service replies with a PluginResponse describing what to do.
+	Plugin struct {
+		// URL is the endpoint receiving the commands.
+		URL string `yaml:"url"`
+		// Events lists the events where the command is available
+		// (issue_comment and pull_request_review_comment by default).
+		Events []EventType `yaml:"events,omitempty"`
+		// Permission is the minimum permission required to run the
+		// command (triage by default).
+		Permission Permission `yaml:"permission,omitempty"`
+	}
+
+	// PluginSettings configures how plugins are called.
+	PluginSettings struct {
+		// Endpoints contains the URL prefixes plugins can be declared
+		// with, and the secret used to sign the requests sent to each of
+		// them (see PluginSignatureHeader); other URLs are refused.
+		Endpoints map[string]string
+		// Timeout is the timeout of each plugin request (10s if not set).
+		Timeout time.Duration
+	}
+
+	// PluginRequest is the JSON body sent to plugins.
+	PluginRequest struct {
+		Version int `json:"version"`
+		Command struct {
+			Name      string   `json:"name"`
+			Arguments []string `json:"arguments"`
+			// Macro is the name of the macro the command comes from, if
+			// any.
+			Macro string `json:"macro,omitempty"`
+		} `json:"command"`
+		Event struct {
+			Type   EventType   `json:"type"`
+			Action EventAction `json:"action"`
+			// Payload is the Github webhook payload.
+			Payload json.RawMessage `json:"payload"`
+		} `json:"event"`
+		Repository struct {
+			Owner string `json:"owner"`
+			Name  string `json:"name"`
+		} `json:"repository"`
+		IssueNumber int `json:"issue_number"`
+		// Token is a read-only installation token restricted to the
+		// repository, revoked as soon as the plugin replied.
+		Token struct {
+			Value     string    `json:"value"`
+			ExpiresAt time.Time `json:"expires_at"`
+		} `json:"token"`
+	}
+
+	// PluginResponse is the JSON body replied by plugins.
+	PluginResponse struct {
+		// Error fails the command with the given message; mutations and
+		// comment are then ignored.
+		Error string `json:"error,omitempty"`
+		// Mutations are the Github API requests to run, in order.
+		Mutations []PluginMutation `json:"mutations,omitempty"`
+		// Comment is posted on the issue or pull request, if not empty.
+		Comment string `json:"comment,omitempty"`
+	}
+
+	// PluginMutation is a Github API request, on the repository where the
+	// command comes from.
+	PluginMutation struct {
+		// Method is one of POST, PUT, PATCH or DELETE.
+		Method string `json:"method"`
+		// Path is the API path relative to the repository, like
+		// `issues/1/labels`; only issues, labels and comments can be
+		// changed.
+		Path string          `json:"path"`
+		Body json.RawMessage `json:"body,omitempty"`
+	}
+
+	// pluginRunner calls plugins with the configured settings.
+	pluginRunner struct {
+		settings PluginSettings
+		client   *http.Client
+	}
+
+	// pluginQuickAction implements QuickAction for a plugin of the
+	// repository configuration.
+	pluginQuickAction struct {
+		name   string
+		plugin *Plugin
+		runner *pluginRunner
+	}
+)
+
+// pluginMutationEndpoints lists the API paths, relative to the repository,
+// plugins can change with their allowed methods: issues, labels and comments
+// only.
+var pluginMutationEndpoints = []struct {
+	path    *regexp.Regexp
+	methods []string
+}{
+	{regexp.MustCompile(`^/issues/\d+$`), []string{http.MethodPatch}},
+	{regexp.MustCompile(`^/issues/\d+/labels$`), []string{http.MethodPost, http.MethodPut, http.MethodDelete}},
+	{regexp.MustCompile(`^/issues/\d+/labels/[^/]+$`), []string{http.MethodDelete}},
+	{regexp.MustCompile(`^/issues/\d+/assignees$`), []string{http.MethodPost, http.MethodDelete}},
+	{regexp.MustCompile(`^/issues/\d+/comments$`), []string{http.MethodPost}},
+	{regexp.MustCompile(`^/issues/comments/\d+$`), []string{http.MethodPatch, http.MethodDelete}},
+	{regexp.MustCompile(`^/labels$`), []string{http.MethodPost}},
+	{regexp.MustCompile(`^/labels/[^/]+$`), []string{http.MethodPatch, http.MethodDelete}},
+}
+
+// repositoryCommandEvents lists the events where the repository commands
+// (macros and plugins) can be available.
+var repositoryCommandEvents = []EventType{EventTypeIssue, EventTypeIssueComment, EventTypePullRequest, EventTypePullRequestReviewComment}
+
+// EnablePlugins enables the plugins declared in repository configurations
+// (see Plugin).
+func (a *GithubQuickActions) EnablePlugins(settings PluginSettings) {
+	if settings.Timeout == 0 {
+		settings.Timeout = defaultPluginTimeout
+	}
+	a.plugins = &pluginRunner{settings: settings, client: &http.Client{Timeout: settings.Timeout}}
+}
+
+// plugin returns the given plugin, if declared and available on the given
+// event type.
+func (c *RepositoryConfig) plugin(name string, eventType EventType) (*Plugin, bool) {
+	if c == nil || c.Plugins[name] == nil {
+		return nil, false
+	}
+	plugin := c.Plugins[name]
+	for _, event := range plugin.events() {
+		if event == eventType {
+			return plugin, true
+		}
+	}
+	return nil, false
+}
+
+// events returns the events where the plugin is available.
+func (p *Plugin) events() []EventType {
+	if len(p.Events) == 0 {
+		return []EventType{EventTypeIssueComment, EventTypePullRequestReviewComment}
+	}
+	return p.Events
+}
+
+// validatePlugins checks the plugins of the given configuration.
+func (a GithubQuickActions) validatePlugins(config *RepositoryConfig) error {
+	errs := &multierror.Error{}
+	errs.ErrorFormat = configErrorFormat
+
+	if len(config.Plugins) > 0 && a.plugins == nil {
+		return multierror.Append(errs, fmt.Errorf("plugins: plugins are not enabled on this instance"))
+	}
+
+	for _, name := range sortedKeys(config.Plugins) {
+		plugin := config.Plugins[name]
+		if _, exists := a.quickAction(name); exists {
+			errs = multierror.Append(errs, fmt.Errorf("plugins.%s: command '%s' already exists", name, name))
+		} else if _, exists := config.Alias(name); exists {
+			errs = multierror.Append(errs, fmt.Errorf("plugins.%s: alias '%s' already exists", name, name))
+		} else if _, exists := config.macro(name); exists {
+			errs = multierror.Append(errs, fmt.Errorf("plugins.%s: macro '%s' already exists", name, name))
+		} else if strings.IndexFunc(name, unicode.IsSpace) >= 0 || name == "" {
+			errs = multierror.Append(errs, fmt.Errorf("plugins.%s: invalid plugin name", name))
+		}
+		if plugin == nil {
+			errs = multierror.Append(errs, fmt.Errorf("plugins.%s: url is required", name))
+			continue
+		}
+
+		if _, err := a.plugins.endpoint(plugin.URL); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("plugins.%s.url: %w", name, err))
+		}
+		for _, event := range plugin.Events {
+			supported := false
+			for _, pluginEvent := range repositoryCommandEvents {
+				supported = supported || event == pluginEvent
+			}
+			if !supported {
+				errs = multierror.Append(errs, fmt.Errorf("plugins.%s.events: unsupported event '%s' (expected issues, issue_comment, pull_request or pull_request_review_comment)", name, event))
+			}
+		}
+		// NOTE: plugins change the repository, so they can't be run by
+		//		 anyone
+		if _, valid := permissionLevels[plugin.Permission]; (!valid && plugin.Permission != "") || plugin.Permission == PermissionNone {
+			errs = multierror.Append(errs, fmt.Errorf("plugins.%s.permission: invalid permission '%s' (expected read, triage, write, maintain or admin)", name, plugin.Permission))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// endpoint returns the secret of the endpoint the given plugin URL belongs
+// to; it fails if the URL doesn't match any endpoint of the instance.
+func (r *pluginRunner) endpoint(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid URL '%s'", rawURL)
+	}
+
+	// NOTE: the longest endpoint wins, in order to give a dedicated
+	//		 secret to a plugin hosted under a shared endpoint
+	match, secret := "", ""
+	for prefix, prefixSecret := range r.settings.Endpoints {
+		endpoint, err := url.Parse(prefix)
+		if err != nil || endpoint.Scheme != u.Scheme || endpoint.Host != u.Host || !pathHasPrefix(u.Path, endpoint.Path) {
+			continue
+		}
+		if len(prefix) > len(match) {
+			match, secret = prefix, prefixSecret
+		}
+	}
+	if match == "" {
+		return "", fmt.Errorf("'%s' is not allowed on this instance", rawURL)
+	}
+	return secret, nil
+}
+
+// pathHasPrefix returns true if the given URL path is the given prefix or
+// one of its sub-paths.
+func pathHasPrefix(path, prefix string) bool {
+	if prefix == "" || strings.HasSuffix(prefix, "/") {
+		return strings.HasPrefix(path, prefix)
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// pluginQuickAction returns the quick action running the given plugin.
+func (a GithubQuickActions) pluginQuickAction(name string, plugin *Plugin) QuickAction {
+	return &pluginQuickAction{name: name, plugin: plugin, runner: a.plugins}
+}
+
+func (qa pluginQuickAction) TriggerOnEvents() []EventType { return qa.plugin.events() }
+
+func (qa pluginQuickAction) Restriction() Restriction {
+	if qa.plugin.Permission == "" {
+		return Restriction{Permission: PermissionTriage}
+	}
+	return Restriction{Permission: qa.plugin.Permission}
+}
+
+func (qa pluginQuickAction) Metadata() Metadata {
+	return Metadata{
+		Usage:       []string{fmt.Sprintf("%s%s [<argument>...]", defaultPrefix, qa.name)},
+		Description: "Run by a plugin of the repository.",
+	}
+}
+
+func (qa pluginQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("quick_action", qa.name).
+		Str("plugin", qa.plugin.URL).
+		Logger()
+
+	logger.Info().Msgf("handle `/%s` (args: %v)", qa.name, command.Arguments)
+
+	if qa.runner == nil {
+		return fmt.Errorf("plugins are not enabled on this instance")
+	}
+
+	client, err := ctx.NewClient(command.Payload)
+	if err != nil {
+		return err
+	}
+
+	token, err := pluginToken(ctx, command.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to create plugin token: %w", err)
+	}
+	response, err := qa.runner.call(ctx, qa.plugin, command, token)
+	if err := qa.runner.revokeToken(ctx, client, token); err != nil {
+		logger.Warn().Err(err).Msgf("failed to revoke plugin token: %s", err)
+	}
+	if err != nil {
+		return fmt.Errorf("plugin `/%s` failed: %w", qa.name, err)
+	}
+
+	if response.Error != "" {
+		return fmt.Errorf("plugin `/%s` failed: %s", qa.name, response.Error)
+	}
+	// NOTE: all mutations are checked before running any of them
+	for _, mutation := range response.Mutations {
+		if err := validatePluginMutation(mutation); err != nil {
+			return fmt.Errorf("plugin `/%s` failed: %w", qa.name, err)
+		}
+	}
+	for _, mutation := range response.Mutations {
+		if err := runPluginMutation(ctx, client, command.Payload, mutation); err != nil {
+			return fmt.Errorf("plugin `/%s` failed: %w", qa.name, err)
+		}
+	}
+	if response.Comment != "" {
+		_, _, err := client.Issues.CreateComment(ctx, command.Payload.RepositoryOwner(), command.Payload.RepositoryName(), command.Payload.IssueNumber(), &github.IssueComment{Body: github.String(response.Comment)})
+		return err
+	}
+	return nil
+}
+
+// pluginToken creates a read-only installation token restricted to the
+// repository where the event comes from; mutations are run by the
+// application itself, once validated.
+func pluginToken(ctx *EventContext, payload EventPayload) (*github.InstallationToken, error) {
+	event, valid := payload.Raw().(interface{ GetInstallation() *github.Installation })
+	if !valid {
+		return nil, fmt.Errorf("invalid event type %T", payload.Raw())
+	}
+
+	client, err := ctx.NewAppClient()
+	if err != nil {
+		return nil, err
+	}
+	token, _, err := client.Apps.CreateInstallationToken(ctx, event.GetInstallation().GetID(), &github.InstallationTokenOptions{
+		Repositories: []string{payload.RepositoryName()},
+		Permissions: &github.InstallationPermissions{
+			Contents:     github.String("read"),
+			Issues:       github.String("read"),
+			Metadata:     github.String("read"),
+			PullRequests: github.String("read"),
+		},
+	})
+	return token, err
+}
+
+// revokeToken revokes the given installation token, once the plugin
+// replied.
+func (r *pluginRunner) revokeToken(ctx *EventContext, client *github.Client, token *github.InstallationToken) error {
+	// NOTE: the installation client authenticates with its own token,
+	//		 so the request is sent without it
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, client.BaseURL.String()+"installation/token", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Authorization", "token "+token.GetToken())
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// call sends the given command to the plugin and returns its response.
+func (r *pluginRunner) call(ctx *EventContext, plugin *Plugin, command *EventCommand, token *github.InstallationToken) (*PluginResponse, error) {
+	secret, err := r.endpoint(plugin.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	request := PluginRequest{Version: PluginProtocolVersion, IssueNumber: command.Payload.IssueNumber()}
+	request.Command.Name = command.Command
+	request.Command.Arguments = command.Arguments
+	request.Command.Macro = command.Macro
+	request.Event.Type = command.Payload.Type()
+	request.Event.Action = command.Payload.Action()
+	request.Repository.Owner = command.Payload.RepositoryOwner()
+	request.Repository.Name = command.Payload.RepositoryName()
+	request.Token.Value = token.GetToken()
+	request.Token.ExpiresAt = token.GetExpiresAt()
+
+	if request.Event.Payload, err = json.Marshal(command.Payload.Raw()); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, plugin.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(PluginSignatureHeader, SignPluginRequest(secret, body))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxPluginResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var response PluginResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if len(response.Mutations) > maxPluginMutations {
+		return nil, fmt.Errorf("invalid response: more than %d mutations", maxPluginMutations)
+	}
+	return &response, nil
+}
+
+// validatePluginMutation checks that the given mutation only changes the
+// issues, the labels or the comments of the repository (see
+// pluginMutationEndpoints).
+func validatePluginMutation(mutation PluginMutation) error {
+	switch mutation.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("invalid mutation method '%s' (expected POST, PUT, PATCH or DELETE)", mutation.Method)
+	}
+
+	// NOTE: mutations are restricted to the repository
+	cleaned := path.Clean("/" + mutation.Path)
+	if cleaned == "/" || strings.Contains(mutation.Path, "..") || strings.ContainsAny(mutation.Path, "?#") {
+		return fmt.Errorf("invalid mutation path '%s'", mutation.Path)
+	}
+
+	for _, endpoint := range pluginMutationEndpoints {
+		if endpoint.path.MatchString(cleaned) && funk.ContainsString(endpoint.methods, mutation.Method) {
+			return nil
+		}
+	}
+	return fmt.Errorf("mutation `%s %s` is not allowed (only issues, labels and comments can be changed)", mutation.Method, mutation.Path)
+}
+
+// runPluginMutation runs the given mutation on the repository where the
+// event comes from; it must have been validated by validatePluginMutation.
+func runPluginMutation(ctx *EventContext, client *github.Client, payload EventPayload, mutation PluginMutation) error {
+	u := fmt.Sprintf("repos/%v/%v%v", payload.RepositoryOwner(), payload.RepositoryName(), path.Clean("/"+mutation.Path))
+
+	var body interface{}
+	if len(mutation.Body) > 0 {
+		body = mutation.Body
+	}
+	req, err := client.NewRequest(mutation.Method, u, body)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		return fmt.Errorf("mutation `%s %s` failed: %w", mutation.Method, mutation.Path, err)
+	}
+	return nil
+}
+
+// VerifyPluginRequest returns true if the given signature, sent in the
+// PluginSignatureHeader header, matches the plugin request body; plugins
+// written in Go can use it to authenticate requests.
+func VerifyPluginRequest(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignPluginRequest(secret, body)), []byte(signature))
+}
+
+// SignPluginRequest returns the signature of the given plugin request body,
+// as sent in the PluginSignatureHeader header.
+func SignPluginRequest(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,349 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pluginTestSecret = "secret"
+
+// pluginTestServer serves the Github API and a plugin, recording all
+// requests.
+type pluginTestServer struct {
+	*httptest.Server
+	sync.Mutex
+
+	// config is the repository configuration, where `{{url}}` is replaced
+	// by the server URL.
+	config string
+	// reply is the plugin response.
+	reply func(w http.ResponseWriter, request PluginRequest)
+
+	requests []string
+	request  PluginRequest
+}
+
+func newPluginTestServer(t *testing.T, config string, reply func(w http.ResponseWriter, request PluginRequest)) *pluginTestServer {
+	srv := &pluginTestServer{config: config, reply: reply}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		srv.Lock()
+		srv.requests = append(srv.requests, strings.TrimSpace(fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body)))
+		srv.Unlock()
+
+		switch {
+		case r.URL.Path == "/repos/xunleii/github-quick-actions/contents/.github/quick-actions.yml":
+			content := strings.ReplaceAll(srv.config, "{{url}}", "http://"+r.Host)
+			_, _ = fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "sha": "sha", "content": %q}`, base64.StdEncoding.EncodeToString([]byte(content)))
+		case strings.HasSuffix(r.URL.Path, "/collaborators/octocat/permission"):
+			_, _ = w.Write([]byte(`{"permission": "write", "role_name": "write"}`))
+		case r.URL.Path == "/app/installations/1/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"token": "ghs_plugin", "expires_at": "2026-10-19T12:00:00Z"}`))
+		case r.URL.Path == "/installation/token":
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/app":
+			_, _ = w.Write([]byte(`{"slug": "quick-actions"}`))
+		case r.URL.Path == "/plugin":
+			if !VerifyPluginRequest(pluginTestSecret, body, r.Header.Get(PluginSignatureHeader)) {
+				http.Error(w, "invalid signature", http.StatusUnauthorized)
+				return
+			}
+			_ = json.Unmarshal(body, &srv.request)
+			srv.reply(w, srv.request)
+		case r.Method == http.MethodGet:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newPluginTestQuickActions(srv *pluginTestServer, settings PluginSettings) *GithubQuickActions {
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv.Server})
+	qa.EnableRepositoryConfig()
+	qa.EnablePlugins(settings)
+	qa.AddQuickAction("label", &mockQuickAction{onEvents: []EventType{EventTypeIssueComment}})
+	return qa
+}
+
+func TestPlugin_handle(t *testing.T) {
+	srv := newPluginTestServer(t, "plugins: {deploy: {url: \"{{url}}/plugin\", permission: write}}\nmacros: {ship: [/deploy production $@]}",
+		func(w http.ResponseWriter, _ PluginRequest) {
+			_, _ = w.Write([]byte(`{
+				"mutations": [{"method": "POST", "path": "issues/1/labels", "body": ["deployed"]}],
+				"comment": "Deployed!"
+			}`))
+		},
+	)
+	qa := newPluginTestQuickActions(srv, PluginSettings{Endpoints: map[string]string{srv.URL: pluginTestSecret}})
+
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", permissionPayload("octocat", "mojombo", "/ship --force")))
+	require.Len(t, srv.requests, 8)
+	assert.Equal(t, []string{
+		"GET /repos/xunleii/github-quick-actions/contents/.github/quick-actions.yml",
+		"GET /repos/xunleii/.github/contents/quick-actions.yml",
+		"GET /repos/xunleii/github-quick-actions/collaborators/octocat/permission",
+		`POST /app/installations/1/access_tokens {"repositories":["github-quick-actions"],"permissions":{"contents":"read","issues":"read","metadata":"read","pull_requests":"read"}}`,
+	}, srv.requests[:4])
+	assert.True(t, strings.HasPrefix(srv.requests[4], "POST /plugin {"))
+	assert.Equal(t, []string{
+		"DELETE /installation/token",
+		`POST /repos/xunleii/github-quick-actions/issues/1/labels ["deployed"]`,
+		`POST /repos/xunleii/github-quick-actions/issues/1/comments {"body":"Deployed!"}`,
+	}, srv.requests[5:])
+
+	request := srv.request
+	assert.Equal(t, PluginProtocolVersion, request.Version)
+	assert.Equal(t, "deploy", request.Command.Name)
+	assert.Equal(t, []string{"production", "--force"}, request.Command.Arguments)
+	assert.Equal(t, "ship", request.Command.Macro)
+	assert.Equal(t, EventTypeIssueComment, request.Event.Type)
+	assert.Equal(t, EventActionCreated, request.Event.Action)
+	assert.Contains(t, string(request.Event.Payload), `"login":"octocat"`)
+	assert.Equal(t, "xunleii", request.Repository.Owner)
+	assert.Equal(t, "github-quick-actions", request.Repository.Name)
+	assert.Equal(t, 1, request.IssueNumber)
+	assert.Equal(t, "ghs_plugin", request.Token.Value)
+	assert.Equal(t, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), request.Token.ExpiresAt)
+}
+
+func TestPlugin_errors(t *testing.T) {
+	ts := map[string]struct {
+		reply func(w http.ResponseWriter, request PluginRequest)
+		err   string
+	}{
+		"plugin error": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) {
+				_, _ = w.Write([]byte(`{"error": "unknown environment", "comment": "ignored"}`))
+			},
+			err: "plugin `/deploy` failed: unknown environment",
+		},
+		"unexpected status": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) { http.Error(w, "boom", http.StatusInternalServerError) },
+			err:   "plugin `/deploy` failed: unexpected status 500 Internal Server Error",
+		},
+		"invalid response": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) { _, _ = w.Write([]byte(`not json`)) },
+			err:   "plugin `/deploy` failed: invalid response: invalid character 'o' in literal null (expecting 'u')",
+		},
+		"timeout": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) { time.Sleep(200 * time.Millisecond) },
+			err:   "Client.Timeout exceeded",
+		},
+		"invalid mutation path": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) {
+				_, _ = w.Write([]byte(`{"mutations": [{"method": "DELETE", "path": "../other-repo"}]}`))
+			},
+			err: "plugin `/deploy` failed: invalid mutation path '../other-repo'",
+		},
+		"invalid mutation method": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) {
+				_, _ = w.Write([]byte(`{"mutations": [{"method": "GET", "path": "issues/1"}]}`))
+			},
+			err: "plugin `/deploy` failed: invalid mutation method 'GET' (expected POST, PUT, PATCH or DELETE)",
+		},
+		"forbidden mutation path": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) {
+				_, _ = w.Write([]byte(`{"mutations": [{"method": "POST", "path": "hooks", "body": {"config": {"url": "https://evil.io"}}}]}`))
+			},
+			err: "plugin `/deploy` failed: mutation `POST hooks` is not allowed (only issues, labels and comments can be changed)",
+		},
+		"forbidden mutation method": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) {
+				_, _ = w.Write([]byte(`{"mutations": [{"method": "DELETE", "path": "issues/1"}]}`))
+			},
+			err: "plugin `/deploy` failed: mutation `DELETE issues/1` is not allowed (only issues, labels and comments can be changed)",
+		},
+		"forbidden mutation after allowed ones": {
+			reply: func(w http.ResponseWriter, _ PluginRequest) {
+				_, _ = w.Write([]byte(`{"mutations": [{"method": "POST", "path": "issues/1/labels", "body": ["ignored"]}, {"method": "PUT", "path": "collaborators/mallory"}]}`))
+			},
+			err: "plugin `/deploy` failed: mutation `PUT collaborators/mallory` is not allowed (only issues, labels and comments can be changed)",
+		},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			srv := newPluginTestServer(t, "plugins: {deploy: {url: \"{{url}}/plugin\"}}", tc.reply)
+			qa := newPluginTestQuickActions(srv, PluginSettings{Endpoints: map[string]string{srv.URL: pluginTestSecret}, Timeout: 100 * time.Millisecond})
+
+			err := qa.Handle(context.TODO(), "issue_comment", "", permissionPayload("octocat", "mojombo", "/deploy"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+			// NOTE: the token is revoked whatever the plugin replied
+			assert.Contains(t, srv.requests, "DELETE /installation/token")
+			assert.NotContains(t, srv.requests, `POST /repos/xunleii/github-quick-actions/issues/1/comments {"body":"ignored"}`)
+			assert.NotContains(t, srv.requests, `POST /repos/xunleii/github-quick-actions/issues/1/labels ["ignored"]`)
+		})
+	}
+}
+
+func TestValidatePluginMutation(t *testing.T) {
+	ts := map[string]bool{
+		"PATCH issues/1":             true,
+		"POST issues/1/labels":       true,
+		"DELETE issues/1/labels/bug": true,
+		"POST issues/1/assignees":    true,
+		"POST issues/1/comments":     true,
+		"PATCH issues/comments/42":   true,
+		"POST labels":                true,
+		"PATCH labels/bug":           true,
+		"DELETE issues/1":            false,
+		"PUT contents/README.md":     false,
+		"POST keys":                  false,
+		"PUT collaborators/mallory":  false,
+		"PATCH issues/1/labels/bug":  false,
+		"POST issues/comments/42":    false,
+	}
+
+	for mutation, valid := range ts {
+		mutation, valid := mutation, valid
+		t.Run(mutation, func(t *testing.T) {
+			parts := strings.SplitN(mutation, " ", 2)
+			err := validatePluginMutation(PluginMutation{Method: parts[0], Path: parts[1]})
+			assert.Equal(t, valid, err == nil, err)
+		})
+	}
+}
+
+func TestPlugin_signature(t *testing.T) {
+	srv := newPluginTestServer(t, "plugins: {deploy: {url: \"{{url}}/plugin\"}}", func(w http.ResponseWriter, _ PluginRequest) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	qa := newPluginTestQuickActions(srv, PluginSettings{Endpoints: map[string]string{srv.URL: "other secret"}})
+
+	err := qa.Handle(context.TODO(), "issue_comment", "", permissionPayload("octocat", "mojombo", "/deploy"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "plugin `/deploy` failed: unexpected status 401 Unauthorized")
+
+	// NOTE: each endpoint has its own secret, the longest one is used
+	qa = newPluginTestQuickActions(srv, PluginSettings{Endpoints: map[string]string{srv.URL: "other secret", srv.URL + "/plugin": pluginTestSecret}})
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", permissionPayload("octocat", "mojombo", "/deploy")))
+
+	body := []byte(`{"version": 1}`)
+	assert.True(t, VerifyPluginRequest("secret", body, SignPluginRequest("secret", body)))
+	assert.False(t, VerifyPluginRequest("secret", body, SignPluginRequest("other", body)))
+	assert.False(t, VerifyPluginRequest("secret", body, ""))
+}
+
+func TestParseRepositoryConfig_plugins(t *testing.T) {
+	qa := newConfigTestQuickActions()
+
+	_, err := qa.ParseRepositoryConfig([]byte("plugins: {deploy: {url: https://plugins.example.com/deploy}}"))
+	assert.EqualError(t, err, "invalid .github/quick-actions.yml: 1 error(s) found\n- plugins: plugins are not enabled on this instance")
+
+	qa.EnablePlugins(PluginSettings{Endpoints: map[string]string{"https://plugins.example.com/": pluginTestSecret, "https://example.com/plugins": pluginTestSecret}})
+	config, err := qa.ParseRepositoryConfig([]byte(`
+plugins:
+  deploy:
+    url: https://plugins.example.com/deploy
+    events: [issue_comment]
+    permission: write
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Plugin{
+		"deploy": {URL: "https://plugins.example.com/deploy", Events: []EventType{EventTypeIssueComment}, Permission: PermissionWrite},
+	}, config.Plugins)
+
+	_, valid := config.plugin("deploy", EventTypeIssueComment)
+	assert.True(t, valid)
+	_, valid = config.plugin("deploy", EventTypePullRequestReviewComment)
+	assert.False(t, valid)
+
+	ts := map[string]struct {
+		content string
+		err     string
+	}{
+		"existing command":   {content: "plugins: {label: {url: https://plugins.example.com/label}}", err: "- plugins.label: command 'label' already exists"},
+		"existing alias":     {content: "aliases: {tag: label}\nplugins: {tag: {url: https://plugins.example.com/tag}}", err: "- plugins.tag: alias 'tag' already exists"},
+		"existing macro":     {content: "macros: {ship: [/label]}\nplugins: {ship: {url: https://plugins.example.com/ship}}", err: "- plugins.ship: macro 'ship' already exists"},
+		"missing url":        {content: "plugins: {deploy: }", err: "- plugins.deploy: url is required"},
+		"invalid url":        {content: "plugins: {deploy: {url: \"ftp://example.com\"}}", err: "- plugins.deploy.url: invalid URL 'ftp://example.com'"},
+		"unknown endpoint":   {content: "plugins: {deploy: {url: https://example.com/deploy}}", err: "- plugins.deploy.url: 'https://example.com/deploy' is not allowed on this instance"},
+		"insecure url":       {content: "plugins: {deploy: {url: \"http://plugins.example.com/deploy\"}}", err: "- plugins.deploy.url: 'http://plugins.example.com/deploy' is not allowed on this instance"},
+		"other host":         {content: "plugins: {deploy: {url: https://plugins.example.com.evil.io/deploy}}", err: "- plugins.deploy.url: 'https://plugins.example.com.evil.io/deploy' is not allowed on this instance"},
+		"other path":         {content: "plugins: {deploy: {url: https://example.com/plugins-evil}}", err: "- plugins.deploy.url: 'https://example.com/plugins-evil' is not allowed on this instance"},
+		"unsupported event":  {content: "plugins: {deploy: {url: https://plugins.example.com/deploy, events: [push]}}", err: "- plugins.deploy.events: unsupported event 'push'"},
+		"invalid permission": {content: "plugins: {deploy: {url: https://plugins.example.com/deploy, permission: owner}}", err: "- plugins.deploy.permission: invalid permission 'owner'"},
+		"no permission":      {content: "plugins: {deploy: {url: https://plugins.example.com/deploy, permission: none}}", err: "- plugins.deploy.permission: invalid permission 'none' (expected read, triage, write, maintain or admin)"},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := qa.ParseRepositoryConfig([]byte(tc.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	_, err = qa.ParseRepositoryConfig([]byte("plugins: {deploy: {url: https://example.com/plugins/deploy}}"))
+	assert.NoError(t, err)
+
+	// NOTE: plugins can be enabled, disabled and aliased like quick actions
+	config, err = qa.ParseRepositoryConfig([]byte(`
+plugins: {deploy: {url: https://plugins.example.com/deploy}}
+enabled: [deploy]
+disabled: [deploy]
+aliases: {ship: deploy}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ship": "deploy"}, config.Aliases)
+}
+
+func TestPayloadToCommands_plugins(t *testing.T) {
+	qa := newConfigTestQuickActions()
+	config := &RepositoryConfig{
+		Plugins:  map[string]*Plugin{"deploy": {URL: "https://plugins.example.com/deploy"}, "rollback": {URL: "https://plugins.example.com/rollback"}},
+		Disabled: []string{"rollback"},
+		Aliases:  map[string]string{"ship": "deploy"},
+	}
+
+	payload, err := PayloadFactory(EventTypeIssueComment, permissionPayload("octocat", "mojombo", "/ship production\n/rollback production"))
+	require.NoError(t, err)
+
+	noLog := zerolog.Nop()
+	ctx := noLog.WithContext(context.Background())
+
+	// NOTE: `/rollback` is disabled on the repository
+	commands := qa.payloadToCommands(ctx, payload, config)
+	require.Len(t, commands, 1)
+	assert.Equal(t, EventCommand{Command: "deploy", Arguments: []string{"production"}, Payload: payload}, *commands[0])
+}
+
+func TestPlugin_sentByItself(t *testing.T) {
+	srv := newPluginTestServer(t, "plugins: {deploy: {url: \"{{url}}/plugin\"}}", func(w http.ResponseWriter, _ PluginRequest) {
+		_, _ = w.Write([]byte(`{"comment": "/deploy"}`))
+	})
+	qa := newPluginTestQuickActions(srv, PluginSettings{Endpoints: map[string]string{srv.URL: pluginTestSecret}})
+
+	// NOTE: comments posted by the application are ignored, to avoid loops
+	payload := strings.Replace(string(permissionPayload("quick-actions[bot]", "mojombo", "/deploy")), `"sender": {"login": "quick-actions[bot]"}`, `"sender": {"login": "quick-actions[bot]", "type": "Bot"}`, 1)
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", []byte(payload)))
+	assert.Contains(t, srv.requests, "GET /app")
+	assert.NotContains(t, srv.requests, `POST /repos/xunleii/github-quick-actions/issues/1/comments {"body":"/deploy"}`)
+
+	// NOTE: other bots can run commands
+	srv.requests = nil
+	payload = strings.Replace(string(permissionPayload("octocat", "mojombo", "/deploy")), `"sender": {"login": "octocat"}`, `"sender": {"login": "octocat", "type": "Bot"}`, 1)
+	require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", []byte(payload)))
+	assert.Contains(t, srv.requests, `POST /repos/xunleii/github-quick-actions/issues/1/comments {"body":"/deploy"}`)
+}
@@ -2,8 +2,13 @@ package gh_quick_actions
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/google/go-github/v39/github"
 	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/shurcooL/githubv4"
+
+	"xnku.be/github-quick-actions/pkg/scheduler"
 )
 
 type (
@@ -13,11 +18,57 @@ type (
 		HandleCommand(ctx *EventContext, command *EventCommand) error
 	}
 
+	// EventHandler defines a Github event handler, triggered on specific
+	// event actions (like `synchronize` on pull requests) instead of a
+	// command.
+	EventHandler interface {
+		// TriggerOnActions returns, for each event type, all actions
+		// that trigger the handler.
+		TriggerOnActions() map[EventType][]EventAction
+		HandleEvent(ctx *EventContext, payload EventPayload) error
+	}
+
+	// CommandEventHandler is an optional interface implemented by event
+	// handlers belonging to a command: they are not run when the command
+	// is disabled on the repository and, if opt-in, unless the command is
+	// explicitly listed in the `enabled` setting.
+	CommandEventHandler interface {
+		// HandledCommand returns the command of the handler and whether
+		// the handler is opt-in.
+		HandledCommand() (command string, optIn bool)
+	}
+
+	// Sweeper defines a task run periodically, outside of any Github
+	// event, on each installation of the Github Application (like
+	// reminders before a deadline).
+	Sweeper interface {
+		Sweep(ctx *EventContext, installationID int64) error
+	}
+
+	// JobRunner defines a task run once a job scheduled by a quick action
+	// (like /remind) is due.
+	JobRunner interface {
+		RunJob(ctx *EventContext, job *scheduler.Job) error
+	}
+
 	// EventContext implement all tools required in order to handle a
 	// Github event.
 	EventContext struct {
 		context.Context
 		githubapp.ClientCreator
+
+		// Store contains the jobs scheduled by quick actions (nil if no
+		// store has been configured).
+		Store scheduler.Store
+		// Config contains the configuration of the repository where the
+		// event comes from (nil if the repository has no configuration).
+		Config *RepositoryConfig
+
+		// identity resolves the login of the Github Application.
+		identity *appIdentity
+		// commands extracts the commands of the handled event (see
+		// EventContext.Commands).
+		commands func() []*EventCommand
 	}
 
 	// EventCommand contains the command to be handled by the
@@ -25,6 +76,16 @@ type (
 	EventCommand struct {
 		Command   string
 		Arguments []string
+		// Values contains the validated arguments, if the quick action
+		// implements ArgumentsSchema.
+		Values Arguments
+		// Options contains the options of the quick action for the
+		// current repository, if the quick action implements
+		// Configurable.
+		Options interface{}
+		// Macro is the name of the repository macro the command comes
+		// from, if any (see RepositoryConfig.Macros).
+		Macro string
 
 		Payload EventPayload
 	}
@@ -39,9 +100,43 @@ type (
 		RepositoryOwner() string
 		IssueNumber() int
 		Body() string
+		// PreviousBody returns the body before the edition on "edited"
+		// actions; it is the same as Body if the body hasn't been edited.
+		PreviousBody() string
 
 		// Raw contains the raw events if it needed to be used by the
 		// quick action implementation.
 		Raw() interface{}
 	}
 )
+
+// NewClient creates a Github REST (v3) client for the installation where the
+// given event comes from.
+func (ctx *EventContext) NewClient(payload EventPayload) (*github.Client, error) {
+	event, valid := payload.Raw().(interface{ GetInstallation() *github.Installation })
+	if !valid {
+		return nil, fmt.Errorf("invalid event type %T", payload.Raw())
+	}
+	return ctx.NewInstallationClient(event.GetInstallation().GetID())
+}
+
+// Commands returns the commands written in the handled event, resolved like
+// the ones run by the quick actions: commands inside code blocks, quotes or
+// HTML comments are ignored, and the repository prefix, aliases, macros and
+// disabled commands are applied.
+func (ctx *EventContext) Commands() []*EventCommand {
+	if ctx.commands == nil {
+		return nil
+	}
+	return ctx.commands()
+}
+
+// NewGraphQLClient creates a Github GraphQL (v4) client for the installation
+// where the given event comes from.
+func (ctx *EventContext) NewGraphQLClient(payload EventPayload) (*githubv4.Client, error) {
+	event, valid := payload.Raw().(interface{ GetInstallation() *github.Installation })
+	if !valid {
+		return nil, fmt.Errorf("invalid event type %T", payload.Raw())
+	}
+	return ctx.NewInstallationV4Client(event.GetInstallation().GetID())
+}
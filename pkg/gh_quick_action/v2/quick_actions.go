@@ -4,13 +4,17 @@ import (
 	"context"
 	"encoding/csv"
 	"fmt"
+	"regexp"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/rs/zerolog"
 	"github.com/thoas/go-funk"
+
+	"xnku.be/github-quick-actions/pkg/scheduler"
 )
 
 type (
@@ -21,6 +25,20 @@ type (
 	// quick action giving the context (event type + command name).
 	quickActionRegistry map[EventType]map[string]QuickAction
 
+	// eventHandlerRegistry represents the registry that contains the
+	// implemented event handlers.
+	// The first key is the event type, the second one the event action
+	// (synchronize, closed, ...) and the last one the handler name.
+	eventHandlerRegistry map[EventType]map[EventAction]map[string]EventHandler
+
+	// sweeperRegistry represents the registry that contains the
+	// implemented sweepers, indexed by name.
+	sweeperRegistry map[string]Sweeper
+
+	// jobRunnerRegistry represents the registry that contains the
+	// implemented job runners, indexed by job kind.
+	jobRunnerRegistry map[string]JobRunner
+
 	// GithubQuickActions manages all defined GitHub quick actions through
 	// a githubapp Handler.
 	GithubQuickActions struct {
@@ -30,15 +48,46 @@ type (
 		// registry contains all Github quick actions implementations
 		// that will be handled.
 		registry quickActionRegistry
+		// aliases contains the command name of all quick action aliases.
+		aliases map[string]string
+		// handlers contains all Github event handlers implementations
+		// that will be handled.
+		handlers eventHandlerRegistry
+		// sweepers contains all periodic tasks implementations that
+		// will be run on each sweep.
+		sweepers sweeperRegistry
+		// sweeps defines when the sweepers are run by the scheduler.
+		sweeps *sweepSchedule
+		// runners contains all job runners implementations that will run
+		// the scheduled jobs.
+		runners jobRunnerRegistry
+
+		// store contains the jobs scheduled by quick actions.
+		store scheduler.Store
+		// feedback defines how users are notified of the result of their
+		// commands.
+		feedback Feedback
+		// configs contains the parsed repository configurations (nil if
+		// repository configurations are disabled).
+		configs *configCache
+		// plugins calls the plugins declared in repository
+		// configurations (nil if plugins are disabled).
+		plugins *pluginRunner
+		// identity resolves the login of the Github Application.
+		identity *appIdentity
 	}
 )
 
 // NewGithubQuickActions creates a new instance of GithubQuickActions.
 func NewGithubQuickActions(cc githubapp.ClientCreator) *GithubQuickActions {
-	return &GithubQuickActions{cc: cc, registry: quickActionRegistry{}}
+	return &GithubQuickActions{cc: cc, registry: quickActionRegistry{}, aliases: map[string]string{}, handlers: eventHandlerRegistry{}, sweepers: sweeperRegistry{}, sweeps: &sweepSchedule{interval: DefaultSweepInterval}, runners: jobRunnerRegistry{}, identity: &appIdentity{}}
 }
 
-// AddQuickAction add quick action for the given command.
+// SetStore defines the store used by quick actions to schedule jobs.
+func (a *GithubQuickActions) SetStore(store scheduler.Store) { a.store = store }
+
+// AddQuickAction add quick action for the given command, and for its
+// aliases if the quick action implements Describer.
 func (a GithubQuickActions) AddQuickAction(command string, action QuickAction) {
 	if action == nil {
 		// NOTE: panic is used to avoid unknown issue like unexpected nil
@@ -47,6 +96,11 @@ func (a GithubQuickActions) AddQuickAction(command string, action QuickAction) {
 		panic(fmt.Errorf("quick action cannot be nil for command '/%s'", command))
 	}
 
+	var aliases []string
+	if describer, valid := action.(Describer); valid {
+		aliases = describer.Metadata().Aliases
+	}
+
 	eventTypes := action.TriggerOnEvents()
 
 	for _, eventType := range eventTypes {
@@ -54,20 +108,83 @@ func (a GithubQuickActions) AddQuickAction(command string, action QuickAction) {
 			a.registry[eventType] = map[string]QuickAction{}
 		}
 
-		if a.registry[eventType][command] != nil {
-			// NOTE: panic to avoid unexpected overwrite of an existing action
-			panic(fmt.Errorf("quick action already defined for command '/%s'", command))
+		for _, name := range append([]string{command}, aliases...) {
+			if a.registry[eventType][name] != nil {
+				// NOTE: panic to avoid unexpected overwrite of an existing action
+				panic(fmt.Errorf("quick action already defined for command '/%s'", name))
+			}
+			a.registry[eventType][name] = action
 		}
-		a.registry[eventType][command] = action
+	}
+
+	for _, alias := range aliases {
+		a.aliases[alias] = command
 	}
 }
 
+// AddEventHandler add event handler with the given name.
+func (a GithubQuickActions) AddEventHandler(name string, handler EventHandler) {
+	if handler == nil {
+		// NOTE: panic is used for the same reasons as AddQuickAction
+		panic(fmt.Errorf("event handler '%s' cannot be nil", name))
+	}
+
+	for eventType, eventActions := range handler.TriggerOnActions() {
+		if a.handlers[eventType] == nil {
+			a.handlers[eventType] = map[EventAction]map[string]EventHandler{}
+		}
+
+		for _, eventAction := range eventActions {
+			if a.handlers[eventType][eventAction] == nil {
+				a.handlers[eventType][eventAction] = map[string]EventHandler{}
+			}
+
+			if a.handlers[eventType][eventAction][name] != nil {
+				// NOTE: panic to avoid unexpected overwrite of an existing handler
+				panic(fmt.Errorf("event handler '%s' already defined", name))
+			}
+			a.handlers[eventType][eventAction][name] = handler
+		}
+	}
+}
+
+// AddSweeper add sweeper with the given name.
+func (a GithubQuickActions) AddSweeper(name string, sweeper Sweeper) {
+	if sweeper == nil {
+		// NOTE: panic is used for the same reasons as AddQuickAction
+		panic(fmt.Errorf("sweeper '%s' cannot be nil", name))
+	}
+
+	if a.sweepers[name] != nil {
+		// NOTE: panic to avoid unexpected overwrite of an existing sweeper
+		panic(fmt.Errorf("sweeper '%s' already defined", name))
+	}
+	a.sweepers[name] = sweeper
+}
+
+// AddJobRunner add job runner for the given job kind.
+func (a GithubQuickActions) AddJobRunner(kind string, runner JobRunner) {
+	if runner == nil {
+		// NOTE: panic is used for the same reasons as AddQuickAction
+		panic(fmt.Errorf("job runner '%s' cannot be nil", kind))
+	}
+
+	if a.runners[kind] != nil {
+		// NOTE: panic to avoid unexpected overwrite of an existing runner
+		panic(fmt.Errorf("job runner '%s' already defined", kind))
+	}
+	a.runners[kind] = runner
+}
+
 // Handles implements githubapp.Handles
 func (a GithubQuickActions) Handles() []string {
 	var handles []string
 	for eventType := range a.registry {
 		handles = append(handles, string(eventType))
 	}
+	for eventType := range a.handlers {
+		handles = append(handles, string(eventType))
+	}
 	return funk.UniqString(handles)
 }
 
@@ -86,8 +203,10 @@ func (a GithubQuickActions) Handle(ctx context.Context, evntType, deliveryID str
 		return err
 	}
 
-	if payload.Action() != EventActionCreated {
-		// NOTE: ignore all event if not "created"
+	handlers := a.handlers[payload.Type()][payload.Action()]
+	if !hasCommands(payload) && len(handlers) == 0 {
+		// NOTE: ignore all event if not "created" or "edited", except if
+		//		 some event handlers are waiting for it
 		return nil
 	}
 
@@ -99,65 +218,199 @@ func (a GithubQuickActions) Handle(ctx context.Context, evntType, deliveryID str
 	logger.Info().Send()
 	logger.Trace().RawJSON("payload", json).Send()
 
-	commands := a.payloadToCommands(ctx, payload)
+	eventCtx := &EventContext{Context: ctx, ClientCreator: a.cc, Store: a.store, identity: a.identity}
+	errors := &multierror.Error{}
+
+	// NOTE: the repository configuration is only loaded once, when it is
+	//		 required by an event handler or by the quick actions
+	var (
+		configOnce    sync.Once
+		configErr     error
+		commandsOnce  sync.Once
+		eventCommands []*EventCommand
+	)
+	loadConfig := func() {
+		configOnce.Do(func() {
+			eventCtx.Config, configErr = a.repositoryConfig(eventCtx, payload)
+			if configErr != nil {
+				logger.Error().Err(configErr).Msgf("invalid repository configuration: %s", configErr)
+			}
+		})
+	}
+	eventCtx.commands = func() []*EventCommand {
+		commandsOnce.Do(func() {
+			loadConfig()
+			eventCommands = a.payloadToCommands(ctx, payload, eventCtx.Config)
+		})
+		return eventCommands
+	}
+
+	for name, handler := range handlers {
+		if handled, valid := handler.(CommandEventHandler); valid {
+			loadConfig()
+
+			command, optIn := handled.HandledCommand()
+			if !eventCtx.Config.IsEnabled(command) || optIn && !eventCtx.Config.isOptedIn(command) {
+				logger.Debug().Msgf("event handler '%s' is not enabled on this repository, ignored", name)
+				continue
+			}
+		}
+
+		err := handler.HandleEvent(eventCtx, payload)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to run event handler '%s': %s", name, err)
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	if !hasCommands(payload) {
+		return errors.ErrorOrNil()
+	}
+
+	commands := eventCtx.Commands()
 	if len(commands) == 0 {
+		// NOTE: configuration errors only matter to the commands; most
+		//		 comments don't contain any
 		logger.Info().Msgf("no command found, aborted")
-		return nil
+		return errors.ErrorOrNil()
 	}
-	eventCtx := &EventContext{Context: ctx, ClientCreator: a.cc}
 
-	errors := &multierror.Error{}
+	// NOTE: commands posted by the application (like the plugins comments)
+	//		 are never run, to avoid loops
+	if self, err := sentByItself(eventCtx, payload); self {
+		if err != nil {
+			logger.Warn().Err(err).Msgf("failed to resolve the application login: %s", err)
+		}
+		logger.Info().Msgf("event sent by the application itself, aborted")
+		return errors.ErrorOrNil()
+	}
+	if configErr != nil {
+		errors = multierror.Append(errors, configErr)
+	}
+
+	notifier := a.newFeedbackNotifier(eventCtx, payload)
+	notifier.received(eventCtx)
+
+	permissions := &permissionResolver{payload: payload}
+	var results []commandResult
 	for _, command := range commands {
-		action := a.registry[command.Payload.Type()][command.Command]
+		if configErr != nil {
+			// NOTE: commands are not run with an invalid configuration,
+			//		 which could have disabled or restricted them
+			results = append(results, commandResult{command: command, err: configErr})
+			continue
+		}
+
 		// TODO: in order to preserve user command order, all calls are sequential,
 		// 		 increasing the execution time. Find a way to detect conflicts between
 		//		 commands (like /label & /remove_label) and group them.
-		err := action.HandleCommand(eventCtx, command)
+		err := a.runCommand(eventCtx, permissions, command)
 		if err != nil {
-			logger.Error().Err(err).Msgf("failed to run quick action: %s", err)
 			errors = multierror.Append(errors, err)
 		}
+		results = append(results, commandResult{command: command, err: err})
 	}
 
+	notifier.done(eventCtx, results)
 	return errors.ErrorOrNil()
 }
 
+// runCommand validates the given command and runs it if the event sender is
+// allowed to.
+func (a GithubQuickActions) runCommand(ctx *EventContext, permissions *permissionResolver, command *EventCommand) error {
+	logger := zerolog.Ctx(ctx)
+	action, exists := a.registry[command.Payload.Type()][command.Command]
+	if plugin, isPlugin := ctx.Config.plugin(command.Command, command.Payload.Type()); !exists && isPlugin {
+		action = a.pluginQuickAction(command.Command, plugin)
+	}
+
+	if err := ValidateArguments(action, command); err != nil {
+		logger.Error().Err(err).Msgf("invalid arguments for quick action: %s", err)
+		return err
+	}
+
+	if err := permissions.authorize(ctx, action, command); err != nil {
+		logger.Warn().Err(err).Msgf("quick action denied: %s", err)
+		return err
+	}
+	// NOTE: arguments needing the Github API are only resolved once the
+	//		 sender is allowed to run the command
+	if err := ResolveArguments(ctx, action, command); err != nil {
+		logger.Error().Err(err).Msgf("invalid arguments for quick action: %s", err)
+		return err
+	}
+
+	options, err := ctx.Config.options(command.Command, action)
+	if err != nil {
+		logger.Error().Err(err).Msgf("invalid options for quick action: %s", err)
+		return err
+	}
+	command.Options = options
+
+	if err := action.HandleCommand(ctx, command); err != nil {
+		logger.Error().Err(err).Msgf("failed to run quick action: %s", err)
+		return err
+	}
+	return nil
+}
+
+// hasCommands returns true if commands must be extracted from the given
+// event: on creation, or on edition for the newly added commands.
+func hasCommands(payload EventPayload) bool {
+	return payload.Action() == EventActionCreated || payload.Action() == EventActionEdited
+}
+
 // payloadToCommands extracts all command defined by the user in the event body.
-func (a GithubQuickActions) payloadToCommands(ctx context.Context, event EventPayload) []*EventCommand {
+// Only lines of normal paragraphs are read; commands inside code blocks, block
+// quotes or HTML comments are ignored. On edition, only commands added since the
+// previous body are extracted, the others being already executed.
+func (a GithubQuickActions) payloadToCommands(ctx context.Context, event EventPayload, config *RepositoryConfig) []*EventCommand {
 	logger := zerolog.Ctx(ctx)
-	actions := a.registry[event.Type()]
+	prefix := config.CommandPrefix()
+
+	lines := commandLines(event.Body(), prefix)
+	if event.Action() == EventActionEdited {
+		lines = addedCommandLines(commandLines(event.PreviousBody(), prefix), lines)
+	}
 
 	var commands []*EventCommand
-	for n, line := range strings.Split(event.Body(), "\n") {
-		if line == "" || line[0] != '/' {
-			// not a command, ignored
-			logger.Trace().Msgf("no command on line n°%d, ignored...", n)
-			continue
-		}
+	for _, cline := range lines {
+		n, line := cline.number, cline.text
 
 		// NOTE: in order to keep to CPU time, we avoid creating the CSV and
 		// 		 parse the line if the action doesn't exist.
 		idx := strings.IndexFunc(line, unicode.IsSpace)
-		command := line[1:]
+		command := line[len(prefix):]
 		switch idx {
-		case 1: // NOTE: if idx == 1 means that le first "word" is only `/` and should be ignored
+		case len(prefix): // NOTE: if idx == len(prefix) means that le first "word" is only the prefix and should be ignored
 			logger.Trace().Msgf("no command on line n°%d, ignored...", n)
 			continue
 		case -1:
 			// ignore because no space found means that the full line is the command
 		default:
-			command = line[1:idx]
+			command = line[len(prefix):idx]
 		}
 
-		if _, exists := actions[command]; !exists {
-			logger.Warn().Msgf("quick action '/%s' doesn't exists, ignored", command)
+		if canonical, isAlias := config.Alias(command); isAlias {
+			logger.Trace().Msgf("quick action '/%s' is a repository alias of '/%s'", command, canonical)
+			command = canonical
+		}
+		if _, isMacro := config.macro(command); isMacro {
+			args, err := parseCommandLine(line)
+			if err != nil {
+				logger.Error().Err(err).Str("quick_action", command).Str("line", line).Msgf("failed to parse command line '%s', ignored", line)
+				continue
+			}
+			commands = append(commands, a.expandMacro(ctx, event, config, command, args[1:], nil)...)
 			continue
 		}
 
-		reader := csv.NewReader(strings.NewReader(line))
-		reader.Comma = ' '
+		command, valid := a.resolveCommand(ctx, event, config, command)
+		if !valid {
+			continue
+		}
 
-		record, err := reader.Read()
+		args, err := parseCommandLine(line)
 		if err != nil {
 			logger.Error().
 				Err(err).
@@ -167,14 +420,6 @@ func (a GithubQuickActions) payloadToCommands(ctx context.Context, event EventPa
 			continue
 		}
 
-		var args []string
-		for _, item := range record {
-			item := strings.TrimSpace(item)
-			if len(item) > 0 {
-				args = append(args, item)
-			}
-		}
-
 		commands = append(commands, &EventCommand{
 			Command:   command,
 			Arguments: args[1:],
@@ -183,3 +428,55 @@ func (a GithubQuickActions) payloadToCommands(ctx context.Context, event EventPa
 	}
 	return commands
 }
+
+// resolveCommand returns the canonical name of the given command and
+// whether it can be run on the given event; unknown and disabled commands
+// are ignored.
+func (a GithubQuickActions) resolveCommand(ctx context.Context, event EventPayload, config *RepositoryConfig, command string) (string, bool) {
+	logger := zerolog.Ctx(ctx)
+
+	if _, isPlugin := config.plugin(command, event.Type()); isPlugin {
+		logger.Trace().Msgf("quick action '/%s' is a repository plugin", command)
+	} else if _, exists := a.registry[event.Type()][command]; !exists {
+		logger.Warn().Msgf("quick action '/%s' doesn't exists, ignored", command)
+		return "", false
+	} else if canonical, isAlias := a.aliases[command]; isAlias {
+		logger.Trace().Msgf("quick action '/%s' is an alias of '/%s'", command, canonical)
+		command = canonical
+	}
+	if !config.IsEnabled(command) {
+		logger.Info().Msgf("quick action '/%s' is disabled on this repository, ignored", command)
+		return "", false
+	}
+	return command, true
+}
+
+// quotedAssignment matches the assignments with a quoted value, like
+// `key="some value"`.
+var quotedAssignment = regexp.MustCompile(`(^|\s)([^\s"=]+)="([^"]*)"`)
+
+// parseCommandLine splits the given command line into the command and its
+// arguments.
+func parseCommandLine(line string) ([]string, error) {
+	// NOTE: quoted assignments, like `key="some value"`, are rewritten into
+	//		 quoted items (`"key=some value"`) in order to keep the standard
+	//		 CSV quoting rules for all other items
+	line = quotedAssignment.ReplaceAllString(line, `$1"$2=$3"`)
+
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.Comma = ' '
+
+	record, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	for _, item := range record {
+		item := strings.TrimSpace(item)
+		if len(item) > 0 {
+			args = append(args, item)
+		}
+	}
+	return args, nil
+}
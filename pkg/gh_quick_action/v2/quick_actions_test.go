@@ -2,10 +2,18 @@ package gh_quick_actions
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"xnku.be/github-quick-actions/pkg/scheduler"
 )
 
 type quickActionsTestSuite struct {
@@ -56,12 +64,93 @@ func (ts *quickActionsTestSuite) TestAddQuickAction_alreadyExists() {
 	})
 }
 
+// GithubQuickActions.AddEventHandler
+func (ts *quickActionsTestSuite) TestAddQuickAction_aliases() {
+	action := &mockDescribedQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{"aaa"}}, metadata: Metadata{Aliases: []string{"alias#1"}}}
+	ts.GithubQuickActions.AddQuickAction("cmd#1", action)
+
+	ts.Assert().Equal(action, ts.GithubQuickActions.registry["aaa"]["alias#1"])
+	ts.Assert().Equal(map[string]string{"alias#1": "cmd#1"}, ts.GithubQuickActions.aliases)
+	ts.Assert().PanicsWithError("quick action already defined for command '/alias#1'", func() {
+		ts.GithubQuickActions.AddQuickAction("alias#1", &mockQuickAction{onEvents: []EventType{"aaa"}})
+	})
+}
+
+func (ts *quickActionsTestSuite) TestAddEventHandler_valid() {
+	ts.GithubQuickActions.AddEventHandler("hdl#1", &mockEventHandler{onActions: map[EventType][]EventAction{"aaa": {"xxx", "yyy"}}})
+	ts.GithubQuickActions.AddEventHandler("hdl#2", &mockEventHandler{onActions: map[EventType][]EventAction{"aaa": {"xxx"}, "bbb": {"zzz"}}})
+
+	ts.Assert().NotNil(ts.GithubQuickActions.handlers["aaa"]["xxx"]["hdl#1"])
+	ts.Assert().NotNil(ts.GithubQuickActions.handlers["aaa"]["yyy"]["hdl#1"])
+	ts.Assert().NotNil(ts.GithubQuickActions.handlers["aaa"]["xxx"]["hdl#2"])
+	ts.Assert().NotNil(ts.GithubQuickActions.handlers["bbb"]["zzz"]["hdl#2"])
+	ts.Assert().Nil(ts.GithubQuickActions.handlers["aaa"]["yyy"]["hdl#2"])
+}
+
+func (ts *quickActionsTestSuite) TestAddEventHandler_nil() {
+	ts.Assert().PanicsWithError("event handler 'hdl#1' cannot be nil", func() {
+		ts.GithubQuickActions.AddEventHandler("hdl#1", nil)
+	})
+}
+
+func (ts *quickActionsTestSuite) TestAddEventHandler_alreadyExists() {
+	ts.GithubQuickActions.AddEventHandler("hdl#1", &mockEventHandler{onActions: map[EventType][]EventAction{"aaa": {"xxx"}}})
+	ts.Assert().PanicsWithError("event handler 'hdl#1' already defined", func() {
+		ts.GithubQuickActions.AddEventHandler("hdl#1", &mockEventHandler{onActions: map[EventType][]EventAction{"aaa": {"xxx"}}})
+	})
+}
+
+// GithubQuickActions.AddSweeper
+func (ts *quickActionsTestSuite) TestAddSweeper_valid() {
+	ts.GithubQuickActions.AddSweeper("swp#1", &mockSweeper{})
+	ts.GithubQuickActions.AddSweeper("swp#2", &mockSweeper{})
+
+	ts.Assert().NotNil(ts.GithubQuickActions.sweepers["swp#1"])
+	ts.Assert().NotNil(ts.GithubQuickActions.sweepers["swp#2"])
+}
+
+func (ts *quickActionsTestSuite) TestAddSweeper_nil() {
+	ts.Assert().PanicsWithError("sweeper 'swp#1' cannot be nil", func() {
+		ts.GithubQuickActions.AddSweeper("swp#1", nil)
+	})
+}
+
+func (ts *quickActionsTestSuite) TestAddSweeper_alreadyExists() {
+	ts.GithubQuickActions.AddSweeper("swp#1", &mockSweeper{})
+	ts.Assert().PanicsWithError("sweeper 'swp#1' already defined", func() {
+		ts.GithubQuickActions.AddSweeper("swp#1", &mockSweeper{})
+	})
+}
+
+// GithubQuickActions.AddJobRunner
+func (ts *quickActionsTestSuite) TestAddJobRunner_valid() {
+	ts.GithubQuickActions.AddJobRunner("job#1", &mockJobRunner{})
+	ts.GithubQuickActions.AddJobRunner("job#2", &mockJobRunner{})
+
+	ts.Assert().NotNil(ts.GithubQuickActions.runners["job#1"])
+	ts.Assert().NotNil(ts.GithubQuickActions.runners["job#2"])
+}
+
+func (ts *quickActionsTestSuite) TestAddJobRunner_nil() {
+	ts.Assert().PanicsWithError("job runner 'job#1' cannot be nil", func() {
+		ts.GithubQuickActions.AddJobRunner("job#1", nil)
+	})
+}
+
+func (ts *quickActionsTestSuite) TestAddJobRunner_alreadyExists() {
+	ts.GithubQuickActions.AddJobRunner("job#1", &mockJobRunner{})
+	ts.Assert().PanicsWithError("job runner 'job#1' already defined", func() {
+		ts.GithubQuickActions.AddJobRunner("job#1", &mockJobRunner{})
+	})
+}
+
 // GithubQuickActions.Handles
 func (ts *quickActionsTestSuite) TestHandles() {
 	ts.GithubQuickActions.AddQuickAction("cmd#1", &mockQuickAction{onEvents: []EventType{"aaa", "bbb"}})
 	ts.GithubQuickActions.AddQuickAction("cmd#2", &mockQuickAction{onEvents: []EventType{"aaa", "bbb", "ccc"}})
+	ts.GithubQuickActions.AddEventHandler("hdl#1", &mockEventHandler{onActions: map[EventType][]EventAction{"ccc": {"xxx"}, "ddd": {"yyy"}}})
 
-	ts.Assert().ElementsMatch([]string{"aaa", "bbb", "ccc"}, ts.GithubQuickActions.Handles())
+	ts.Assert().ElementsMatch([]string{"aaa", "bbb", "ccc", "ddd"}, ts.GithubQuickActions.Handles())
 }
 
 // ghQuickActions.payloadToCommands
@@ -79,6 +168,7 @@ func (ts *quickActionsTestSuite) TestPayloadToCommands() {
 /cmd#1 simple
 /cmd#2 "quoted arguments"
 /cmd#2 mixed "arguments" with simple and "quoted arguments"
+/cmd#2 "quoted" key="quoted value" other="single" simple=value
   /cmd#1 even with spaces ?
 `}
 
@@ -86,24 +176,254 @@ func (ts *quickActionsTestSuite) TestPayloadToCommands() {
 	ctx := noLog.WithContext(context.Background())
 
 	payload.eventType = "aaa"
-	commands := ts.GithubQuickActions.payloadToCommands(ctx, payload)
+	commands := ts.GithubQuickActions.payloadToCommands(ctx, payload, nil)
 
-	ts.Require().Len(commands, 4)
+	ts.Require().Len(commands, 5)
 	ts.Assert().Equal(EventCommand{Command: "cmd#1", Arguments: []string{}, Payload: payload}, *commands[0])
 	ts.Assert().Equal(EventCommand{Command: "cmd#1", Arguments: []string{"simple"}, Payload: payload}, *commands[1])
 	ts.Assert().Equal(EventCommand{Command: "cmd#2", Arguments: []string{"quoted arguments"}, Payload: payload}, *commands[2])
 	ts.Assert().Equal(EventCommand{Command: "cmd#2", Arguments: []string{"mixed", "arguments", "with", "simple", "and", "quoted arguments"}, Payload: payload}, *commands[3])
+	ts.Assert().Equal(EventCommand{Command: "cmd#2", Arguments: []string{"quoted", "key=quoted value", "other=single", "simple=value"}, Payload: payload}, *commands[4])
 
 	payload.eventType = "ccc"
-	commands = ts.GithubQuickActions.payloadToCommands(ctx, payload)
+	commands = ts.GithubQuickActions.payloadToCommands(ctx, payload, nil)
 
-	ts.Require().Len(commands, 2) // NOTE: `cmd#1` is only available for events `aaa` and `bbb`
+	ts.Require().Len(commands, 3) // NOTE: `cmd#1` is only available for events `aaa` and `bbb`
 	ts.Assert().Equal(EventCommand{Command: "cmd#2", Arguments: []string{"quoted arguments"}, Payload: payload}, *commands[0])
 	ts.Assert().Equal(EventCommand{Command: "cmd#2", Arguments: []string{"mixed", "arguments", "with", "simple", "and", "quoted arguments"}, Payload: payload}, *commands[1])
+	ts.Assert().Equal(EventCommand{Command: "cmd#2", Arguments: []string{"quoted", "key=quoted value", "other=single", "simple=value"}, Payload: payload}, *commands[2])
+}
+
+func (ts *quickActionsTestSuite) TestPayloadToCommands_markdown() {
+	ts.GithubQuickActions.AddQuickAction("cmd#1", &mockQuickAction{onEvents: []EventType{"aaa"}})
+
+	payload := mockEventPayload{eventType: "aaa", body: "" +
+		"> /cmd#1 quoted\r\n" +
+		"\r\n" +
+		"```\r\n" +
+		"/cmd#1 fenced\r\n" +
+		"```\r\n" +
+		"<!--\r\n" +
+		"/cmd#1 commented\r\n" +
+		"-->\r\n" +
+		"/cmd#1 valid <!-- commented -->\r\n",
+	}
+
+	noLog := zerolog.Nop()
+	ctx := noLog.WithContext(context.Background())
+
+	commands := ts.GithubQuickActions.payloadToCommands(ctx, payload, nil)
+	ts.Require().Len(commands, 1)
+	ts.Assert().Equal(EventCommand{Command: "cmd#1", Arguments: []string{"valid"}, Payload: payload}, *commands[0])
+}
+
+func (ts *quickActionsTestSuite) TestPayloadToCommands_edited() {
+	ts.GithubQuickActions.AddQuickAction("cmd#1", &mockQuickAction{onEvents: []EventType{"aaa"}})
+	ts.GithubQuickActions.AddQuickAction("cmd#2", &mockQuickAction{onEvents: []EventType{"aaa"}})
+
+	payload := mockEventPayload{
+		eventType: "aaa",
+		action:    EventActionEdited,
+		body:      "/cmd#1 simple\n/cmd#2 fixed\n/cmd#1 added",
+		prevBody:  "/cmd#1 simple\n/cmd#2 fxed",
+	}
+
+	noLog := zerolog.Nop()
+	ctx := noLog.WithContext(context.Background())
+
+	commands := ts.GithubQuickActions.payloadToCommands(ctx, payload, nil)
+	ts.Require().Len(commands, 2)
+	ts.Assert().Equal(EventCommand{Command: "cmd#2", Arguments: []string{"fixed"}, Payload: payload}, *commands[0])
+	ts.Assert().Equal(EventCommand{Command: "cmd#1", Arguments: []string{"added"}, Payload: payload}, *commands[1])
+}
+
+func (ts *quickActionsTestSuite) TestPayloadToCommands_alias() {
+	ts.GithubQuickActions.AddQuickAction("cmd#1", &mockDescribedQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{"aaa"}}, metadata: Metadata{Aliases: []string{"alias#1"}}})
+
+	payload := mockEventPayload{eventType: "aaa", body: "/alias#1 arg"}
+
+	noLog := zerolog.Nop()
+	ctx := noLog.WithContext(context.Background())
+
+	commands := ts.GithubQuickActions.payloadToCommands(ctx, payload, nil)
+	ts.Require().Len(commands, 1)
+	ts.Assert().Equal(EventCommand{Command: "cmd#1", Arguments: []string{"arg"}, Payload: payload}, *commands[0])
+}
+
+func (ts *quickActionsTestSuite) TestPayloadToCommands_config() {
+	ts.GithubQuickActions.AddQuickAction("cmd#1", &mockQuickAction{onEvents: []EventType{"aaa"}})
+	ts.GithubQuickActions.AddQuickAction("cmd#2", &mockQuickAction{onEvents: []EventType{"aaa"}})
+
+	config := &RepositoryConfig{Prefix: "!", Disabled: []string{"cmd#2"}, Aliases: map[string]string{"alias#1": "cmd#1"}}
+	payload := mockEventPayload{eventType: "aaa", body: "/cmd#1 ignored\n!cmd#1 simple\n!cmd#2 disabled\n!alias#1 aliased\n! no command"}
+
+	noLog := zerolog.Nop()
+	ctx := noLog.WithContext(context.Background())
+
+	commands := ts.GithubQuickActions.payloadToCommands(ctx, payload, config)
+	ts.Require().Len(commands, 2)
+	ts.Assert().Equal(EventCommand{Command: "cmd#1", Arguments: []string{"simple"}, Payload: payload}, *commands[0])
+	ts.Assert().Equal(EventCommand{Command: "cmd#1", Arguments: []string{"aliased"}, Payload: payload}, *commands[1])
+}
+
+// GithubQuickActions.QuickActions
+func (ts *quickActionsTestSuite) TestQuickActions() {
+	ts.GithubQuickActions.AddQuickAction("cmd#2", &mockQuickAction{onEvents: []EventType{"bbb", "aaa"}})
+	ts.GithubQuickActions.AddQuickAction("cmd#1", &mockDescribedQuickAction{
+		mockQuickAction: mockQuickAction{onEvents: []EventType{"aaa"}},
+		metadata:        Metadata{Usage: []string{"/cmd#1 <arg>"}, Description: "Some description.", Aliases: []string{"alias#1"}},
+	})
+
+	ts.Assert().Equal([]QuickActionInfo{
+		{Command: "cmd#1", Events: []EventType{"aaa"}, Metadata: Metadata{Usage: []string{"/cmd#1 <arg>"}, Description: "Some description.", Aliases: []string{"alias#1"}}},
+		{Command: "cmd#2", Events: []EventType{"aaa", "bbb"}, Metadata: Metadata{Usage: []string{"/cmd#2"}}},
+	}, ts.GithubQuickActions.QuickActions())
+	ts.Assert().Equal([]QuickActionInfo{
+		{Command: "cmd#2", Events: []EventType{"aaa", "bbb"}, Metadata: Metadata{Usage: []string{"/cmd#2"}}},
+	}, ts.GithubQuickActions.QuickActions("bbb", "ccc"))
+	ts.Assert().Empty(ts.GithubQuickActions.QuickActions("ccc"))
+}
+
+// GithubQuickActions.QuickAction
+func (ts *quickActionsTestSuite) TestQuickAction() {
+	ts.GithubQuickActions.AddQuickAction("cmd#1", &mockDescribedQuickAction{
+		mockQuickAction: mockQuickAction{onEvents: []EventType{"aaa", "bbb"}},
+		metadata:        Metadata{Aliases: []string{"alias#1"}},
+	})
+	ts.GithubQuickActions.AddQuickAction("cmd#2", &mockQuickActionWithArguments{
+		mockQuickAction: mockQuickAction{onEvents: []EventType{"aaa"}},
+		schema:          []ArgumentSpec{{Name: "user", Kind: ArgumentKindUser, Required: true}},
+	})
+
+	info, exists := ts.GithubQuickActions.QuickAction("bbb", "alias#1")
+	ts.Require().True(exists)
+	ts.Assert().Equal(QuickActionInfo{Command: "cmd#1", Events: []EventType{"aaa", "bbb"}, Metadata: Metadata{Usage: []string{"/cmd#1"}, Aliases: []string{"alias#1"}}}, info)
+
+	info, exists = ts.GithubQuickActions.QuickAction("aaa", "cmd#2")
+	ts.Require().True(exists)
+	ts.Assert().Equal([]string{"/cmd#2 @user"}, info.Usage)
+
+	_, exists = ts.GithubQuickActions.QuickAction("bbb", "cmd#2")
+	ts.Assert().False(exists)
 }
 
 func TestGithubQuickActionsSuite(t *testing.T) { suite.Run(t, new(quickActionsTestSuite)) }
 
+func TestParseCommandLine(t *testing.T) {
+	ts := map[string]struct {
+		line     string
+		expected []string
+		err      string
+	}{
+		"label":                {line: `/label ~bug "~needs info"`, expected: []string{"/label", "~bug", "~needs info"}},
+		"changelog":            {line: `/changelog fixed "Crash on empty comments"`, expected: []string{"/changelog", "fixed", "Crash on empty comments"}},
+		"poll":                 {line: `/poll "Which release name?" "Argon" "Boron"`, expected: []string{"/poll", "Which release name?", "Argon", "Boron"}},
+		"remind":               {line: `/remind me "check the release" in 3 days`, expected: []string{"/remind", "me", "check the release", "in", "3", "days"}},
+		"escaped quotes":       {line: `/changelog fixed "Handle ""quoted"" labels"`, expected: []string{"/changelog", "fixed", `Handle "quoted" labels`}},
+		"extra spaces":         {line: `/label   ~bug  `, expected: []string{"/label", "~bug"}},
+		"project":              {line: `/project "Roadmap" status="In progress" other="single" iteration=@current`, expected: []string{"/project", "Roadmap", "status=In progress", "other=single", "iteration=@current"}},
+		"quoted assignment":    {line: `/project "Roadmap" "status=In progress"`, expected: []string{"/project", "Roadmap", "status=In progress"}},
+		"equal in quoted item": {line: `/changelog fixed "Support a="b" in values"`, err: `extraneous or missing " in quoted-field`},
+		"bare quote":           {line: `/label ~"needs info"`, err: `bare " in non-quoted-field`},
+		"unclosed quote":       {line: `/poll "Which release name?`, err: `extraneous or missing " in quoted-field`},
+	}
+
+	for name, tc := range ts {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			args, err := parseCommandLine(tc.line)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, args)
+		})
+	}
+}
+
+func TestEventContext_Commands(t *testing.T) {
+	config := "prefix: \"!\"\naliases: {tag: label}\ndisabled: [unlabel]"
+
+	configRequests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/xunleii/github-quick-actions/contents/.github/quick-actions.yml":
+			configRequests++
+			_, _ = fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "sha": "sha", "content": %q}`, base64.StdEncoding.EncodeToString([]byte(config)))
+		case r.Method == http.MethodGet:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	label := &mockRestrictedQuickAction{mockQuickAction: mockQuickAction{onEvents: []EventType{EventTypeIssueComment}}}
+	handler := &mockCommandsEventHandler{onActions: map[EventType][]EventAction{EventTypeIssueComment: {EventActionCreated}}}
+
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+	qa.EnableRepositoryConfig()
+	qa.AddQuickAction("label", label)
+	qa.AddQuickAction("unlabel", &mockQuickAction{onEvents: []EventType{EventTypeIssueComment}})
+	qa.AddEventHandler("commands", handler)
+
+	// NOTE: the event handler gets the same commands as the quick actions,
+	//		 with a single configuration fetch
+	body := "!tag ~bug\n!unlabel ~wontfix\n/label ~ignored\n```\n!label ~quoted\n```\n> !label ~quoted"
+	err := qa.Handle(context.TODO(), "issue_comment", "", permissionPayload("octocat", "mojombo", body))
+	require.NoError(t, err)
+	require.Len(t, handler.commands, 1)
+	assert.Equal(t, "label", handler.commands[0].Command)
+	assert.Equal(t, []string{"~bug"}, handler.commands[0].Arguments)
+	assert.Equal(t, 1, label.handled)
+	assert.Equal(t, 1, configRequests)
+}
+
+func TestHandle_commandEventHandlers(t *testing.T) {
+	var config string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/xunleii/github-quick-actions/contents/.github/quick-actions.yml" && config != "":
+			_, _ = fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "sha": %q, "content": %q}`, config, base64.StdEncoding.EncodeToString([]byte(config)))
+		default:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	onActions := map[EventType][]EventAction{EventTypeIssueComment: {EventActionCreated}}
+	label := &mockCommandEventHandler{mockCommandsEventHandler: mockCommandsEventHandler{onActions: onActions}, command: "label"}
+	status := &mockCommandEventHandler{mockCommandsEventHandler: mockCommandsEventHandler{onActions: onActions}, command: "status", optIn: true}
+	other := &mockCommandsEventHandler{onActions: onActions}
+
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+	qa.EnableRepositoryConfig()
+	qa.AddQuickAction("label", &mockQuickAction{onEvents: []EventType{EventTypeIssueComment}})
+	qa.AddQuickAction("status", &mockQuickAction{onEvents: []EventType{EventTypeIssueComment}})
+	qa.AddEventHandler("label", label)
+	qa.AddEventHandler("status", status)
+	qa.AddEventHandler("other", other)
+
+	ts := []struct {
+		config string
+		calls  [3]int
+	}{
+		// NOTE: opt-in handlers are only run when explicitly enabled
+		{config: "", calls: [3]int{1, 0, 1}},
+		{config: "enabled: [status]", calls: [3]int{2, 1, 2}},
+		{config: "disabled: [label, status]\nenabled: [status]", calls: [3]int{2, 2, 3}},
+		// NOTE: handlers without command ignore the configuration
+		{config: "disabled: [\"*\"]", calls: [3]int{2, 2, 4}},
+	}
+	for _, tc := range ts {
+		config = tc.config
+		require.NoError(t, qa.Handle(context.TODO(), "issue_comment", "", permissionPayload("octocat", "mojombo", "no command")))
+		assert.Equal(t, tc.calls, [3]int{label.calls, status.calls, other.calls}, tc.config)
+	}
+}
+
 // mockQuickAction implements a simple QuickAction
 type mockQuickAction struct {
 	onEvents []EventType
@@ -114,3 +434,72 @@ func (m mockQuickAction) TriggerOnEvents() []EventType { return m.onEvents }
 func (m mockQuickAction) HandleCommand(ctx *EventContext, command *EventCommand) error {
 	return m.retErr
 }
+
+// mockDescribedQuickAction implements a simple QuickAction with metadata
+type mockDescribedQuickAction struct {
+	mockQuickAction
+	metadata Metadata
+}
+
+func (m mockDescribedQuickAction) Metadata() Metadata { return m.metadata }
+
+// mockEventHandler implements a simple EventHandler
+type mockEventHandler struct {
+	onActions map[EventType][]EventAction
+	retErr    error
+}
+
+func (m mockEventHandler) TriggerOnActions() map[EventType][]EventAction { return m.onActions }
+func (m mockEventHandler) HandleEvent(ctx *EventContext, payload EventPayload) error {
+	return m.retErr
+}
+
+// mockCommandsEventHandler implements a simple EventHandler, recording the
+// commands of the handled event
+type mockCommandsEventHandler struct {
+	onActions map[EventType][]EventAction
+	commands  []*EventCommand
+	calls     int
+}
+
+func (m *mockCommandsEventHandler) TriggerOnActions() map[EventType][]EventAction {
+	return m.onActions
+}
+func (m *mockCommandsEventHandler) HandleEvent(ctx *EventContext, payload EventPayload) error {
+	m.commands = ctx.Commands()
+	m.calls++
+	return nil
+}
+
+// mockCommandEventHandler implements a simple EventHandler belonging to a
+// command
+type mockCommandEventHandler struct {
+	mockCommandsEventHandler
+	command string
+	optIn   bool
+}
+
+func (m *mockCommandEventHandler) HandledCommand() (string, bool) { return m.command, m.optIn }
+
+// mockSweeper implements a simple Sweeper, recording all installations
+// swept
+type mockSweeper struct {
+	installations []int64
+	retErr        error
+}
+
+func (m *mockSweeper) Sweep(ctx *EventContext, installationID int64) error {
+	m.installations = append(m.installations, installationID)
+	return m.retErr
+}
+
+// mockJobRunner implements a simple JobRunner, recording all jobs run
+type mockJobRunner struct {
+	jobs   []string
+	retErr error
+}
+
+func (m *mockJobRunner) RunJob(ctx *EventContext, job *scheduler.Job) error {
+	m.jobs = append(m.jobs, job.ID)
+	return m.retErr
+}
@@ -0,0 +1,90 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+)
+
+// DefaultSweepInterval is the default minimum interval between two runs of
+// the sweepers.
+const DefaultSweepInterval = time.Hour
+
+// sweepSchedule tracks when the sweepers were last run by the scheduler.
+// NOTE: sweepers must be idempotent; instances don't share their schedule, so
+//		 sweepers can run more often when several instances are running.
+type sweepSchedule struct {
+	mx       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+// due returns true, and records the run, if the sweepers were not run since
+// the sweep interval.
+func (s *sweepSchedule) due(now time.Time) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if !s.last.IsZero() && now.Sub(s.last) < s.interval {
+		return false
+	}
+	s.last = now
+	return true
+}
+
+// SetSweepInterval defines the minimum interval between two runs of the
+// sweepers by the scheduler (see Tick).
+func (a *GithubQuickActions) SetSweepInterval(interval time.Duration) {
+	a.sweeps = &sweepSchedule{interval: interval}
+}
+
+// Sweep runs all registered sweepers on each installation of the Github
+// Application.
+func (a GithubQuickActions) Sweep(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	if len(a.sweepers) == 0 {
+		logger.Debug().Msgf("no sweeper registered, aborted")
+		return nil
+	}
+
+	client, err := a.cc.NewAppClient()
+	if err != nil {
+		return err
+	}
+
+	var installations []*github.Installation
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.Apps.ListInstallations(ctx, opts)
+		if err != nil {
+			return err
+		}
+		installations = append(installations, page...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	eventCtx := &EventContext{Context: ctx, ClientCreator: a.cc, Store: a.store, identity: a.identity}
+	errors := &multierror.Error{}
+
+	for _, installation := range installations {
+		for name, sweeper := range a.sweepers {
+			err := sweeper.Sweep(eventCtx, installation.GetID())
+			if err != nil {
+				logger.Error().Err(err).
+					Int64("installation_id", installation.GetID()).
+					Msgf("failed to run sweeper '%s': %s", name, err)
+				errors = multierror.Append(errors, err)
+			}
+		}
+	}
+
+	return errors.ErrorOrNil()
+}
@@ -0,0 +1,96 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAppClientCreator creates app clients using the given test server.
+type mockAppClientCreator struct {
+	githubapp.ClientCreator
+	srv *httptest.Server
+}
+
+func (cc mockAppClientCreator) NewAppClient() (*github.Client, error) {
+	client := github.NewClient(cc.srv.Client())
+	client.BaseURL, _ = url.Parse(cc.srv.URL + "/")
+	return client, nil
+}
+
+func (cc mockAppClientCreator) NewInstallationClient(int64) (*github.Client, error) {
+	return cc.NewAppClient()
+}
+
+func newSweepTestServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/app/installations?page=2>; rel="next"`, "http://"+r.Host))
+			_, _ = w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+		default:
+			_, _ = w.Write([]byte(`[{"id": 3}]`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSweep(t *testing.T) {
+	srv := newSweepTestServer(t)
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+
+	sweeper := &mockSweeper{}
+	qa.AddSweeper("swp#1", sweeper)
+
+	require.NoError(t, qa.Sweep(context.TODO()))
+	assert.Equal(t, []int64{1, 2, 3}, sweeper.installations)
+}
+
+func TestSweep_errors(t *testing.T) {
+	srv := newSweepTestServer(t)
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+
+	sweeper := &mockSweeper{retErr: fmt.Errorf("sweep failed")}
+	qa.AddSweeper("swp#1", sweeper)
+
+	err := qa.Sweep(context.TODO())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "3 errors occurred")
+	assert.Equal(t, []int64{1, 2, 3}, sweeper.installations)
+}
+
+func TestSweep_noSweeper(t *testing.T) {
+	// NOTE: without sweeper, no Github request should be done
+	qa := NewGithubQuickActions(nil)
+	assert.NoError(t, qa.Sweep(context.TODO()))
+}
+
+func TestTick_sweepers(t *testing.T) {
+	srv := newSweepTestServer(t)
+	qa := NewGithubQuickActions(mockAppClientCreator{srv: srv})
+	qa.SetSweepInterval(time.Hour)
+
+	sweeper := &mockSweeper{}
+	qa.AddSweeper("swp#1", sweeper)
+
+	// NOTE: sweepers are run on the first tick, then once per interval
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, qa.Tick(context.TODO(), now))
+	assert.Equal(t, []int64{1, 2, 3}, sweeper.installations)
+
+	require.NoError(t, qa.Tick(context.TODO(), now.Add(30*time.Minute)))
+	assert.Equal(t, []int64{1, 2, 3}, sweeper.installations)
+
+	require.NoError(t, qa.Tick(context.TODO(), now.Add(time.Hour)))
+	assert.Equal(t, []int64{1, 2, 3, 1, 2, 3}, sweeper.installations)
+}
@@ -0,0 +1,90 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+)
+
+// Tick runs all jobs due at the given time, then the sweepers if they were
+// not run since the sweep interval. This is the only entrypoint of the
+// scheduled tasks, called periodically by RunScheduler or by the serverless
+// platform scheduler.
+func (a GithubQuickActions) Tick(ctx context.Context, now time.Time) error {
+	errors := &multierror.Error{}
+	errors = multierror.Append(errors, a.runJobs(ctx, now))
+
+	if len(a.sweepers) > 0 && a.sweeps.due(now) {
+		errors = multierror.Append(errors, a.Sweep(ctx))
+	}
+	return errors.ErrorOrNil()
+}
+
+// jobClaimLease is the delay during which the jobs claimed by a tick are
+// not returned to other ticks (of this process or of another one sharing
+// the same store).
+const jobClaimLease = 5 * time.Minute
+
+// runJobs runs all jobs due at the given time. Jobs are claimed before being
+// run, so that each one is run by a single tick, then removed from the
+// store once successfully run; failed jobs are retried once the claim lease
+// expires.
+func (a GithubQuickActions) runJobs(ctx context.Context, now time.Time) error {
+	logger := zerolog.Ctx(ctx)
+	if a.store == nil {
+		logger.Debug().Msgf("no store configured, no job to run")
+		return nil
+	}
+
+	jobs, err := a.store.Claim(ctx, now, jobClaimLease)
+	if err != nil {
+		return err
+	}
+
+	eventCtx := &EventContext{Context: ctx, ClientCreator: a.cc, Store: a.store, identity: a.identity}
+	errors := &multierror.Error{}
+
+	for _, job := range jobs {
+		runner := a.runners[job.Kind]
+		if runner == nil {
+			// NOTE: jobs are kept in order to be run once the runner is
+			//		 available again (like after a rollback)
+			logger.Warn().Str("job_id", job.ID).Msgf("no job runner for '%s' jobs, ignored", job.Kind)
+			continue
+		}
+
+		err := runner.RunJob(eventCtx, job)
+		if err != nil {
+			logger.Error().Err(err).
+				Str("job_id", job.ID).
+				Int64("installation_id", job.InstallationID).
+				Msgf("failed to run '%s' job: %s", job.Kind, err)
+			errors = multierror.Append(errors, err)
+			continue
+		}
+
+		errors = multierror.Append(errors, a.store.Delete(ctx, job.ID))
+	}
+
+	return errors.ErrorOrNil()
+}
+
+// RunScheduler runs Tick at the given interval until the context is done.
+func (a GithubQuickActions) RunScheduler(ctx context.Context, interval time.Duration) {
+	logger := zerolog.Ctx(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := a.Tick(ctx, now.UTC()); err != nil {
+				logger.Error().Err(err).Msgf("failed to run scheduled tasks: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package gh_quick_actions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"xnku.be/github-quick-actions/pkg/scheduler"
+)
+
+func TestTick(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	store := scheduler.NewMemoryStore()
+	qa := NewGithubQuickActions(nil)
+	qa.SetStore(store)
+
+	runner := &mockJobRunner{}
+	failing := &mockJobRunner{retErr: fmt.Errorf("job failed")}
+	qa.AddJobRunner("run", runner)
+	qa.AddJobRunner("fail", failing)
+
+	jobs := []*scheduler.Job{
+		{Kind: "run", DueAt: now.Add(-time.Minute)},
+		{Kind: "run", DueAt: now.Add(time.Minute)},
+		{Kind: "fail", DueAt: now},
+		{Kind: "unknown", DueAt: now},
+	}
+	for _, job := range jobs {
+		require.NoError(t, store.Schedule(ctx, job))
+	}
+
+	err := qa.Tick(ctx, now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "job failed")
+	assert.Equal(t, []string{jobs[0].ID}, runner.jobs)
+	assert.Equal(t, []string{jobs[2].ID}, failing.jobs)
+
+	// NOTE: claimed jobs are not run again by another tick until the claim
+	//		 lease expires
+	require.NoError(t, qa.Tick(ctx, now))
+	assert.Equal(t, []string{jobs[0].ID}, runner.jobs)
+	assert.Equal(t, []string{jobs[2].ID}, failing.jobs)
+
+	// NOTE: successful jobs are removed, others are retried after the lease
+	due, err := store.Due(ctx, now.Add(jobClaimLease))
+	require.NoError(t, err)
+	var ids []string
+	for _, job := range due {
+		ids = append(ids, job.ID)
+	}
+	assert.ElementsMatch(t, []string{jobs[1].ID, jobs[2].ID, jobs[3].ID}, ids)
+
+	err = qa.Tick(ctx, now.Add(jobClaimLease))
+	require.Error(t, err)
+	assert.Equal(t, []string{jobs[0].ID, jobs[1].ID}, runner.jobs)
+	assert.Equal(t, []string{jobs[2].ID, jobs[2].ID}, failing.jobs)
+}
+
+func TestTick_noStore(t *testing.T) {
+	qa := NewGithubQuickActions(nil)
+	assert.NoError(t, qa.Tick(context.TODO(), time.Now()))
+}
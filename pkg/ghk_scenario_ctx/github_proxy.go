@@ -22,6 +22,9 @@ func NewGithubAPIProxy() *GithubAPIProxy {
 
 	apiProxy.Use(apiProxy.proxyMiddleware)
 	apiProxy.NotFoundHandler = apiProxy.proxyMiddleware(http.HandlerFunc(func(wr http.ResponseWriter, _ *http.Request) { wr.WriteHeader(http.StatusOK) }))
+	// NOTE: requests with another method than the replied one (like a PATCH
+	//		 on a replied GET) are handled like unknown requests
+	apiProxy.MethodNotAllowedHandler = apiProxy.NotFoundHandler
 
 	return apiProxy
 }
@@ -20,6 +20,15 @@ type (
 		client *http.Client
 	}
 
+	// ProxyEventHandler injects the same information than ProxyQuickAction,
+	// using the handler name as command.
+	ProxyEventHandler struct {
+		gh_quick_actions.EventHandler
+		name      string
+		eventType gh_quick_actions.EventType
+		client    *http.Client
+	}
+
 	ProxyQuickActionErr struct {
 		error
 		ctx *gh_quick_actions.EventCommand
@@ -51,7 +60,16 @@ func (action *ProxyQuickAction) HandleCommand(ctx *gh_quick_actions.EventContext
 	ctx.ClientCreator = &ClientCreator{client}
 	_, _ = client.Get("quick-action://localhost/triggered")
 
-	err := action.QuickAction.HandleCommand(ctx, command)
+	// NOTE: arguments are validated here because the proxy hides the
+	//		 arguments schema of the quick action; permissions are not
+	//		 checked, the proxy being seen as unrestricted
+	err := gh_quick_actions.ValidateArguments(action.QuickAction, command)
+	if err == nil {
+		err = gh_quick_actions.ResolveArguments(ctx, action.QuickAction, command)
+	}
+	if err == nil {
+		err = action.QuickAction.HandleCommand(ctx, command)
+	}
 	if err != nil {
 		return &ProxyQuickActionErr{
 			error: err,
@@ -61,6 +79,39 @@ func (action *ProxyQuickAction) HandleCommand(ctx *gh_quick_actions.EventContext
 	return nil
 }
 
+func (handler *ProxyEventHandler) TriggerOnActions() map[gh_quick_actions.EventType][]gh_quick_actions.EventAction {
+	// NOTE: only the event type given by the Gherkin rule is registered
+	return map[gh_quick_actions.EventType][]gh_quick_actions.EventAction{
+		handler.eventType: handler.EventHandler.TriggerOnActions()[handler.eventType],
+	}
+}
+
+func (handler *ProxyEventHandler) HandleEvent(ctx *gh_quick_actions.EventContext, payload gh_quick_actions.EventPayload) error {
+	// NOTE: event handlers are seen as commands without arguments
+	command := &gh_quick_actions.EventCommand{Command: handler.name, Arguments: []string{}, Payload: payload}
+
+	client := httpwares.WrapClient(handler.client, func(next http.RoundTripper) http.RoundTripper {
+		return httpwares.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Add(EventHeader, string(payload.Type()))
+			req.Header.Add(CommandHeader, handler.name)
+			req.Header.Add(ArgsHeader, "[]")
+
+			return next.RoundTrip(req)
+		})
+	})
+
+	ctx.ClientCreator = &ClientCreator{client}
+	_, _ = client.Get("quick-action://localhost/triggered")
+
+	err := handler.EventHandler.HandleEvent(ctx, payload)
+	if err != nil {
+		return &ProxyQuickActionErr{
+			error: err,
+			ctx:   command,
+		}
+	}
+	return nil
+}
 
 type ClientCreator struct{ *http.Client }
 
@@ -69,7 +120,7 @@ func (cc *ClientCreator) NewInstallationClient(installationID int64) (*github.Cl
 func (cc *ClientCreator) NewTokenSourceClient(ts oauth2.TokenSource) (*github.Client, error) { return cc.NewAppClient() }
 func (cc *ClientCreator) NewTokenClient(token string) (*github.Client, error) 				 { return cc.NewAppClient() }
 
-func (cc *ClientCreator) NewAppV4Client() (*githubv4.Client, error) 							 { panic("not implemented") }
-func (cc *ClientCreator) NewInstallationV4Client(installationID int64) (*githubv4.Client, error) { panic("not implemented") }
-func (cc *ClientCreator) NewTokenSourceV4Client(ts oauth2.TokenSource) (*githubv4.Client, error) { panic("not implemented") }
-func (cc *ClientCreator) NewTokenV4Client(token string) (*githubv4.Client, error) 				 { panic("not implemented") }
+func (cc *ClientCreator) NewAppV4Client() (*githubv4.Client, error) 							 { return githubv4.NewClient(cc.Client), nil }
+func (cc *ClientCreator) NewInstallationV4Client(installationID int64) (*githubv4.Client, error) { return cc.NewAppV4Client() }
+func (cc *ClientCreator) NewTokenSourceV4Client(ts oauth2.TokenSource) (*githubv4.Client, error) { return cc.NewAppV4Client() }
+func (cc *ClientCreator) NewTokenV4Client(token string) (*githubv4.Client, error) 				 { return cc.NewAppV4Client() }
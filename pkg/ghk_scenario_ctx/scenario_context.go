@@ -16,6 +16,7 @@ import (
 
 	gh_quick_actions "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
 	"xnku.be/github-quick-actions/pkg/ghk_scenario_ctx/httptest"
+	"xnku.be/github-quick-actions/pkg/scheduler"
 )
 
 type (
@@ -24,23 +25,33 @@ type (
 	QuickActionScenarioContext struct {
 		ghQuickActions *gh_quick_actions.GithubQuickActions
 		ghAPIProxy     *GithubAPIProxy
+		ghAPIReplies   map[string][]func(http.ResponseWriter)
 
 		errs []error
 	}
 )
 
 func ScenarioInitializer(quickActions map[string]gh_quick_actions.QuickAction) func(ctx *godog.ScenarioContext) {
+	return ScenarioInitializerWithHandlers(quickActions, nil)
+}
+
+// ScenarioInitializerWithHandlers works like ScenarioInitializer but also
+// manages event handlers.
+func ScenarioInitializerWithHandlers(quickActions map[string]gh_quick_actions.QuickAction, eventHandlers map[string]gh_quick_actions.EventHandler) func(ctx *godog.ScenarioContext) {
 	return func(ctx *godog.ScenarioContext) {
 		scenario := &QuickActionScenarioContext{
 			ghQuickActions: gh_quick_actions.NewGithubQuickActions(nil),
 			ghAPIProxy:     NewGithubAPIProxy(),
+			ghAPIReplies:   map[string][]func(http.ResponseWriter){},
 		}
+		scenario.ghQuickActions.SetStore(scheduler.NewMemoryStore())
 
 		srv := httptest.NewServer(scenario.ghAPIProxy)
 
 		// NOTE: generates some steps dynamically using registered quick actions
 		client := srv.Client()
 		for command, quickAction := range quickActions {
+			command, quickAction := command, quickAction
 			for _, eventType := range quickAction.TriggerOnEvents() {
 				ctx.Step(fmt.Sprintf("^quick action \"/%s\" is registered for \"%s\" events$", command, eventType), func() {
 					proxy := &ProxyQuickAction{
@@ -53,6 +64,23 @@ func ScenarioInitializer(quickActions map[string]gh_quick_actions.QuickAction) f
 			}
 		}
 
+		for name, eventHandler := range eventHandlers {
+			name, eventHandler := name, eventHandler
+			for eventType := range eventHandler.TriggerOnActions() {
+				eventType := eventType
+				ctx.Step(fmt.Sprintf("^event handler \"%s\" is registered for \"%s\" events$", name, eventType), func() {
+					proxy := &ProxyEventHandler{
+						EventHandler: eventHandler,
+						name:         name,
+						eventType:    eventType,
+						client:       client,
+					}
+
+					scenario.ghQuickActions.AddEventHandler(name, proxy)
+				})
+			}
+		}
+
 		// WHEN steps
 		ctx.Step(`^Github sends an event "([^"]*)" with$`, scenario.simulateGithubEvent)
 		ctx.Step(`^Github replies to '([A-Z]+) ([^']+)' with '(\d{3}) (.+)'$`, scenario.simulateGithubAPIReply)
@@ -67,6 +95,10 @@ func ScenarioInitializer(quickActions map[string]gh_quick_actions.QuickAction) f
 		ctx.Step(`^Github Quick Actions should handle command "/([^"]+)" for "([^"]+)" event with arguments (\[.+\]) but returns this error: '(.+)'$`, scenario.assertCommandTriggeredWithError)
 		ctx.Step(`^Github Quick Actions should handle command "/([^"]+)" for "([^"]+)" event without argument but returns this error: '(.+)'$`, scenario.assertNoArgCommandTriggeredWithError)
 
+		ctx.Step(`^Github Quick Actions should run event handler "([^"]+)" for "([^"]+)" event by sending these following requests$`, scenario.assertNoArgCommandTriggeredSuccessfully)
+		ctx.Step(`^Github Quick Actions should run event handler "([^"]+)" for "([^"]+)" event without sending anything$`, scenario.assertNoArgCommandTriggeredSuccessfullyWithoutRequest)
+		ctx.Step(`^Github Quick Actions should run event handler "([^"]+)" for "([^"]+)" event but returns this error: '(.+)'$`, scenario.assertNoArgCommandTriggeredWithError)
+
 		// DEBUG steps
 		ctx.Step(`^\(debug\) Show all intercepted requests$`, scenario.showAllRequests)
 	}
@@ -88,9 +120,13 @@ func (ctx *QuickActionScenarioContext) simulateGithubAPIReply(method, rawURL str
 
 	rkey := fmt.Sprintf("%s %s", method, url)
 
-	// NOTE: in order to use once each call, we need to link all handler to the
-	//		 next one until we reach the default handler (LIFO queue)
-	// WARN: the first tuple (method, url) is the default handler
+	// NOTE: in order to use once each reply, all replies for the same tuple
+	//		 (method, url) are queued (FIFO); the last one is kept as default
+	//		 reply for all next calls
+	reply := func(writer http.ResponseWriter) {
+		writer.WriteHeader(code)
+		_, _ = writer.Write([]byte(response))
+	}
 
 	route := ctx.ghAPIProxy.GetRoute(rkey)
 	if route == nil {
@@ -99,16 +135,13 @@ func (ctx *QuickActionScenarioContext) simulateGithubAPIReply(method, rawURL str
 			Methods(method).Host(url.Host).Path(url.Path)
 	}
 
-	prev := route.GetHandler()
+	ctx.ghAPIReplies[rkey] = append(ctx.ghAPIReplies[rkey], reply)
 	route.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-		if prev != nil {
-			prev.ServeHTTP(writer, request)
-			prev = nil
-			return
+		replies := ctx.ghAPIReplies[rkey]
+		if len(replies) > 1 {
+			ctx.ghAPIReplies[rkey] = replies[1:]
 		}
-
-		writer.WriteHeader(code)
-		_, _ = writer.Write([]byte(response))
+		replies[0](writer)
 	})
 	return nil
 }
@@ -0,0 +1,202 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// boltJobsBucket contains all jobs, indexed by due date (big endian
+	// Unix nanoseconds) followed by the job ID, in order to list due jobs
+	// with a cursor.
+	boltJobsBucket = []byte("jobs")
+	// boltIndexBucket contains the key of each job in boltJobsBucket,
+	// indexed by job ID.
+	boltIndexBucket = []byte("jobs_index")
+)
+
+// BoltStore implements a Store persisted in an embedded bbolt file.
+// bbolt locks the whole file while it is open, so the file is only opened
+// for the duration of each transaction; this allows several processes (like
+// serverless function instances sharing the same EFS volume) to use the
+// same file.
+type BoltStore struct {
+	path string
+	// mx serializes the transactions of this process, which would
+	// otherwise wait for each other through the file lock.
+	mx sync.Mutex
+}
+
+// boltLockTimeout is the maximum time to wait for the file lock held by
+// another process.
+const boltLockTimeout = 5 * time.Second
+
+// NewBoltStore creates the bbolt file at the given path, if missing.
+func NewBoltStore(path string) (*BoltStore, error) {
+	store := &BoltStore{path: path}
+
+	err := store.update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{boltJobsBucket, boltIndexBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize store '%s': %w", path, err)
+	}
+	return store, nil
+}
+
+// update runs the given function in a read-write transaction.
+func (s *BoltStore) update(fn func(*bolt.Tx) error) error {
+	return s.transaction(false, fn)
+}
+
+// view runs the given function in a read-only transaction.
+func (s *BoltStore) view(fn func(*bolt.Tx) error) error {
+	return s.transaction(true, fn)
+}
+
+// transaction opens the bbolt file, runs the given function in a
+// transaction and closes the file, releasing its lock.
+func (s *BoltStore) transaction(readOnly bool, fn func(*bolt.Tx) error) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	db, err := bolt.Open(s.path, 0600, &bolt.Options{Timeout: boltLockTimeout, ReadOnly: readOnly})
+	if err != nil {
+		return fmt.Errorf("failed to open store '%s': %w", s.path, err)
+	}
+
+	if readOnly {
+		err = db.View(fn)
+	} else {
+		err = db.Update(fn)
+	}
+	if cerr := db.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("failed to close store '%s': %w", s.path, cerr)
+	}
+	return err
+}
+
+// boltJobKey returns the key of a job in boltJobsBucket.
+func boltJobKey(dueAt time.Time, id string) []byte {
+	key := make([]byte, 8, 8+len(id))
+	binary.BigEndian.PutUint64(key, uint64(dueAt.UnixNano()))
+	return append(key, id...)
+}
+
+// Schedule implements Store.
+func (s *BoltStore) Schedule(_ context.Context, job *Job) error {
+	job.ID = uuid.NewString()
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+
+	return s.update(func(tx *bolt.Tx) error {
+		key := boltJobKey(job.DueAt, job.ID)
+		if err := tx.Bucket(boltJobsBucket).Put(key, raw); err != nil {
+			return err
+		}
+		return tx.Bucket(boltIndexBucket).Put([]byte(job.ID), key)
+	})
+}
+
+// Due implements Store.
+func (s *BoltStore) Due(_ context.Context, now time.Time) ([]*Job, error) {
+	var jobs []*Job
+	limit := boltJobKey(now, "")
+
+	err := s.view(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltJobsBucket).Cursor()
+		for key, raw := cursor.First(); key != nil && bytes.Compare(key[:8], limit) <= 0; key, raw = cursor.Next() {
+			job := &Job{}
+			if err := json.Unmarshal(raw, job); err != nil {
+				return fmt.Errorf("failed to decode job %s: %w", key[8:], err)
+			}
+			jobs = append(jobs, job)
+		}
+		return nil
+	})
+	return jobs, err
+}
+
+// Claim implements Store; jobs are read and postponed in the same
+// read-write transaction, which holds the file lock.
+func (s *BoltStore) Claim(_ context.Context, now time.Time, lease time.Duration) ([]*Job, error) {
+	var jobs []*Job
+	limit := boltJobKey(now, "")
+
+	err := s.update(func(tx *bolt.Tx) error {
+		bucket, index := tx.Bucket(boltJobsBucket), tx.Bucket(boltIndexBucket)
+
+		// NOTE: keys are copied because the bucket can't be modified while
+		//		 being iterated
+		var keys [][]byte
+		cursor := bucket.Cursor()
+		for key, raw := cursor.First(); key != nil && bytes.Compare(key[:8], limit) <= 0; key, raw = cursor.Next() {
+			job := &Job{}
+			if err := json.Unmarshal(raw, job); err != nil {
+				return fmt.Errorf("failed to decode job %s: %w", key[8:], err)
+			}
+			jobs = append(jobs, job)
+			keys = append(keys, append([]byte(nil), key...))
+		}
+
+		for i, job := range jobs {
+			claimed := *job
+			claimed.DueAt = now.Add(lease)
+			raw, err := json.Marshal(claimed)
+			if err != nil {
+				return fmt.Errorf("failed to encode job %s: %w", job.ID, err)
+			}
+
+			key := boltJobKey(claimed.DueAt, job.ID)
+			if err := bucket.Delete(keys[i]); err != nil {
+				return err
+			}
+			if err := bucket.Put(key, raw); err != nil {
+				return err
+			}
+			if err := index.Put([]byte(job.ID), key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Delete implements Store.
+func (s *BoltStore) Delete(_ context.Context, id string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		index := tx.Bucket(boltIndexBucket)
+		key := index.Get([]byte(id))
+		if key == nil {
+			return nil
+		}
+
+		if err := tx.Bucket(boltJobsBucket).Delete(key); err != nil {
+			return err
+		}
+		return index.Delete([]byte(id))
+	})
+}
+
+// Close implements Store; the file is already closed after each
+// transaction.
+func (s *BoltStore) Close() error { return nil }
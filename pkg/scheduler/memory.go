@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore implements a Store kept in memory; jobs are lost when the
+// process stops, so it must only be used for tests or ephemeral deployments.
+type MemoryStore struct {
+	mx   sync.Mutex
+	jobs map[string]Job
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore { return &MemoryStore{jobs: map[string]Job{}} }
+
+// Schedule implements Store.
+func (s *MemoryStore) Schedule(_ context.Context, job *Job) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	job.ID = uuid.NewString()
+	s.jobs[job.ID] = *job
+	return nil
+}
+
+// Due implements Store.
+func (s *MemoryStore) Due(_ context.Context, now time.Time) ([]*Job, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.due(now), nil
+}
+
+// Claim implements Store.
+func (s *MemoryStore) Claim(_ context.Context, now time.Time, lease time.Duration) ([]*Job, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	jobs := s.due(now)
+	for _, job := range jobs {
+		claimed := *job
+		claimed.DueAt = now.Add(lease)
+		s.jobs[job.ID] = claimed
+	}
+	return jobs, nil
+}
+
+// due returns all jobs due at the given time, ordered by due date; the
+// caller must hold the lock.
+func (s *MemoryStore) due(now time.Time) []*Job {
+	var jobs []*Job
+	for _, job := range s.jobs {
+		if !job.DueAt.After(now) {
+			job := job
+			jobs = append(jobs, &job)
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].DueAt.Equal(jobs[j].DueAt) {
+			return jobs[i].ID < jobs[j].ID
+		}
+		return jobs[i].DueAt.Before(jobs[j].DueAt)
+	})
+	return jobs
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	delete(s.jobs, id)
+	return nil
+}
+
+// Close implements Store.
+func (s *MemoryStore) Close() error { return nil }
@@ -0,0 +1,44 @@
+// Package scheduler stores the jobs scheduled by quick actions (like
+// reminders) until they are due.
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type (
+	// Job represents a task scheduled by a quick action, run once due.
+	Job struct {
+		// ID is generated by the store when the job is scheduled.
+		ID string `json:"id"`
+		// Kind identifies the job runner that must run the job.
+		Kind string `json:"kind"`
+		// InstallationID is the Github Application installation used to
+		// run the job.
+		InstallationID int64     `json:"installation_id"`
+		DueAt          time.Time `json:"due_at"`
+		// Data contains the job runner specific data.
+		Data json.RawMessage `json:"data,omitempty"`
+	}
+
+	// Store defines a durable storage of scheduled jobs. Implementations
+	// must be safe for concurrent use.
+	Store interface {
+		// Schedule stores the given job and sets its ID.
+		Schedule(ctx context.Context, job *Job) error
+		// Due returns all jobs due at the given time, ordered by due date.
+		Due(ctx context.Context, now time.Time) ([]*Job, error)
+		// Claim works like Due but also postpones the returned jobs by the
+		// given lease, atomically, so that they are not returned again (to
+		// this process or to another one) before the lease expires. Claimed
+		// jobs must be deleted once run.
+		Claim(ctx context.Context, now time.Time, lease time.Duration) ([]*Job, error)
+		// Delete removes the job with the given ID; removing an unknown job
+		// is not an error.
+		Delete(ctx context.Context, id string) error
+		// Close releases all resources used by the store.
+		Close() error
+	}
+)
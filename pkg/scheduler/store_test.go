@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(_ *testing.T) Store { return NewMemoryStore() },
+		"bolt": func(t *testing.T) Store {
+			store, err := NewBoltStore(filepath.Join(t.TempDir(), "jobs.db"))
+			require.NoError(t, err)
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		newStore := newStore
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+			store := newStore(t)
+			defer store.Close()
+
+			later := &Job{Kind: "remind", InstallationID: 1, DueAt: now.Add(time.Hour), Data: []byte(`{"text":"later"}`)}
+			due := &Job{Kind: "remind", InstallationID: 1, DueAt: now, Data: []byte(`{"text":"due"}`)}
+			late := &Job{Kind: "snooze", InstallationID: 2, DueAt: now.Add(-time.Hour)}
+			for _, job := range []*Job{later, due, late} {
+				require.NoError(t, store.Schedule(ctx, job))
+				require.NotEmpty(t, job.ID)
+			}
+
+			jobs, err := store.Due(ctx, now)
+			require.NoError(t, err)
+			require.Len(t, jobs, 2)
+			assert.Equal(t, late.ID, jobs[0].ID)
+			assert.Equal(t, "snooze", jobs[0].Kind)
+			assert.Equal(t, int64(2), jobs[0].InstallationID)
+			assert.Equal(t, due.ID, jobs[1].ID)
+			assert.JSONEq(t, `{"text":"due"}`, string(jobs[1].Data))
+			assert.True(t, jobs[1].DueAt.Equal(now))
+
+			require.NoError(t, store.Delete(ctx, late.ID))
+			require.NoError(t, store.Delete(ctx, "unknown"))
+
+			jobs, err = store.Due(ctx, now.Add(2*time.Hour))
+			require.NoError(t, err)
+			require.Len(t, jobs, 2)
+			assert.Equal(t, due.ID, jobs[0].ID)
+			assert.Equal(t, later.ID, jobs[1].ID)
+
+			// NOTE: claimed jobs are postponed until the lease expires
+			jobs, err = store.Claim(ctx, now, time.Minute)
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			assert.Equal(t, due.ID, jobs[0].ID)
+			assert.True(t, jobs[0].DueAt.Equal(now))
+			assert.JSONEq(t, `{"text":"due"}`, string(jobs[0].Data))
+
+			jobs, err = store.Claim(ctx, now, time.Minute)
+			require.NoError(t, err)
+			assert.Empty(t, jobs)
+
+			jobs, err = store.Due(ctx, now.Add(time.Minute))
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			assert.Equal(t, due.ID, jobs[0].ID)
+			assert.True(t, jobs[0].DueAt.Equal(now.Add(time.Minute)))
+
+			require.NoError(t, store.Delete(ctx, due.ID))
+			jobs, err = store.Due(ctx, now.Add(2*time.Hour))
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			assert.Equal(t, later.ID, jobs[0].ID)
+		})
+	}
+}
+
+func TestBoltStore_Persistence(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	store, err := NewBoltStore(path)
+	require.NoError(t, err)
+	job := &Job{Kind: "remind", InstallationID: 1, DueAt: now}
+	require.NoError(t, store.Schedule(ctx, job))
+	require.NoError(t, store.Close())
+
+	store, err = NewBoltStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	jobs, err := store.Due(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, job.ID, jobs[0].ID)
+}
+
+func TestBoltStore_SharedFile(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	// NOTE: like several function instances sharing the same volume, both
+	//		 stores must be usable at the same time
+	first, err := NewBoltStore(path)
+	require.NoError(t, err)
+	defer first.Close()
+	second, err := NewBoltStore(path)
+	require.NoError(t, err)
+	defer second.Close()
+
+	job := &Job{Kind: "remind", InstallationID: 1, DueAt: now}
+	require.NoError(t, first.Schedule(ctx, job))
+
+	jobs, err := second.Due(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, job.ID, jobs[0].ID)
+
+	// NOTE: a job claimed by one store is not claimed by the other one
+	jobs, err = second.Claim(ctx, now, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	jobs, err = first.Claim(ctx, now, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	require.NoError(t, second.Delete(ctx, job.ID))
+	jobs, err = first.Due(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
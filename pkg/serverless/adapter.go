@@ -2,6 +2,7 @@ package serverless
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -19,6 +20,7 @@ import (
 	quick_actions "xnku.be/github-quick-actions/internal/quick-actions"
 	"xnku.be/github-quick-actions/pkg/cmd"
 	appv2 "xnku.be/github-quick-actions/pkg/gh_quick_action/v2"
+	"xnku.be/github-quick-actions/pkg/scheduler"
 )
 
 func init() {
@@ -43,10 +45,18 @@ type (
 	// Adapter interfaces all serverless provider with the same proxy definition
 	Adapter interface {
 		ProxyWithContext(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+		// HandleWithContext handles any invocation of the serverless
+		// function: scheduled events run the scheduled jobs and other
+		// events are proxied like ProxyWithContext.
+		HandleWithContext(ctx context.Context, event json.RawMessage) (interface{}, error)
 
 		injectLogger(logger zerolog.Logger)
 		injectGithubApp(app http.Handler)
+		injectTicker(ticker Ticker)
 	}
+	// Ticker runs all scheduled tasks (jobs and sweepers) due at the given
+	// time
+	Ticker func(ctx context.Context, now time.Time) error
 	// AdapterOption extends the configuration of an Adapter
 	AdapterOption func(adapter Adapter)
 
@@ -54,11 +64,13 @@ type (
 	adapter struct {
 		logger *zerolog.Logger
 		app    http.Handler
+		ticker Ticker
 	}
 )
 
 func (a *adapter) injectLogger(logger zerolog.Logger) { a.logger = &logger }
 func (a *adapter) injectGithubApp(app http.Handler)   { a.app = app }
+func (a *adapter) injectTicker(ticker Ticker)         { a.ticker = ticker }
 
 func LoggerFromEnvironment() AdapterOption {
 	return func(adapter Adapter) {
@@ -158,9 +170,83 @@ func GithubApplicationFromEnvironment() AdapterOption {
 		githubQuickActions := appv2.NewGithubQuickActions(cc)
 		quick_actions.InjectAll(githubQuickActions)
 
+		if policy, exists := os.LookupEnv(cmd.EnvVarLabelPolicy); exists && policy != "" {
+			if err := quick_actions.SetDefaultLabelPolicy(policy); err != nil {
+				zerolog.DefaultContextLogger.
+					Fatal().Err(err).
+					Msgf("invalid environment variable '%s': %s", cmd.EnvVarLabelPolicy, err)
+			}
+		}
+
+		feedback, repositoryConfig := appv2.Feedback{Reactions: true}, true
+		for key, value := range map[string]*bool{
+			cmd.EnvVarFeedbackReactions: &feedback.Reactions,
+			cmd.EnvVarFeedbackReport:    &feedback.Report,
+			cmd.EnvVarRepositoryConfig:  &repositoryConfig,
+		} {
+			if raw, exists := os.LookupEnv(key); exists && raw != "" {
+				enabled, err := strconv.ParseBool(raw)
+				if err != nil {
+					zerolog.DefaultContextLogger.
+						Fatal().Err(err).
+						Msgf("invalid environment variable '%s': %s", key, err)
+				}
+				*value = enabled
+			}
+		}
+		githubQuickActions.SetFeedback(feedback)
+		if repositoryConfig {
+			githubQuickActions.EnableRepositoryConfig()
+		}
+
+		if endpoints, exists := os.LookupEnv(cmd.EnvVarPluginsEndpoints); exists && endpoints != "" {
+			plugins := appv2.PluginSettings{}
+			if plugins.Endpoints, err = cmd.ParsePluginsEndpoints(endpoints); err != nil {
+				zerolog.DefaultContextLogger.
+					Fatal().Err(err).
+					Msgf("invalid environment variable '%s': %s", cmd.EnvVarPluginsEndpoints, err)
+			}
+			if timeout, exists := os.LookupEnv(cmd.EnvVarPluginsTimeout); exists && timeout != "" {
+				if plugins.Timeout, err = time.ParseDuration(timeout); err != nil {
+					zerolog.DefaultContextLogger.
+						Fatal().Err(err).
+						Msgf("invalid environment variable '%s': %s", cmd.EnvVarPluginsTimeout, err)
+				}
+			}
+			githubQuickActions.EnablePlugins(plugins)
+		}
+
 		zerolog.DefaultContextLogger.WithLevel(zerolog.InfoLevel).
 			Msgf("prepare application event dispatcher")
 
+		// NOTE: the store must be on a durable storage (like EFS), shared
+		//		 by all function instances; the function local storage is
+		//		 lost after each cold start.
+		if path, exists := os.LookupEnv(cmd.EnvVarStorePath); exists && path != "" {
+			store, err := scheduler.NewBoltStore(path)
+			if err != nil {
+				zerolog.DefaultContextLogger.
+					Fatal().Err(err).
+					Msgf("failed to open store: %s", err)
+			}
+			githubQuickActions.SetStore(store)
+		} else {
+			zerolog.DefaultContextLogger.
+				Warn().
+				Msgf("environment variable '%s' not set; scheduled jobs (like /remind) are disabled", cmd.EnvVarStorePath)
+		}
+
+		if interval, exists := os.LookupEnv(cmd.EnvVarSweepInterval); exists && interval != "" {
+			sweepInterval, err := time.ParseDuration(interval)
+			if err != nil {
+				zerolog.DefaultContextLogger.
+					Fatal().Err(err).
+					Msgf("invalid environment variable '%s': %s", cmd.EnvVarSweepInterval, err)
+			}
+			githubQuickActions.SetSweepInterval(sweepInterval)
+		}
+		adapter.injectTicker(githubQuickActions.Tick)
+
 		adapter.injectGithubApp(githubapp.NewEventDispatcher(
 			[]githubapp.EventHandler{githubQuickActions},
 			appConfig.App.WebhookSecret,
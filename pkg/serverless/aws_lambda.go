@@ -2,6 +2,8 @@ package serverless
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/awslabs/aws-lambda-go-api-proxy/gorillamux"
@@ -38,3 +40,28 @@ func (a AWSLambdaAdapter) ProxyWithContext(ctx context.Context, event events.API
 	a.logger.Trace().Interface("event", event).Send()
 	return a.mux.ProxyWithContext(a.logger.WithContext(ctx), event)
 }
+
+// HandleWithContext implements Adapter.HandleWithContext; scheduled events
+// are sent by an EventBridge rule (see deployments/aws_lambda).
+func (a AWSLambdaAdapter) HandleWithContext(ctx context.Context, event json.RawMessage) (interface{}, error) {
+	var scheduled events.CloudWatchEvent
+	if err := json.Unmarshal(event, &scheduled); err == nil && scheduled.DetailType == "Scheduled Event" {
+		return nil, a.TickWithContext(a.logger.WithContext(ctx), scheduled.Time)
+	}
+
+	var request events.APIGatewayProxyRequest
+	if err := json.Unmarshal(event, &request); err != nil {
+		return nil, err
+	}
+	return a.ProxyWithContext(ctx, request)
+}
+
+// TickWithContext runs all scheduled tasks (jobs and sweepers) due at the
+// given time.
+func (a AWSLambdaAdapter) TickWithContext(ctx context.Context, now time.Time) error {
+	if a.adapter.ticker == nil {
+		a.logger.Warn().Msgf("scheduled event received but no scheduler configured, ignored")
+		return nil
+	}
+	return a.adapter.ticker(ctx, now.UTC())
+}